@@ -0,0 +1,58 @@
+package topologicalsort
+
+// Intersect returns a new graph holding the vertices and edges which are part of both g and other.
+// Vertices keep their Data from g, and the new graph has g's options. Edges are compared by Source and Dest, ignoring their labels.
+func (g *Graph[T]) Intersect(other *Graph[T]) *Graph[T] {
+	return g.restrict(other.HasVertex, func(e Edge) bool {
+		return other.hasEdge(e.Source, e.Dest)
+	})
+}
+
+// Subtract returns a new graph holding the edges of g which are not part of other, along with the vertices of g which are not part of other
+// or are needed by one of those edges. Computing after.Subtract(before) gives the dependencies (and vertices) that after introduced.
+// Vertices keep their Data from g, and the new graph has g's options. Edges are compared by Source and Dest, ignoring their labels.
+func (g *Graph[T]) Subtract(other *Graph[T]) *Graph[T] {
+	needed := make(map[string]bool)
+	for _, e := range g.Edges() {
+		if !other.hasEdge(e.Source, e.Dest) {
+			needed[e.Source] = true
+			needed[e.Dest] = true
+		}
+	}
+	return g.restrict(func(key string) bool {
+		return needed[key] || !other.HasVertex(key)
+	}, func(e Edge) bool {
+		return !other.hasEdge(e.Source, e.Dest)
+	})
+}
+
+// restrict returns a copy of g with only the vertices and edges for which keepVertex and keepEdge return true, in registration order.
+// Edges between vertices which aren't kept are dropped regardless of keepEdge.
+func (g *Graph[T]) restrict(keepVertex func(key string) bool, keepEdge func(e Edge) bool) *Graph[T] {
+	restricted := newGraph[T](g.config)
+	for _, node := range g.nodes {
+		if keepVertex(node.Key) {
+			// can't fail: the keys are unique, and there are at most as many as in g
+			_ = restricted.RegisterVertex(node.Key, node.Data)
+		}
+	}
+	for id, dests := range g.adjacency {
+		for i, dest := range dests {
+			e := Edge{Source: g.nodes[id].Key, Dest: g.nodes[dest].Key, Label: g.edgeLabel(int32(id), i)}
+			if restricted.HasVertex(e.Source) && restricted.HasVertex(e.Dest) && keepEdge(e) {
+				_ = restricted.addEdge(e)
+			}
+		}
+	}
+	return restricted
+}
+
+// hasEdge reports whether source depends directly on dest
+func (g *Graph[T]) hasEdge(source, dest string) bool {
+	sourceID, ok := g.id(source)
+	if !ok {
+		return false
+	}
+	destID, ok := g.id(dest)
+	return ok && containsID(g.adjacency[sourceID], destID)
+}
@@ -0,0 +1,78 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph_IntersectAndSubtract(t *testing.T) {
+	before := graphWithVerticesDUMMYDATA(map[string][]string{
+		"app":    {"http", "log"},
+		"http":   {"log"},
+		"log":    {},
+		"legacy": {},
+	}, "")
+	after := graphWithVerticesDUMMYDATA(map[string][]string{
+		"app":  {"http", "log", "yaml"},
+		"http": {},
+		"log":  {},
+		"yaml": {"log"},
+	}, "")
+
+	tests := []struct {
+		name      string
+		graph     *Graph[string]
+		wantKeys  []string
+		wantEdges []Edge
+	}{
+		{
+			name:      "Intersect",
+			graph:     after.Intersect(before),
+			wantKeys:  []string{"app", "http", "log"},
+			wantEdges: []Edge{{Source: "app", Dest: "http"}, {Source: "app", Dest: "log"}},
+		},
+		{
+			name:      "Subtract the old graph from the new one",
+			graph:     after.Subtract(before),
+			wantKeys:  []string{"app", "log", "yaml"},
+			wantEdges: []Edge{{Source: "app", Dest: "yaml"}, {Source: "yaml", Dest: "log"}},
+		},
+		{
+			name:      "Subtract the new graph from the old one",
+			graph:     before.Subtract(after),
+			wantKeys:  []string{"http", "legacy", "log"},
+			wantEdges: []Edge{{Source: "http", Dest: "log"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.graph.sortedVertexKeys(); !reflect.DeepEqual(got, tt.wantKeys) {
+				t.Errorf("vertices = %v, want %v", got, tt.wantKeys)
+			}
+			if got := tt.graph.Edges(); !reflect.DeepEqual(got, tt.wantEdges) {
+				t.Errorf("Graph.Edges() = %v, want %v", got, tt.wantEdges)
+			}
+		})
+	}
+}
+
+func TestGraph_IntersectKeepsDataAndLabels(t *testing.T) {
+	graph := NewGraphWithOptions[int]()
+	graph.RegisterVertex("one", 1)
+	graph.RegisterVertex("two", 2)
+	graph.AddEdges(Edge{Source: "two", Dest: "one", Label: "imports"})
+
+	other := NewGraphWithOptions[int]()
+	other.RegisterVertex("one", 100)
+	other.RegisterVertex("two", 200)
+	other.AddEdge("two", "one")
+
+	intersection := graph.Intersect(other)
+	if node, _ := intersection.Vertex("two"); node.Data != 2 {
+		t.Errorf("Graph.Intersect() vertex two has Data %d, want 2", node.Data)
+	}
+	want := []Edge{{Source: "two", Dest: "one", Label: "imports"}}
+	if got := intersection.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.Intersect() edges = %v, want %v", got, want)
+	}
+}
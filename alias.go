@@ -0,0 +1,33 @@
+package topologicalsort
+
+// AddAlias makes alias another name for the vertex registered under canonical, e.g. for a renamed or virtual package:
+// edges, lookups and sorts treat alias exactly like canonical, and report the vertex under canonical.
+// canonical can be an alias itself. It returns an error if canonical is unregistered, or alias is already taken by a vertex or another alias.
+func (g *Graph[T]) AddAlias(alias, canonical string) error {
+	id, ok := g.id(canonical)
+	if !ok {
+		return vertexError(canonical, "attempted to add alias %s of unregistered vertex %s", alias, canonical)
+	}
+	canonical = g.nodes[id].Key
+	if existing, ok := g.id(alias); ok {
+		if existing == id && g.config.duplicateVertices != RejectDuplicates {
+			return nil
+		}
+		return vertexError(alias, "attempted to add alias %s, which is already taken by vertex %s", alias, g.nodes[existing].Key)
+	}
+
+	g.ids[g.normalizeKey(alias)] = id
+	g.aliases[g.normalizeKey(alias)] = canonical
+	// optional dependencies on alias are met now
+	return g.addPendingOptional(alias)
+}
+
+// Aliases returns the aliases added with [Graph.AddAlias], mapped to the key of the vertex they name.
+// Aliases are listed the way the graph looks them up, so they're normalized if the graph was created with [WithKeyNormalizer].
+func (g *Graph[T]) Aliases() map[string]string {
+	aliases := make(map[string]string, len(g.aliases))
+	for alias, canonical := range g.aliases {
+		aliases[alias] = canonical
+	}
+	return aliases
+}
@@ -0,0 +1,104 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAddAlias(t *testing.T) {
+	graph := NewGraphWithOptions[string]()
+	for _, k := range []string{"libssl3", "curl", "wget"} {
+		if err := graph.RegisterVertex(k, k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := graph.AddAlias("openssl", "libssl3"); err != nil {
+		t.Fatalf("AddAlias returned unexpected error: %v", err)
+	}
+	// an alias of an alias names the same vertex
+	if err := graph.AddAlias("ssl", "openssl"); err != nil {
+		t.Fatalf("AddAlias returned unexpected error: %v", err)
+	}
+
+	if err := graph.AddDependency("curl", "openssl"); err != nil {
+		t.Fatalf("AddDependency returned unexpected error: %v", err)
+	}
+	if err := graph.AddDependency("wget", "ssl"); err != nil {
+		t.Fatalf("AddDependency returned unexpected error: %v", err)
+	}
+	if err := graph.AddDependency("curl", "libssl3"); err == nil {
+		t.Errorf("AddDependency should have rejected an edge duplicating one added through an alias")
+	}
+
+	wantEdges := []Edge{{Source: "curl", Dest: "libssl3"}, {Source: "wget", Dest: "libssl3"}}
+	if got := graph.Edges(); !reflect.DeepEqual(got, wantEdges) {
+		t.Errorf("Edges() = %v, want %v", got, wantEdges)
+	}
+	if node, ok := graph.Vertex("ssl"); !ok || node.Key != "libssl3" {
+		t.Errorf("Vertex(%q) = %v, %v, want the vertex registered as %q", "ssl", node, ok, "libssl3")
+	}
+
+	got, err := graph.TopologicalSortFor("wget")
+	if err != nil {
+		t.Fatalf("TopologicalSortFor returned unexpected error: %v", err)
+	}
+	want := []string{"libssl3", "wget"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TopologicalSortFor(%q) = %v, want %v", "wget", got, want)
+	}
+
+	wantAliases := map[string]string{"openssl": "libssl3", "ssl": "libssl3"}
+	if got := graph.Aliases(); !reflect.DeepEqual(got, wantAliases) {
+		t.Errorf("Aliases() = %v, want %v", got, wantAliases)
+	}
+}
+
+func TestAddAliasErrors(t *testing.T) {
+	graph := NewGraphWithOptions[string]()
+	for _, k := range []string{"a", "b"} {
+		if err := graph.RegisterVertex(k, k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := graph.AddAlias("x", "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		fn   func() error
+	}{
+		{name: "Unregistered canonical vertex", fn: func() error { return graph.AddAlias("y", "missing") }},
+		{name: "Alias taken by a vertex", fn: func() error { return graph.AddAlias("b", "a") }},
+		{name: "Alias taken by another alias", fn: func() error { return graph.AddAlias("x", "b") }},
+		{name: "Registering a vertex under an alias", fn: func() error { return graph.RegisterVertex("x", "x") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fn(); err == nil {
+				t.Errorf("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestAddAliasMeetsOptionalDependency(t *testing.T) {
+	graph := NewGraphWithOptions[string](WithKeyNormalizer(strings.ToLower))
+	for _, k := range []string{"plugin", "core-v2"} {
+		if err := graph.RegisterVertex(k, k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := graph.AddOptionalDependency("plugin", "core"); err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.AddAlias("Core", "core-v2"); err != nil {
+		t.Fatalf("AddAlias returned unexpected error: %v", err)
+	}
+
+	want := []Edge{{Source: "plugin", Dest: "core-v2"}}
+	if got := graph.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Edges() = %v, want %v", got, want)
+	}
+}
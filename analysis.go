@@ -0,0 +1,207 @@
+package topologicalsort
+
+import (
+	"sort"
+	"strings"
+)
+
+// RootGroup is a set of vertices which are reachable from exactly the same set of roots
+type RootGroup struct {
+	Roots []string
+	Keys  []string
+}
+
+// Roots returns the sorted keys of all vertices which nothing depends on (the entry points of the graph)
+func (g *Graph[T]) Roots() []string {
+	hasDependents := make(map[string]bool)
+	for _, dests := range g.adjacency {
+		for _, dest := range dests {
+			hasDependents[g.nodes[dest].Key] = true
+		}
+	}
+
+	roots := make([]string, 0)
+	for _, node := range g.nodes {
+		if !hasDependents[node.Key] {
+			roots = append(roots, node.Key)
+		}
+	}
+	g.sortKeys(roots)
+	return roots
+}
+
+// GroupByRoots groups vertices by the exact set of roots that can reach them (a root reaches itself), showing which entry points each vertex serves.
+// Groups are sorted by their roots; vertices only reachable from a cycle end up in a group with no roots.
+func (g *Graph[T]) GroupByRoots() []RootGroup {
+	reachedBy := make(map[string][]string, len(g.nodes))
+	for _, node := range g.nodes {
+		reachedBy[node.Key] = []string{}
+	}
+
+	// roots are sorted, so every reachedBy slice ends up sorted too
+	for _, root := range g.Roots() {
+		seen := map[string]bool{root: true}
+		stack := []string{root}
+		for len(stack) > 0 {
+			k := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			reachedBy[k] = append(reachedBy[k], root)
+
+			for _, dest := range g.dependencyNodes(k) {
+				if !seen[dest.Key] {
+					seen[dest.Key] = true
+					stack = append(stack, dest.Key)
+				}
+			}
+		}
+	}
+
+	byRoots := make(map[string]*RootGroup)
+	for k, roots := range reachedBy {
+		// NUL can't clash with anything a sane key contains
+		id := strings.Join(roots, "\x00")
+		group, ok := byRoots[id]
+		if !ok {
+			group = &RootGroup{Roots: roots, Keys: []string{}}
+			byRoots[id] = group
+		}
+		group.Keys = append(group.Keys, k)
+	}
+
+	ids := make([]string, 0, len(byRoots))
+	for id := range byRoots {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	groups := make([]RootGroup, len(ids))
+	for i, id := range ids {
+		sort.Strings(byRoots[id].Keys)
+		groups[i] = *byRoots[id]
+	}
+	return groups
+}
+
+// Descendants returns the vertices key depends on, directly or transitively, grouped by distance:
+// the first group holds its direct dependencies, the second group their dependencies, and so on.
+// A maxDepth of 0 means no limit. Every vertex is listed once, at its shortest distance, and groups are sorted.
+func (g *Graph[T]) Descendants(key string, maxDepth int) ([][]string, error) {
+	if !g.HasVertex(key) {
+		return [][]string{}, vertexError(key, "attempted to find descendants of unregistered vertex %s", key)
+	}
+	key = g.canonicalKey(key)
+	return breadthFirstGroups(key, maxDepth, g.dependencyKeys, g.sortKeys), nil
+}
+
+// Ancestors returns the vertices which depend on key, directly or transitively, grouped by distance:
+// the first group holds its direct dependents, the second group their dependents, and so on.
+// A maxDepth of 0 means no limit. Every vertex is listed once, at its shortest distance, and groups are sorted.
+func (g *Graph[T]) Ancestors(key string, maxDepth int) ([][]string, error) {
+	if !g.HasVertex(key) {
+		return [][]string{}, vertexError(key, "attempted to find ancestors of unregistered vertex %s", key)
+	}
+	key = g.canonicalKey(key)
+	dependents := g.dependentsOf()
+	return breadthFirstGroups(key, maxDepth, func(k string) []string {
+		return dependents[k]
+	}, g.sortKeys), nil
+}
+
+// MustPrecede reports whether the edges force a to come before b in every topological order, because b depends on a, directly or transitively.
+// If it's false, a and b are either unrelated, so they can be reordered freely, or b must come before a (see MustPrecede(b, a)).
+// It returns an error if either vertex is unregistered, or if a and b depend on each other through a cycle.
+func (g *Graph[T]) MustPrecede(a, b string) (bool, error) {
+	for _, k := range []string{a, b} {
+		if !g.HasVertex(k) {
+			return false, vertexError(k, "attempted to compare order of unregistered vertex %s", k)
+		}
+	}
+	a, b = g.canonicalKey(a), g.canonicalKey(b)
+	if a == b {
+		return false, nil
+	}
+	if !g.reaches(b, a) {
+		return false, nil
+	}
+	if g.reaches(a, b) {
+		return false, edgeError(b, a, "", "%w: vertices %s and %s depend on each other", ErrCycle, a, b)
+	}
+	return true, nil
+}
+
+// reaches reports whether source depends on dest, directly or transitively
+func (g *Graph[T]) reaches(source, dest string) bool {
+	sourceID, _ := g.id(source)
+	destID, _ := g.id(dest)
+	seen := make([]bool, len(g.nodes))
+	seen[sourceID] = true
+	stack := []int32{sourceID}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, dep := range g.adjacency[id] {
+			if dep == destID {
+				return true
+			}
+			if !seen[dep] {
+				seen[dep] = true
+				stack = append(stack, dep)
+			}
+		}
+	}
+	return false
+}
+
+// dependencyClosure returns, indexed by vertex ID, which vertices key depends on, directly or transitively
+func (g *Graph[T]) dependencyClosure(key string) []bool {
+	closure := make([]bool, len(g.nodes))
+	id, ok := g.id(key)
+	if !ok {
+		return closure
+	}
+	stack := []int32{id}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, dep := range g.adjacency[id] {
+			if !closure[dep] {
+				closure[dep] = true
+				stack = append(stack, dep)
+			}
+		}
+	}
+	return closure
+}
+
+// breadthFirstGroups walks outwards from start using next, and returns the vertices found grouped by distance, each group sorted by sortKeys
+func breadthFirstGroups(start string, maxDepth int, next func(string) []string, sortKeys func([]string)) [][]string {
+	groups := make([][]string, 0)
+	seen := map[string]bool{start: true}
+	frontier := []string{start}
+
+	for len(frontier) > 0 && (maxDepth <= 0 || len(groups) < maxDepth) {
+		group := make([]string, 0)
+		for _, k := range frontier {
+			for _, n := range next(k) {
+				if !seen[n] {
+					seen[n] = true
+					group = append(group, n)
+				}
+			}
+		}
+		if len(group) == 0 {
+			break
+		}
+		sortKeys(group)
+		groups = append(groups, group)
+		frontier = group
+	}
+	return groups
+}
+
+// Depth returns the length of the longest dependency chain in the graph (counted in vertices, so it equals the number of [Graph.Levels]),
+// along with that chain, dependencies first. Ties are broken by key, so the chain is stable. It returns an error if the graph contains a cycle.
+func (g *Graph[T]) Depth() (int, []string, error) {
+	// the longest chain is the heaviest one when every vertex weighs the same
+	return g.CriticalPath(func(*GraphNode[T]) int { return 1 })
+}
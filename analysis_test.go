@@ -0,0 +1,238 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph_Roots(t *testing.T) {
+	g := graphWithVerticesDUMMYDATA(map[string][]string{
+		"build-essential": {"make", "gcc"},
+		"make":            {"gcc"},
+		"gcc":             {"libc"},
+		"libc":            {},
+		"vim":             {"libc"},
+	}, "")
+	if got, want := g.Roots(), []string{"build-essential", "vim"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.Roots() = %v, want %v", got, want)
+	}
+}
+
+func TestGraph_GroupByRoots(t *testing.T) {
+	tests := []struct {
+		name           string
+		adjacency_list map[string][]string
+		want           []RootGroup
+	}{
+		{
+			name:           "A graph with no vertices has no groups",
+			adjacency_list: map[string][]string{},
+			want:           []RootGroup{},
+		},
+		{
+			name: "Shared dependencies are grouped separately from exclusive ones",
+			adjacency_list: map[string][]string{
+				"web":    {"http", "log"},
+				"worker": {"queue", "log"},
+				"http":   {},
+				"queue":  {},
+				"log":    {"fmt"},
+				"fmt":    {},
+			},
+			want: []RootGroup{
+				{Roots: []string{"web"}, Keys: []string{"http", "web"}},
+				{Roots: []string{"web", "worker"}, Keys: []string{"fmt", "log"}},
+				{Roots: []string{"worker"}, Keys: []string{"queue", "worker"}},
+			},
+		},
+		{
+			name: "Vertices only reachable from a cycle have no roots",
+			adjacency_list: map[string][]string{
+				"one":   {"two"},
+				"two":   {"one"},
+				"three": {},
+			},
+			want: []RootGroup{
+				{Roots: []string{}, Keys: []string{"one", "two"}},
+				{Roots: []string{"three"}, Keys: []string{"three"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphWithVerticesDUMMYDATA(tt.adjacency_list, "")
+			if got := g.GroupByRoots(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graph.GroupByRoots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraph_DescendantsAndAncestors(t *testing.T) {
+	g := graphWithVerticesDUMMYDATA(map[string][]string{
+		"build-essential": {"make", "gcc"},
+		"make":            {"gcc"},
+		"gcc":             {"libc"},
+		"libc":            {},
+		"vim":             {"libc"},
+	}, "")
+
+	tests := []struct {
+		name     string
+		query    func(key string, maxDepth int) ([][]string, error)
+		key      string
+		maxDepth int
+		want     [][]string
+		wantErr  bool
+	}{
+		{
+			name:  "Descendants are grouped by shortest distance",
+			query: g.Descendants,
+			key:   "build-essential",
+			want:  [][]string{{"gcc", "make"}, {"libc"}},
+		},
+		{
+			name:     "Descendants can be depth-limited",
+			query:    g.Descendants,
+			key:      "build-essential",
+			maxDepth: 1,
+			want:     [][]string{{"gcc", "make"}},
+		},
+		{
+			name:  "A vertex without dependencies has no descendants",
+			query: g.Descendants,
+			key:   "libc",
+			want:  [][]string{},
+		},
+		{
+			name:  "Ancestors are grouped by shortest distance",
+			query: g.Ancestors,
+			key:   "libc",
+			want:  [][]string{{"gcc", "vim"}, {"build-essential", "make"}},
+		},
+		{
+			name:     "Ancestors can be depth-limited",
+			query:    g.Ancestors,
+			key:      "libc",
+			maxDepth: 1,
+			want:     [][]string{{"gcc", "vim"}},
+		},
+		{
+			name:    "Unregistered vertices trigger an error",
+			query:   g.Ancestors,
+			key:     "emacs",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.query(tt.key, tt.maxDepth)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraph_Depth(t *testing.T) {
+	tests := []struct {
+		name           string
+		adjacency_list map[string][]string
+		wantDepth      int
+		wantPath       []string
+		wantErr        bool
+	}{
+		{
+			name:           "A graph with no vertices has no depth",
+			adjacency_list: map[string][]string{},
+			wantPath:       []string{},
+		},
+		{
+			name:           "A graph with one vertex has depth one",
+			adjacency_list: map[string][]string{"one": {}},
+			wantDepth:      1,
+			wantPath:       []string{"one"},
+		},
+		{
+			name: "Package manager example from cmd",
+			adjacency_list: map[string][]string{
+				"build-essential": {"make", "gcc"},
+				"make":            {"gcc"},
+				"gcc":             {"libc"},
+				"libc":            {},
+				"vim":             {"libc"},
+			},
+			wantDepth: 4,
+			wantPath:  []string{"libc", "gcc", "make", "build-essential"},
+		},
+		{
+			name: "Ties are broken by key",
+			adjacency_list: map[string][]string{
+				"top": {"b", "a"},
+				"a":   {},
+				"b":   {},
+			},
+			wantDepth: 2,
+			wantPath:  []string{"a", "top"},
+		},
+		{
+			name:           "A graph with a cycle triggers an error",
+			adjacency_list: map[string][]string{"one": {"two"}, "two": {"one"}},
+			wantPath:       []string{},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphWithVerticesDUMMYDATA(tt.adjacency_list, "")
+			depth, path, err := g.Depth()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Graph.Depth() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if depth != tt.wantDepth || !reflect.DeepEqual(path, tt.wantPath) {
+				t.Errorf("Graph.Depth() = %d, %v, want %d, %v", depth, path, tt.wantDepth, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestGraph_MustPrecede(t *testing.T) {
+	g := graphWithVerticesDUMMYDATA(map[string][]string{
+		"deploy":  {"build", "migrate"},
+		"build":   {"fetch"},
+		"migrate": {},
+		"fetch":   {},
+		"a":       {"b"},
+		"b":       {"a"},
+	}, "")
+	tests := []struct {
+		name    string
+		a, b    string
+		want    bool
+		wantErr bool
+	}{
+		{name: "Direct dependency", a: "build", b: "deploy", want: true},
+		{name: "Transitive dependency", a: "fetch", b: "deploy", want: true},
+		{name: "Reversed", a: "deploy", b: "fetch", want: false},
+		{name: "Unrelated steps can be reordered", a: "build", b: "migrate", want: false},
+		{name: "A vertex doesn't precede itself", a: "build", b: "build", want: false},
+		{name: "Unregistered vertex", a: "build", b: "missing", wantErr: true},
+		{name: "Cycle", a: "a", b: "b", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.MustPrecede(tt.a, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Graph.MustPrecede() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Graph.MustPrecede(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
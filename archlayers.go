@@ -0,0 +1,57 @@
+package topologicalsort
+
+import "fmt"
+
+// SetVertexLayer puts a registered vertex in an architecture layer, for checking that dependencies only point downwards (see [Graph.EnforceLayers]).
+// Lower numbers are lower layers: a vertex may depend on vertices in its own layer or below, but not above. Vertices without a layer aren't checked.
+// These layers are unrelated to the drawing layers of [Graph.Layout].
+func (g *Graph[T]) SetVertexLayer(key string, layer int) error {
+	if !g.HasVertex(key) {
+		return vertexError(key, "attempted to set layer on unregistered vertex %s", key)
+	}
+	g.layers[g.canonicalKey(key)] = layer
+	return nil
+}
+
+// VertexLayer returns the architecture layer of a vertex, and whether it has one
+func (g *Graph[T]) VertexLayer(key string) (int, bool) {
+	layer, ok := g.layers[g.canonicalKey(key)]
+	return layer, ok
+}
+
+// EnforceLayers turns the graph into an architecture conformance checker: it registers a constraint (see [Graph.RegisterConstraint])
+// which makes AddEdge reject edges pointing upwards, from a vertex to one in a higher layer. Layers set later are taken into account too.
+// Edges added before are reported by [Graph.Validate]; use [Graph.LayerViolations] to report upward edges without rejecting them.
+func (g *Graph[T]) EnforceLayers() {
+	if g.layersEnforced {
+		return
+	}
+	g.layersEnforced = true
+	g.RegisterConstraint(func(source, dest *GraphNode[T]) error {
+		if !g.pointsUpwards(source.Key, dest.Key) {
+			return nil
+		}
+		return fmt.Errorf("vertex %s (layer %d) may not depend on vertex %s in higher layer %d", source.Key, g.layers[source.Key], dest.Key, g.layers[dest.Key])
+	})
+}
+
+// LayerViolations returns the edges which point upwards across architecture layers (see [Graph.SetVertexLayer]), sorted like [Graph.Edges]
+func (g *Graph[T]) LayerViolations() []Edge {
+	violations := make([]Edge, 0)
+	for _, e := range g.Edges() {
+		if g.pointsUpwards(e.Source, e.Dest) {
+			violations = append(violations, e)
+		}
+	}
+	return violations
+}
+
+// pointsUpwards reports whether source is in a lower layer than dest
+func (g *Graph[T]) pointsUpwards(source, dest string) bool {
+	sourceLayer, ok := g.layers[source]
+	if !ok {
+		return false
+	}
+	destLayer, ok := g.layers[dest]
+	return ok && sourceLayer < destLayer
+}
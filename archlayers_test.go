@@ -0,0 +1,97 @@
+package topologicalsort
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// layeredGraph returns a graph of a small layered application: ui (2) above service (1) above storage (0), plus an unlayered logger
+func layeredGraph(t *testing.T) *Graph[string] {
+	t.Helper()
+	graph := NewGraphWithOptions[string]()
+	layers := []struct {
+		key   string
+		layer int
+	}{{"ui", 2}, {"service", 1}, {"storage", 0}}
+	for _, l := range layers {
+		if err := graph.RegisterVertex(l.key, l.key); err != nil {
+			t.Fatal(err)
+		}
+		if err := graph.SetVertexLayer(l.key, l.layer); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := graph.RegisterVertex("logger", "logger"); err != nil {
+		t.Fatal(err)
+	}
+	return graph
+}
+
+func TestEnforceLayers(t *testing.T) {
+	graph := layeredGraph(t)
+	graph.EnforceLayers()
+	// registering twice doesn't report violations twice
+	graph.EnforceLayers()
+
+	tests := []struct {
+		name    string
+		source  string
+		dest    string
+		wantErr bool
+	}{
+		{name: "Downward edge", source: "ui", dest: "service"},
+		{name: "Edge skipping a layer", source: "ui", dest: "storage"},
+		{name: "Unlayered vertices are unconstrained", source: "storage", dest: "logger"},
+		{name: "Upward edge", source: "storage", dest: "service", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := graph.AddDependency(tt.source, tt.dest)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddDependency(%q, %q) error = %v, wantErr %v", tt.source, tt.dest, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrConstraintViolated) {
+					t.Errorf("AddDependency returned %v, want an error wrapping ErrConstraintViolated", err)
+				}
+				if got := len(err.(interface{ Unwrap() []error }).Unwrap()); got != 1 {
+					t.Errorf("AddDependency reported %d violations, want 1", got)
+				}
+			}
+		})
+	}
+}
+
+func TestLayerViolations(t *testing.T) {
+	graph := layeredGraph(t)
+	edges := []Edge{
+		{Source: "ui", Dest: "service"},
+		{Source: "storage", Dest: "ui"},
+		{Source: "service", Dest: "ui"},
+		{Source: "service", Dest: "logger"},
+	}
+	if err := graph.AddEdges(edges...); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Edge{{Source: "service", Dest: "ui"}, {Source: "storage", Dest: "ui"}}
+	if got := graph.LayerViolations(); !reflect.DeepEqual(got, want) {
+		t.Errorf("LayerViolations() = %v, want %v", got, want)
+	}
+
+	if layer, ok := graph.VertexLayer("service"); !ok || layer != 1 {
+		t.Errorf("VertexLayer(%q) = %d, %v, want 1, true", "service", layer, ok)
+	}
+	if _, ok := graph.VertexLayer("logger"); ok {
+		t.Errorf("VertexLayer(%q) reported a layer for an unlayered vertex", "logger")
+	}
+	if err := graph.SetVertexLayer("missing", 0); err == nil {
+		t.Errorf("SetVertexLayer on an unregistered vertex should have failed")
+	}
+
+	graph.EnforceLayers()
+	if err := graph.Validate(); !errors.Is(err, ErrConstraintViolated) {
+		t.Errorf("Validate() = %v, want an error wrapping ErrConstraintViolated", err)
+	}
+}
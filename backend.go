@@ -0,0 +1,100 @@
+package topologicalsort
+
+// Backend gives read access to a graph's structure wherever it is stored, e.g. in BoltDB or SQLite for graphs which don't fit into memory.
+// [SortBackend] sorts a backend without loading its adjacency lists: it only reads the dependencies of the vertices it's currently visiting.
+type Backend interface {
+	// Vertices calls fn for every vertex key, stopping at (and returning) the first error fn returns
+	Vertices(fn func(key string) error) error
+	// Dependencies returns the keys of the vertices key depends on
+	Dependencies(key string) ([]string, error)
+}
+
+// BackendOf returns an in-memory [Backend] reading from g, listing vertices in registration order
+func BackendOf[T any](g *Graph[T]) Backend {
+	return graphBackend[T]{g}
+}
+
+type graphBackend[T any] struct {
+	g *Graph[T]
+}
+
+func (b graphBackend[T]) Vertices(fn func(key string) error) error {
+	for _, node := range b.g.nodes {
+		if err := fn(node.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b graphBackend[T]) Dependencies(key string) ([]string, error) {
+	if !b.g.HasVertex(key) {
+		return nil, vertexError(key, "attempted to read dependencies of unregistered vertex %s", key)
+	}
+	return b.g.dependencyKeys(key), nil
+}
+
+// backendFrame is a vertex on the depth-first search stack, along with its dependencies and the next one to visit
+type backendFrame struct {
+	key  string
+	deps []string
+	next int
+}
+
+// SortBackend topologically sorts the graph in b, passing every vertex key to emit as soon as its place in the order is known (dependencies first),
+// so the order never has to be held in memory. It stops at, and returns, the first error from b or emit, or an error wrapping [ErrCycle].
+// Memory use is one small entry per vertex, plus the dependencies of the vertices on the current search path; adjacency lists are read once each.
+// A vertex depending on a key which [Backend.Vertices] doesn't list is an error, and vertices are visited in the order Vertices lists them.
+func SortBackend(b Backend, emit func(key string) error) error {
+	// state tracks the vertices which were seen by Vertices, are on the search path, or are done
+	state := make(map[string]dfsState)
+	err := b.Vertices(func(key string) error {
+		if _, ok := state[key]; !ok {
+			state[key] = unvisited
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return b.Vertices(func(root string) error {
+		if state[root] != unvisited {
+			return nil
+		}
+		deps, err := b.Dependencies(root)
+		if err != nil {
+			return err
+		}
+		state[root] = visiting
+		stack := []backendFrame{{key: root, deps: deps}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next == len(top.deps) {
+				state[top.key] = finished
+				if err := emit(top.key); err != nil {
+					return err
+				}
+				stack = stack[:len(stack)-1]
+				continue
+			}
+
+			dep := top.deps[top.next]
+			top.next++
+			switch s, ok := state[dep]; {
+			case !ok:
+				return edgeError(top.key, dep, dep, "vertex %s depends on unknown vertex %s", top.key, dep)
+			case s == visiting:
+				return edgeError(top.key, dep, "", "\n%w: found a back edge from %s to %s", ErrCycle, top.key, dep)
+			case s == unvisited:
+				deps, err := b.Dependencies(dep)
+				if err != nil {
+					return err
+				}
+				state[dep] = visiting
+				stack = append(stack, backendFrame{key: dep, deps: deps})
+			}
+		}
+		return nil
+	})
+}
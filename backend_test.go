@@ -0,0 +1,112 @@
+package topologicalsort
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// mapBackend is a Backend over an adjacency map, listing vertices in the order of keys, and counting dependency reads
+type mapBackend struct {
+	keys  []string
+	deps  map[string][]string
+	reads int
+}
+
+func (b *mapBackend) Vertices(fn func(key string) error) error {
+	for _, k := range b.keys {
+		if err := fn(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *mapBackend) Dependencies(key string) ([]string, error) {
+	b.reads++
+	return b.deps[key], nil
+}
+
+func TestSortBackend(t *testing.T) {
+	tests := []struct {
+		name      string
+		backend   *mapBackend
+		want      []string
+		wantCycle bool
+		wantErr   bool
+	}{
+		{
+			name: "Sorts dependencies first",
+			backend: &mapBackend{
+				keys: []string{"app", "auth", "db", "cache"},
+				deps: map[string][]string{"app": {"auth", "cache"}, "auth": {"db"}, "cache": {"db"}},
+			},
+			want: []string{"db", "auth", "cache", "app"},
+		},
+		{
+			name: "Cycle",
+			backend: &mapBackend{
+				keys: []string{"a", "b"},
+				deps: map[string][]string{"a": {"b"}, "b": {"a"}},
+			},
+			wantCycle: true,
+			wantErr:   true,
+		},
+		{
+			name: "Unknown dependency",
+			backend: &mapBackend{
+				keys: []string{"a"},
+				deps: map[string][]string{"a": {"b"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			err := SortBackend(tt.backend, func(key string) error {
+				got = append(got, key)
+				return nil
+			})
+			if (err != nil) != tt.wantErr || errors.Is(err, ErrCycle) != tt.wantCycle {
+				t.Fatalf("SortBackend() error = %v, wantErr %v, wantCycle %v", err, tt.wantErr, tt.wantCycle)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SortBackend() = %v, want %v", got, tt.want)
+			}
+			if tt.backend.reads != len(tt.backend.keys) {
+				t.Errorf("SortBackend() read dependencies %d times, want once per vertex (%d)", tt.backend.reads, len(tt.backend.keys))
+			}
+		})
+	}
+}
+
+func TestSortBackend_EmitError(t *testing.T) {
+	backend := &mapBackend{keys: []string{"a", "b"}, deps: map[string][]string{"b": {"a"}}}
+	stop := errors.New("stop")
+	if err := SortBackend(backend, func(string) error { return stop }); !errors.Is(err, stop) {
+		t.Errorf("SortBackend() error = %v, want %v", err, stop)
+	}
+}
+
+func TestBackendOf(t *testing.T) {
+	graph := NewGraphWithOptions[string]()
+	for _, k := range []string{"app", "auth", "db"} {
+		graph.RegisterVertex(k, "")
+	}
+	graph.AddEdge("app", "auth")
+	graph.AddEdge("auth", "db")
+
+	want, _ := graph.TopologicalSort()
+	got := make([]string, 0)
+	err := SortBackend(BackendOf(graph), func(key string) error {
+		got = append(got, key)
+		return nil
+	})
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("SortBackend(BackendOf()) = %v, %v, want %v", got, err, want)
+	}
+}
@@ -0,0 +1,146 @@
+package topologicalsort
+
+import (
+	"fmt"
+	"testing"
+)
+
+const benchVertices = 100000
+
+func benchmarkBuild(b *testing.B, opts ...Option) {
+	keys := make([]string, benchVertices)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("v%d", i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		g := NewGraphWithOptions[int](opts...)
+		for i, k := range keys {
+			g.RegisterVertex(k, i)
+		}
+		// every vertex depends on the (up to) four before it
+		for i := range keys {
+			for j := i - 4; j < i; j++ {
+				if j >= 0 {
+					g.AddEdge(keys[i], keys[j])
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkBuild(b *testing.B) {
+	benchmarkBuild(b)
+}
+
+func BenchmarkBuild_WithCapacity(b *testing.B) {
+	benchmarkBuild(b, WithCapacity(benchVertices, 4))
+}
+
+func BenchmarkTopologicalSort(b *testing.B) {
+	benchmarkTopologicalSort(b)
+}
+
+func BenchmarkTopologicalSort_WithParallelism(b *testing.B) {
+	benchmarkTopologicalSort(b, WithParallelism(8))
+}
+
+// benchmarkTopologicalSort sorts one connected graph, where every vertex depends on the (up to) four before it
+func benchmarkTopologicalSort(b *testing.B, opts ...Option) {
+	g := NewGraphWithOptions[int](append(opts, WithCapacity(benchVertices, 4))...)
+	for i := 0; i < benchVertices; i++ {
+		g.RegisterVertex(fmt.Sprintf("v%d", i), i)
+		for j := i - 4; j >= 0 && j < i; j++ {
+			g.AddEdge(fmt.Sprintf("v%d", i), fmt.Sprintf("v%d", j))
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		g.TopologicalSort()
+	}
+}
+
+// benchmarkComponentsSort sorts a graph made of 64 separate chains-of-four-dependencies
+func benchmarkComponentsSort(b *testing.B, opts ...Option) {
+	g := NewGraphWithOptions[int](append(opts, WithCapacity(benchVertices, 4))...)
+	for i := 0; i < benchVertices; i++ {
+		g.RegisterVertex(fmt.Sprintf("v%d", i), i)
+		for j := i - 4*64; j >= 0 && j < i; j += 64 {
+			g.AddEdge(fmt.Sprintf("v%d", i), fmt.Sprintf("v%d", j))
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		g.TopologicalSort()
+	}
+}
+
+func BenchmarkTopologicalSort_Components(b *testing.B) {
+	benchmarkComponentsSort(b)
+}
+
+func BenchmarkTopologicalSort_ComponentsWithParallelism(b *testing.B) {
+	benchmarkComponentsSort(b, WithParallelism(8))
+}
+
+func BenchmarkIndexedGraph_Sort(b *testing.B) {
+	g := NewIndexedGraph(benchVertices)
+	for i := 0; i < benchVertices; i++ {
+		for j := i - 4; j >= 0 && j < i; j++ {
+			g.AddEdge(i, j)
+		}
+	}
+	out := make([]int, 0, benchVertices)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		out, _ = g.Sort(out[:0])
+	}
+}
+
+func BenchmarkSorter_Sort(b *testing.B) {
+	g := NewGraphWithOptions[int](WithCapacity(benchVertices, 4))
+	for i := 0; i < benchVertices; i++ {
+		g.RegisterVertex(fmt.Sprintf("v%d", i), i)
+		for j := i - 4; j >= 0 && j < i; j++ {
+			g.AddEdge(fmt.Sprintf("v%d", i), fmt.Sprintf("v%d", j))
+		}
+	}
+	s := NewSorter[int]()
+	// the first sort sizes the Sorter
+	s.Sort(g)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		s.Sort(g)
+	}
+}
+
+func BenchmarkScheduler(b *testing.B) {
+	g := NewGraphWithOptions[int](WithCapacity(benchVertices, 4))
+	for i := 0; i < benchVertices; i++ {
+		g.RegisterVertex(fmt.Sprintf("v%d", i), i)
+		for j := i - 4; j >= 0 && j < i; j++ {
+			g.AddEdge(fmt.Sprintf("v%d", i), fmt.Sprintf("v%d", j))
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		s, _ := g.NewScheduler()
+		for next := s.Next(); len(next) > 0; next = s.Next() {
+			for _, node := range next {
+				s.Done(node.Key)
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package topologicalsort
+
+import "fmt"
+
+// SetVertexBlock tags a registered vertex with a block label (e.g. the migration it belongs to), for use with [Graph.TopologicalSortByBlock]
+func (g *Graph[T]) SetVertexBlock(key, block string) error {
+	if !g.HasVertex(key) {
+		return vertexError(key, "attempted to set block on unregistered vertex %s", key)
+	}
+	g.blocks[g.canonicalKey(key)] = block
+	return nil
+}
+
+// VertexBlock returns the block label of a vertex, and whether it has one
+func (g *Graph[T]) VertexBlock(key string) (string, bool) {
+	block, ok := g.blocks[g.canonicalKey(key)]
+	return block, ok
+}
+
+// TopologicalSortByBlock performs a topological sort that keeps the vertices sharing a block label next to each other,
+// e.g. to keep the steps of one migration together in a plan. Vertices without a block label are placed on their own.
+// It returns an error if the graph contains a cycle, or if the edges make it impossible to keep a block together
+// (because a vertex outside the block has to come between two of its members).
+func (g *Graph[T]) TopologicalSortByBlock() ([]string, error) {
+	end := g.startSort("topologicalsort.sort_by_block")
+	sorted, err := g.topologicalSortByBlock()
+	end(err)
+	return sorted, err
+}
+
+func (g *Graph[T]) topologicalSortByBlock() ([]string, error) {
+	if _, err := g.topologicalSort(); err != nil {
+		return []string{}, err
+	}
+
+	// every block gets an index in the order of its smallest key, so the result is stable; unlabeled vertices form a block each
+	keys := g.sortedVertexKeys()
+	blockOf := make(map[string]int, len(keys))
+	blockIndex := make(map[string]int)
+	names := make([]string, 0)
+	members := make([][]string, 0)
+	for _, k := range keys {
+		label, ok := g.blocks[k]
+		i, seen := blockIndex[label]
+		if !ok || !seen {
+			i = len(names)
+			if ok {
+				blockIndex[label] = i
+				names = append(names, label)
+			} else {
+				names = append(names, k)
+			}
+			members = append(members, make([]string, 0))
+		}
+		blockOf[k] = i
+		members[i] = append(members[i], k)
+	}
+
+	// Kahn's algorithm over the blocks: a block is ready once every block its members depend on is done
+	remaining := make([]int, len(names))
+	dependents := make([][]int, len(names))
+	for _, e := range g.Edges() {
+		source, dest := blockOf[e.Source], blockOf[e.Dest]
+		if source != dest {
+			remaining[source]++
+			dependents[dest] = append(dependents[dest], source)
+		}
+	}
+	ready := newReadyQueue(func(a, b int) bool { return a < b })
+	for i, count := range remaining {
+		if count == 0 {
+			ready.push(i)
+		}
+	}
+
+	g.topoSortedOrder = make([]*GraphNode[T], 0, len(g.nodes))
+	done := 0
+	for ready.Len() > 0 {
+		i := ready.pop()
+		done++
+		g.topoSortedOrder = append(g.topoSortedOrder, g.sortBlock(members[i], blockOf)...)
+		for _, d := range dependents[i] {
+			remaining[d]--
+			if remaining[d] == 0 {
+				ready.push(d)
+			}
+		}
+	}
+
+	if done != len(names) {
+		stuck := make([]string, 0)
+		for i, count := range remaining {
+			if count > 0 {
+				stuck = append(stuck, names[i])
+			}
+		}
+		return []string{}, fmt.Errorf("blocks %v can't be kept together: they are part of, or depend on, blocks which depend on each other", stuck)
+	}
+
+	if err := g.applyPins(); err != nil {
+		return []string{}, err
+	}
+	finished := make(map[int]bool, len(names))
+	current := -1
+	for _, node := range g.topoSortedOrder {
+		block := blockOf[node.Key]
+		if block == current {
+			continue
+		}
+		if finished[block] {
+			return []string{}, fmt.Errorf("pins split up block %s", names[block])
+		}
+		if current >= 0 {
+			finished[current] = true
+		}
+		current = block
+	}
+	if err := g.selfCheck(true); err != nil {
+		return []string{}, err
+	}
+	return g.SortedKeys(), nil
+}
+
+// sortBlock sorts the (sorted) keys of one block by the edges between them, taking ready vertices in key order
+func (g *Graph[T]) sortBlock(keys []string, blockOf map[string]int) []*GraphNode[T] {
+	block := blockOf[keys[0]]
+	remaining := make(map[string]int, len(keys))
+	dependents := make(map[string][]string)
+	for _, k := range keys {
+		for _, dest := range g.dependencyKeys(k) {
+			if blockOf[dest] == block {
+				remaining[k]++
+				dependents[dest] = append(dependents[dest], k)
+			}
+		}
+	}
+	ready := newReadyQueue(g.lessKey)
+	for _, k := range keys {
+		if remaining[k] == 0 {
+			ready.push(k)
+		}
+	}
+
+	sorted := make([]*GraphNode[T], 0, len(keys))
+	for ready.Len() > 0 {
+		k := ready.pop()
+		sorted = append(sorted, g.vertex(k))
+		for _, d := range dependents[k] {
+			remaining[d]--
+			if remaining[d] == 0 {
+				ready.push(d)
+			}
+		}
+	}
+	return sorted
+}
@@ -0,0 +1,86 @@
+package topologicalsort
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGraph_TopologicalSortByBlock(t *testing.T) {
+	adjacencyList := map[string][]string{
+		"users-table":    {},
+		"users-index":    {"users-table"},
+		"orders-table":   {"users-table"},
+		"orders-index":   {"orders-table"},
+		"orders-trigger": {"orders-table", "audit"},
+		"audit":          {},
+	}
+	tests := []struct {
+		name    string
+		blockOf map[string]string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Blocks are kept together",
+			blockOf: map[string]string{
+				"users-table": "users", "users-index": "users",
+				"orders-table": "orders", "orders-index": "orders", "orders-trigger": "orders",
+			},
+			want: []string{"audit", "users-table", "users-index", "orders-table", "orders-index", "orders-trigger"},
+		},
+		{
+			name: "Without blocks, vertices are taken in key order",
+			want: []string{"audit", "users-table", "orders-table", "orders-index", "orders-trigger", "users-index"},
+		},
+		{
+			name: "A vertex outside the block has to come in between",
+			blockOf: map[string]string{
+				"audit": "audit", "orders-trigger": "audit",
+			},
+			want: []string{"users-table", "orders-table", "audit", "orders-trigger", "orders-index", "users-index"},
+		},
+		{
+			name: "Blocks which depend on each other trigger an error",
+			blockOf: map[string]string{
+				"users-table": "schema", "orders-index": "schema",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := graphWithVerticesDUMMYDATA(adjacencyList, "")
+			for k, block := range tt.blockOf {
+				if err := graph.SetVertexBlock(k, block); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := graph.TopologicalSortByBlock()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Graph.TopologicalSortByBlock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) && !tt.wantErr {
+				t.Errorf("Graph.TopologicalSortByBlock() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraph_SetVertexBlock(t *testing.T) {
+	graph := graphWithVerticesDUMMYDATA(map[string][]string{"one": {}}, "")
+	if err := graph.SetVertexBlock("two", "numbers"); err == nil {
+		t.Errorf("Graph.SetVertexBlock() expected an error for an unregistered vertex")
+	}
+	if err := graph.SetVertexBlock("one", "numbers"); err != nil {
+		t.Fatalf("Graph.SetVertexBlock() unexpected error %v", err)
+	}
+	if block, ok := graph.VertexBlock("one"); !ok || block != "numbers" {
+		t.Errorf("Graph.VertexBlock() = %s, %v, want numbers, true", block, ok)
+	}
+
+	cyclic := graphWithVerticesDUMMYDATA(map[string][]string{"one": {"two"}, "two": {"one"}}, "")
+	if _, err := cyclic.TopologicalSortByBlock(); !errors.Is(err, ErrCycle) {
+		t.Errorf("Graph.TopologicalSortByBlock() error = %v, want ErrCycle", err)
+	}
+}
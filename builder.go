@@ -0,0 +1,62 @@
+package topologicalsort
+
+import "errors"
+
+// GraphBuilder collects vertices and edges in any order, and validates them all at once in [GraphBuilder.Build].
+// Its methods return the builder, so calls can be chained.
+type GraphBuilder[T any] struct {
+	opts     []Option
+	vertices []*GraphNode[T]
+	edges    []Edge
+}
+
+// NewGraphBuilder returns an empty builder; the options are passed on to the graph it builds
+func NewGraphBuilder[T any](opts ...Option) *GraphBuilder[T] {
+	return &GraphBuilder[T]{opts: opts}
+}
+
+// AddVertex adds a vertex, see [Graph.RegisterVertex]
+func (b *GraphBuilder[T]) AddVertex(key string, data T) *GraphBuilder[T] {
+	b.vertices = append(b.vertices, NewGraphNode(key, data))
+	return b
+}
+
+// AddEdge adds an edge, see [Graph.AddEdge]. The vertices don't have to be added yet.
+func (b *GraphBuilder[T]) AddEdge(source, dest string) *GraphBuilder[T] {
+	b.edges = append(b.edges, Edge{Source: source, Dest: dest})
+	return b
+}
+
+// AddEdges adds several edges, along with their labels, see [Graph.AddEdges]
+func (b *GraphBuilder[T]) AddEdges(edges ...Edge) *GraphBuilder[T] {
+	b.edges = append(b.edges, edges...)
+	return b
+}
+
+// Build creates the graph, adding all vertices before any edges. It returns every problem at once (joined with [errors.Join]),
+// such as duplicates (subject to [WithDuplicateVertices] and [WithDuplicateEdges]) or edges between unregistered vertices (subject to [WithMissingVertices]),
+// instead of stopping at the first one.
+func (b *GraphBuilder[T]) Build() (*Graph[T], error) {
+	graph := NewGraphWithOptions[T](b.opts...)
+	errs := make([]error, 0)
+	for i, node := range b.vertices {
+		if err := graph.RegisterVertex(node.Key, node.Data); err != nil {
+			errs = append(errs, withIndex(err, i))
+		}
+	}
+	if err := graph.addMissing(b.edges); err != nil {
+		errs = append(errs, err)
+	}
+	for i, e := range b.edges {
+		if graph.reportedMissing(e) {
+			continue
+		}
+		if err := graph.AddEdges(e); err != nil {
+			errs = append(errs, withIndex(err, i))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return graph, nil
+}
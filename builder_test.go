@@ -0,0 +1,60 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGraphBuilder_Build(t *testing.T) {
+	graph, err := NewGraphBuilder[string]().
+		AddEdge("build-essential", "gcc").
+		AddEdges(Edge{Source: "gcc", Dest: "libc", Label: "links"}).
+		AddVertex("build-essential", "be-data").
+		AddVertex("gcc", "gcc-data").
+		AddVertex("libc", "libc-data").
+		Build()
+	if err != nil {
+		t.Fatalf("GraphBuilder.Build() unexpected error %v", err)
+	}
+	got, err := graph.TopologicalSort()
+	if err != nil {
+		t.Fatalf("Graph.TopologicalSort() unexpected error %v", err)
+	}
+	if want := []string{"libc", "gcc", "build-essential"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.TopologicalSort() = %v, want %v", got, want)
+	}
+}
+
+func TestGraphBuilder_BuildErrors(t *testing.T) {
+	_, err := NewGraphBuilder[string]().
+		AddVertex("gcc", "").
+		AddVertex("gcc", "").
+		AddVertex("libc", "").
+		AddEdge("gcc", "libc").
+		AddEdge("gcc", "libc").
+		AddEdge("make", "bash").
+		Build()
+	if err == nil {
+		t.Fatalf("GraphBuilder.Build() expected an error")
+	}
+	for _, want := range []string{"duplicate vertex gcc", "duplicate edge between gcc and libc", "unregistered vertex make"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("GraphBuilder.Build() error = %v, want it to mention %q", err, want)
+		}
+	}
+	if got := len(err.(interface{ Unwrap() []error }).Unwrap()); got != 3 {
+		t.Errorf("GraphBuilder.Build() returned %d errors, want 3", got)
+	}
+
+	graph, err := NewGraphBuilder[string](WithDuplicateVertices(IgnoreDuplicates), WithDuplicateEdges(IgnoreDuplicates)).
+		AddVertex("gcc", "").
+		AddVertex("gcc", "").
+		AddVertex("libc", "").
+		AddEdge("gcc", "libc").
+		AddEdge("gcc", "libc").
+		Build()
+	if err != nil || len(graph.Edges()) != 1 {
+		t.Errorf("GraphBuilder.Build() = %v, %v; want duplicates ignored", graph, err)
+	}
+}
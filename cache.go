@@ -0,0 +1,138 @@
+package topologicalsort
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// SortStore stores sorted orders by the hash of the graph they belong to, see [WithSortCache].
+// Implementations have to be safe for concurrent use if they are shared between graphs used by several goroutines.
+type SortStore interface {
+	// Load returns the order stored for hash, and whether there is one
+	Load(hash string) ([]string, bool, error)
+	// Store stores order for hash
+	Store(hash string, order []string) error
+}
+
+// WithSortCache makes TopologicalSort look up the sorted order in store, keyed by a canonical hash of the graph's structure (see [Graph.Hash]),
+// and only sort if it isn't there yet. Graphs with the same vertices, edges and pins share their cache entries, even across processes if the store does.
+// The hash is remembered until the graph's structure changes, so sorting an unchanged graph again doesn't even need to hash it.
+// A cached order is a valid order of the graph, but it may differ from the one sorting this particular graph would produce,
+// since that depends on the order in which vertices were registered.
+func WithSortCache(store SortStore) Option {
+	return func(c *config) {
+		c.sortCache = store
+	}
+}
+
+// MemorySortStore is a [SortStore] holding the most recently used orders in memory. It is safe for concurrent use.
+type MemorySortStore struct {
+	mu       sync.Mutex
+	capacity int
+	recent   *list.List
+	entries  map[string]*list.Element
+}
+
+type memorySortEntry struct {
+	hash  string
+	order []string
+}
+
+// NewMemorySortStore returns a store keeping up to capacity orders (or any number, if capacity <= 0)
+func NewMemorySortStore(capacity int) *MemorySortStore {
+	return &MemorySortStore{capacity: capacity, recent: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// Load returns a copy of the order stored for hash
+func (s *MemorySortStore) Load(hash string) ([]string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[hash]
+	if !ok {
+		return nil, false, nil
+	}
+	s.recent.MoveToFront(e)
+	return append([]string{}, e.Value.(*memorySortEntry).order...), true, nil
+}
+
+// Store stores a copy of order for hash, evicting the least recently used order if the store is full
+func (s *MemorySortStore) Store(hash string, order []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	order = append([]string{}, order...)
+	if e, ok := s.entries[hash]; ok {
+		e.Value.(*memorySortEntry).order = order
+		s.recent.MoveToFront(e)
+		return nil
+	}
+	s.entries[hash] = s.recent.PushFront(&memorySortEntry{hash: hash, order: order})
+	if s.capacity > 0 && s.recent.Len() > s.capacity {
+		oldest := s.recent.Back()
+		s.recent.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memorySortEntry).hash)
+	}
+	return nil
+}
+
+// cachedTopologicalSort looks the sorted order up in the sort cache, sorting (and storing the result) on a miss
+func (g *Graph[T]) cachedTopologicalSort() ([]string, error) {
+	if g.sortCacheKey == "" {
+		key, err := g.computeSortCacheKey()
+		if err != nil {
+			return []string{}, err
+		}
+		g.sortCacheKey = key
+	}
+
+	order, ok, err := g.config.sortCache.Load(g.sortCacheKey)
+	if err != nil {
+		return []string{}, fmt.Errorf("failed to load sorted order from cache: %w", err)
+	}
+	if ok && len(order) == len(g.nodes) {
+		g.topoSortedOrder = make([]*GraphNode[T], 0, len(order))
+		for _, k := range order {
+			node := g.vertex(k)
+			if node == nil {
+				// a hash collision, or a store returning garbage; sorting is always safe
+				break
+			}
+			g.topoSortedOrder = append(g.topoSortedOrder, node)
+		}
+		if len(g.topoSortedOrder) == len(g.nodes) {
+			if err := g.selfCheck(true); err != nil {
+				return []string{}, err
+			}
+			return g.SortedKeys(), nil
+		}
+	}
+
+	sorted, err := g.uncachedTopologicalSort()
+	if err != nil {
+		return sorted, err
+	}
+	if err := g.config.sortCache.Store(g.sortCacheKey, sorted); err != nil {
+		return []string{}, fmt.Errorf("failed to store sorted order in cache: %w", err)
+	}
+	return sorted, nil
+}
+
+// computeSortCacheKey hashes the graph's structure (but not its Data), along with the traversal order and pins which change the sorted order
+func (g *Graph[T]) computeSortCacheKey() (string, error) {
+	structure, err := g.HashWith(func(T) ([]byte, error) { return nil, nil })
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	writeField(h, []byte(structure))
+	writeUint(h, uint64(g.config.traversalOrder))
+	for _, pins := range [][]string{g.config.pinFirst, g.config.pinLast} {
+		writeUint(h, uint64(len(pins)))
+		for _, k := range pins {
+			writeField(h, []byte(k))
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
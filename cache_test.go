@@ -0,0 +1,82 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+// countingSortStore wraps a MemorySortStore, counting lookups and hits
+type countingSortStore struct {
+	*MemorySortStore
+	loads, hits int
+}
+
+func (s *countingSortStore) Load(hash string) ([]string, bool, error) {
+	order, ok, err := s.MemorySortStore.Load(hash)
+	s.loads++
+	if ok {
+		s.hits++
+	}
+	return order, ok, err
+}
+
+func TestWithSortCache(t *testing.T) {
+	store := &countingSortStore{MemorySortStore: NewMemorySortStore(0)}
+	graph := NewGraphWithOptions[string](WithSortCache(store))
+	for _, k := range []string{"one", "two", "three"} {
+		graph.RegisterVertex(k, "")
+	}
+	graph.AddEdge("two", "one")
+
+	want := []string{"one", "two", "three"}
+	for i := 0; i < 2; i++ {
+		got, err := graph.TopologicalSort()
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Fatalf("Graph.TopologicalSort() = %v, %v, want %v", got, err, want)
+		}
+	}
+	if store.loads != 2 || store.hits != 1 {
+		t.Errorf("sort cache had %d loads and %d hits, want 2 and 1", store.loads, store.hits)
+	}
+
+	// changing the structure invalidates the cached order
+	graph.AddEdge("one", "three")
+	want = []string{"three", "one", "two"}
+	if got, err := graph.TopologicalSort(); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.TopologicalSort() = %v, %v, want %v", got, err, want)
+	}
+	if store.hits != 1 {
+		t.Errorf("sort cache had %d hits after a change, want 1", store.hits)
+	}
+
+	// a graph with the same structure shares the entry, even if its vertices were registered in another order
+	other := NewGraphWithOptions[string](WithSortCache(store))
+	for _, k := range []string{"three", "two", "one"} {
+		other.RegisterVertex(k, "data doesn't count")
+	}
+	other.AddEdge("two", "one")
+	other.AddEdge("one", "three")
+	if got, err := other.TopologicalSort(); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.TopologicalSort() = %v, %v, want %v", got, err, want)
+	}
+	if store.hits != 2 {
+		t.Errorf("sort cache had %d hits for a graph with the same structure, want 2", store.hits)
+	}
+}
+
+func TestMemorySortStore(t *testing.T) {
+	store := NewMemorySortStore(2)
+	store.Store("a", []string{"one"})
+	store.Store("b", []string{"two"})
+	store.Load("a")
+	store.Store("c", []string{"three"})
+
+	for _, tt := range []struct {
+		hash string
+		want bool
+	}{{"a", true}, {"b", false}, {"c", true}} {
+		if _, ok, _ := store.Load(tt.hash); ok != tt.want {
+			t.Errorf("MemorySortStore.Load(%s) found = %v, want %v", tt.hash, ok, tt.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package topologicalsort
+
+import "sort"
+
+// CentralityScore is how central a vertex is to the graph, see [Graph.Centrality]
+type CentralityScore struct {
+	Key string
+	// Dependencies and Dependents count the vertex's direct dependencies and direct dependents (its out- and in-degree)
+	Dependencies int
+	Dependents   int
+	// TransitiveDependents counts every vertex which depends on this one, directly or transitively: everything a broken release of it can break
+	TransitiveDependents int
+	// Betweenness is the number of shortest dependency paths between other vertices passing through this one (counted fractionally when
+	// there are several shortest paths), which spots bottlenecks. It's only computed if asked for.
+	Betweenness float64
+}
+
+// DependentCounts returns, for every vertex, how many vertices depend on it, directly or transitively.
+// It costs a walk of the graph per vertex, and works on graphs with cycles too.
+func (g *Graph[T]) DependentCounts() map[string]int {
+	dependents := g.dependentIDs()
+	counts := make(map[string]int, len(g.nodes))
+	seen := make([]int, len(g.nodes))
+	for i := range seen {
+		seen[i] = -1
+	}
+	stack := make([]int32, 0)
+	for id, node := range g.nodes {
+		count := 0
+		seen[id] = id
+		stack = append(stack[:0], int32(id))
+		for len(stack) > 0 {
+			v := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, d := range dependents[v] {
+				if seen[d] != id {
+					seen[d] = id
+					count++
+					stack = append(stack, d)
+				}
+			}
+		}
+		counts[node.Key] = count
+	}
+	return counts
+}
+
+// MostDependedUpon returns the keys of the k vertices with the most transitive dependents (see [Graph.DependentCounts]),
+// most depended-upon first and ties broken by key, e.g. to find the riskiest packages of a supply chain. k <= 0 returns every vertex.
+func (g *Graph[T]) MostDependedUpon(k int) []string {
+	counts := g.DependentCounts()
+	keys := g.sortedVertexKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		return counts[keys[i]] > counts[keys[j]]
+	})
+	if k > 0 && k < len(keys) {
+		keys = keys[:k]
+	}
+	return keys
+}
+
+// Centrality returns a [CentralityScore] for every vertex, sorted like [Graph.MostDependedUpon]. Betweenness is only computed if betweenness is true,
+// since it costs a breadth-first search from every vertex (Brandes' algorithm); paths follow dependency edges.
+func (g *Graph[T]) Centrality(betweenness bool) []CentralityScore {
+	counts := g.DependentCounts()
+	dependents := g.dependentIDs()
+	var between []float64
+	if betweenness {
+		between = g.betweenness()
+	}
+
+	scores := make([]CentralityScore, 0, len(g.nodes))
+	for _, k := range g.MostDependedUpon(0) {
+		id, _ := g.id(k)
+		score := CentralityScore{
+			Key:                  k,
+			Dependencies:         len(g.adjacency[id]),
+			Dependents:           len(dependents[id]),
+			TransitiveDependents: counts[k],
+		}
+		if betweenness {
+			score.Betweenness = between[id]
+		}
+		scores = append(scores, score)
+	}
+	return scores
+}
+
+// dependentIDs returns the reverse adjacency lists: for every vertex ID, the IDs of the vertices depending on it directly
+func (g *Graph[T]) dependentIDs() [][]int32 {
+	dependents := make([][]int32, len(g.nodes))
+	for source, dests := range g.adjacency {
+		for _, dest := range dests {
+			dependents[dest] = append(dependents[dest], int32(source))
+		}
+	}
+	return dependents
+}
+
+// betweenness computes the betweenness centrality of every vertex with Brandes' algorithm, following dependency edges
+func (g *Graph[T]) betweenness() []float64 {
+	n := len(g.nodes)
+	between := make([]float64, n)
+	paths := make([]float64, n)
+	distance := make([]int, n)
+	delta := make([]float64, n)
+	predecessors := make([][]int32, n)
+	for s := range g.nodes {
+		for i := range g.nodes {
+			paths[i], distance[i], delta[i] = 0, -1, 0
+			predecessors[i] = predecessors[i][:0]
+		}
+		paths[s], distance[s] = 1, 0
+
+		// breadth-first search, keeping the vertices in the order they were reached
+		visited := []int32{int32(s)}
+		for i := 0; i < len(visited); i++ {
+			v := visited[i]
+			for _, w := range g.adjacency[v] {
+				if distance[w] < 0 {
+					distance[w] = distance[v] + 1
+					visited = append(visited, w)
+				}
+				if distance[w] == distance[v]+1 {
+					paths[w] += paths[v]
+					predecessors[w] = append(predecessors[w], v)
+				}
+			}
+		}
+
+		// accumulate dependencies, farthest vertices first
+		for i := len(visited) - 1; i > 0; i-- {
+			w := visited[i]
+			for _, v := range predecessors[w] {
+				delta[v] += paths[v] / paths[w] * (1 + delta[w])
+			}
+			between[w] += delta[w]
+		}
+	}
+	return between
+}
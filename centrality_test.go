@@ -0,0 +1,93 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph_DependentCounts(t *testing.T) {
+	tests := []struct {
+		name           string
+		adjacency_list map[string][]string
+		want           map[string]int
+	}{
+		{
+			name: "Transitive dependents are counted once",
+			adjacency_list: map[string][]string{
+				"app":    {"http", "log"},
+				"http":   {"log", "tls"},
+				"tls":    {"crypto"},
+				"log":    {},
+				"crypto": {},
+			},
+			want: map[string]int{"app": 0, "http": 1, "log": 2, "tls": 2, "crypto": 3},
+		},
+		{
+			name: "Vertices in a cycle depend on each other",
+			adjacency_list: map[string][]string{
+				"a": {"b"},
+				"b": {"a"},
+				"c": {"a"},
+			},
+			want: map[string]int{"a": 2, "b": 2, "c": 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphWithVerticesDUMMYDATA(tt.adjacency_list, "")
+			if got := g.DependentCounts(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graph.DependentCounts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraph_MostDependedUpon(t *testing.T) {
+	g := graphWithVerticesDUMMYDATA(map[string][]string{
+		"app":    {"http", "log"},
+		"http":   {"log", "tls"},
+		"tls":    {"crypto"},
+		"log":    {},
+		"crypto": {},
+	}, "")
+	tests := []struct {
+		k    int
+		want []string
+	}{
+		{k: 2, want: []string{"crypto", "log"}},
+		{k: 0, want: []string{"crypto", "log", "tls", "http", "app"}},
+		{k: 10, want: []string{"crypto", "log", "tls", "http", "app"}},
+	}
+	for _, tt := range tests {
+		if got := g.MostDependedUpon(tt.k); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Graph.MostDependedUpon(%d) = %v, want %v", tt.k, got, tt.want)
+		}
+	}
+}
+
+func TestGraph_Centrality(t *testing.T) {
+	// two paths from app to db: app -> api -> db and app -> worker -> db
+	g := graphWithVerticesDUMMYDATA(map[string][]string{
+		"app":    {"api", "worker"},
+		"api":    {"db"},
+		"worker": {"db"},
+		"db":     {},
+	}, "")
+
+	want := []CentralityScore{
+		{Key: "db", Dependencies: 0, Dependents: 2, TransitiveDependents: 3},
+		{Key: "api", Dependencies: 1, Dependents: 1, TransitiveDependents: 1, Betweenness: 0.5},
+		{Key: "worker", Dependencies: 1, Dependents: 1, TransitiveDependents: 1, Betweenness: 0.5},
+		{Key: "app", Dependencies: 2, Dependents: 0, TransitiveDependents: 0},
+	}
+	if got := g.Centrality(true); !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.Centrality(true) = %+v, want %+v", got, want)
+	}
+
+	for i := range want {
+		want[i].Betweenness = 0
+	}
+	if got := g.Centrality(false); !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.Centrality(false) = %+v, want %+v", got, want)
+	}
+}
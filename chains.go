@@ -0,0 +1,30 @@
+package topologicalsort
+
+import "sort"
+
+// ChainDecomposition splits the graph into as few chains as possible: sequences of vertices where each depends on the one before it,
+// directly or transitively. Every vertex is in exactly one chain, e.g. for handing each chain to a long-lived worker so related work stays together.
+// There are as many chains as the graph's [Graph.Width]. Chains list their dependencies first, and are sorted by their first key.
+// Running the chains in parallel still has to wait for dependencies across chains. It returns an error if the graph contains a cycle.
+func (g *Graph[T]) ChainDecomposition() ([][]string, error) {
+	d, err := g.dilworth()
+	if err != nil {
+		return [][]string{}, err
+	}
+
+	chains := make([][]string, 0)
+	for v := range g.nodes {
+		if d.predecessor[v] >= 0 {
+			continue
+		}
+		chain := make([]string, 0)
+		for id := int32(v); id >= 0; id = d.successor[id] {
+			chain = append(chain, g.nodes[id].Key)
+		}
+		chains = append(chains, chain)
+	}
+	sort.Slice(chains, func(i, j int) bool {
+		return chains[i][0] < chains[j][0]
+	})
+	return chains, nil
+}
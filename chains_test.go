@@ -0,0 +1,116 @@
+package topologicalsort
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestGraph_ChainDecomposition(t *testing.T) {
+	tests := []struct {
+		name           string
+		adjacency_list map[string][]string
+		want           [][]string
+	}{
+		{
+			name:           "Empty graph",
+			adjacency_list: map[string][]string{},
+			want:           [][]string{},
+		},
+		{
+			name: "Package manager example from cmd",
+			adjacency_list: map[string][]string{
+				"build-essential": {"make", "gcc"},
+				"make":            {"gcc"},
+				"gcc":             {"libc"},
+				"libc":            {},
+			},
+			want: [][]string{{"libc", "gcc", "make", "build-essential"}},
+		},
+		{
+			name: "Independent vertices are chains of their own",
+			adjacency_list: map[string][]string{
+				"a": {},
+				"b": {},
+				"c": {},
+			},
+			want: [][]string{{"a"}, {"b"}, {"c"}},
+		},
+		{
+			name: "Chains may skip over vertices they only depend on transitively",
+			adjacency_list: map[string][]string{
+				"top":    {"left", "right"},
+				"left":   {"bottom"},
+				"right":  {"bottom"},
+				"bottom": {},
+			},
+			// either left or right is left out of the long chain; both decompositions are minimal
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphWithVerticesDUMMYDATA(tt.adjacency_list, "")
+			got, err := g.ChainDecomposition()
+			if err != nil {
+				t.Fatalf("Graph.ChainDecomposition() unexpected error: %v", err)
+			}
+			checkChains(t, g, got)
+			if tt.want != nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graph.ChainDecomposition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraph_ChainDecomposition_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		g := NewGraph(0)
+		for v := 0; v < 30; v++ {
+			g.RegisterVertex(string(rune('A'+v)), v)
+		}
+		for e := 0; e < 40; e++ {
+			a, b := rng.Intn(30), rng.Intn(30)
+			if a > b {
+				g.AddEdge(string(rune('A'+a)), string(rune('A'+b)))
+			}
+		}
+		chains, err := g.ChainDecomposition()
+		if err != nil {
+			t.Fatalf("Graph.ChainDecomposition() unexpected error: %v", err)
+		}
+		checkChains(t, g, chains)
+	}
+}
+
+func TestGraph_ChainDecomposition_Cycle(t *testing.T) {
+	g := graphWithVerticesDUMMYDATA(map[string][]string{"a": {"b"}, "b": {"a"}}, "")
+	if _, err := g.ChainDecomposition(); !errors.Is(err, ErrCycle) {
+		t.Errorf("Graph.ChainDecomposition() error = %v, want an error wrapping ErrCycle", err)
+	}
+}
+
+// checkChains checks that chains cover every vertex once, that every chain is ordered by dependency, and that there are as many as the graph's width
+func checkChains[T any](t *testing.T, g *Graph[T], chains [][]string) {
+	t.Helper()
+	seen := make(map[string]bool)
+	for _, chain := range chains {
+		for i, k := range chain {
+			if seen[k] {
+				t.Fatalf("vertex %s is in more than one chain: %v", k, chains)
+			}
+			seen[k] = true
+			if i > 0 && !dependsTransitively(g.AdjacencyMap(), k, chain[i-1]) {
+				t.Fatalf("chain %v has %s after %s, which it doesn't depend on", chain, k, chain[i-1])
+			}
+		}
+	}
+	if len(seen) != len(g.nodes) {
+		t.Fatalf("chains %v cover %d vertices, want %d", chains, len(seen), len(g.nodes))
+	}
+	width, _, _ := g.Width()
+	if len(chains) != width {
+		t.Fatalf("got %d chains, want as many as the width (%d)", len(chains), width)
+	}
+}
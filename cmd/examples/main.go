@@ -0,0 +1,66 @@
+// Command examples runs realistic scenarios built on topologicalsort and its sub-packages: working out a package install order,
+// starting services, and ordering database migrations. They double as living documentation of the API, and as smoke tests.
+//
+// Usage:
+//
+//	examples            run every scenario
+//	examples -list      list the scenarios
+//	examples -run NAME  run a single scenario
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// scenario is one runnable example, writing what it does to w
+type scenario struct {
+	name        string
+	description string
+	run         func(w io.Writer) error
+}
+
+var scenarios = []scenario{
+	{name: "packages", description: "install order of packages with virtual capabilities, aliases and case-insensitive names", run: packagesScenario},
+	{name: "services", description: "startup plan of services, with architecture layers and a service pinned first", run: servicesScenario},
+	{name: "migrations", description: "apply order of database migrations, kept together per feature", run: migrationsScenario},
+}
+
+func main() {
+	list := flag.Bool("list", false, "list the scenarios")
+	name := flag.String("run", "", "run a single scenario")
+	flag.Parse()
+
+	if *list {
+		for _, s := range scenarios {
+			fmt.Printf("%-12s %s\n", s.name, s.description)
+		}
+		return
+	}
+	if err := run(os.Stdout, *name); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run runs the scenario called name, or every scenario if name is empty
+func run(w io.Writer, name string) error {
+	found := false
+	for _, s := range scenarios {
+		if name != "" && s.name != name {
+			continue
+		}
+		found = true
+		fmt.Fprintf(w, "== %s: %s\n", s.name, s.description)
+		if err := s.run(w); err != nil {
+			return fmt.Errorf("scenario %s: %w", s.name, err)
+		}
+		fmt.Fprintln(w)
+	}
+	if !found {
+		return fmt.Errorf("unknown scenario %s (see -list)", name)
+	}
+	return nil
+}
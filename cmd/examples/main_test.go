@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestScenarios(t *testing.T) {
+	// a line of every scenario's output that shows it worked out the right thing
+	want := map[string]string{
+		"packages":   "install order: libc, gcc, make, build-essential, postfix, mailutils",
+		"services":   "sequential startup: monitoring, postgres, redis, api, worker, web",
+		"migrations": "apply order by feature: 0001_users, 0003_users_email, 0005_users_email_idx, 0002_orders, 0004_order_items",
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := run(&out, s.name); err != nil {
+				t.Fatalf("run(%q) returned unexpected error: %v", s.name, err)
+			}
+			if !strings.Contains(out.String(), want[s.name]+"\n") {
+				t.Errorf("run(%q) output doesn't contain %q:\n%s", s.name, want[s.name], out.String())
+			}
+		})
+	}
+}
+
+func TestRunUnknownScenario(t *testing.T) {
+	var out bytes.Buffer
+	if err := run(&out, "missing"); err == nil {
+		t.Errorf("run(%q) should have failed", "missing")
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing/fstest"
+
+	"github.com/groovemonkey/topologicalsort/migrations"
+)
+
+// migrationFiles are the migrations of the migrations scenario: two features, each made of several migrations
+var migrationFiles = fstest.MapFS{
+	"db/0001_users.sql":           {Data: []byte("CREATE TABLE users (id int);")},
+	"db/0002_orders.sql":          {Data: []byte("-- requires: 0001_users\nCREATE TABLE orders (id int, user_id int);")},
+	"db/0003_users_email.sql":     {Data: []byte("-- requires: 0001_users\nALTER TABLE users ADD email text;")},
+	"db/0004_order_items.sql":     {Data: []byte("-- requires: 0002_orders\nCREATE TABLE order_items (order_id int);")},
+	"db/0005_users_email_idx.sql": {Data: []byte("-- requires: 0003_users_email\nCREATE INDEX ON users (email);")},
+}
+
+// features groups the migrations by the feature they belong to
+var features = map[string]string{
+	"0001_users":           "accounts",
+	"0003_users_email":     "accounts",
+	"0005_users_email_idx": "accounts",
+	"0002_orders":          "shop",
+	"0004_order_items":     "shop",
+}
+
+// migrationsScenario works out the order to apply the migrations in, first by name and then keeping every feature's migrations together
+func migrationsScenario(w io.Writer) error {
+	graph, err := migrations.Load(migrationFiles, "db/*.sql")
+	if err != nil {
+		return err
+	}
+	order, err := migrations.ApplyOrder(graph)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "apply order: %s\n", strings.Join(order, ", "))
+
+	for name, feature := range features {
+		if err := graph.SetVertexBlock(name, feature); err != nil {
+			return err
+		}
+	}
+	byFeature, err := graph.TopologicalSortByBlock()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "apply order by feature: %s\n", strings.Join(byFeature, ", "))
+
+	// a migration requiring a later one makes a cycle, which is reported with the requirement to remove
+	broken := fstest.MapFS{}
+	for file, f := range migrationFiles {
+		broken[file] = f
+	}
+	broken["db/0001_users.sql"] = &fstest.MapFile{Data: []byte("-- requires: 0005_users_email_idx\nCREATE TABLE users (id int);")}
+	graph, err = migrations.Load(broken, "db/*.sql")
+	if err != nil {
+		return err
+	}
+	if _, err := migrations.ApplyOrder(graph); err != nil {
+		fmt.Fprintf(w, "broken migrations: %v\n", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/groovemonkey/topologicalsort"
+	"github.com/groovemonkey/topologicalsort/provides"
+)
+
+// packagesScenario resolves the requirements of a few packages (the "package manager example" of the tests, plus a mail server)
+// and works out what to install, and in which order
+func packagesScenario(w io.Writer) error {
+	// package names are case-insensitive, like on most package managers
+	resolver := provides.NewResolver[string](topologicalsort.WithKeyNormalizer(strings.ToLower))
+	packages := []provides.Package[string]{
+		{Key: "libc", Version: "2.36.0", Data: "C standard library"},
+		{Key: "gcc", Version: "12.2.0", Data: "GNU C compiler", Requires: []string{"libc >=2.31"}},
+		{Key: "make", Version: "4.3.0", Data: "build tool", Requires: []string{"gcc"}},
+		{Key: "build-essential", Version: "12.9.0", Data: "meta package for building software", Requires: []string{"make", "gcc"}},
+		{Key: "postfix", Version: "3.7.0", Data: "mail server", Provides: []string{"mail-transport-agent"}, Requires: []string{"libc"}},
+		{Key: "mailutils", Version: "3.15.0", Data: "mail tools", Requires: []string{"mail-transport-agent"}},
+	}
+	for _, p := range packages {
+		if err := resolver.Add(p); err != nil {
+			return err
+		}
+	}
+
+	order, err := resolver.Sort()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "install order: %s\n", strings.Join(order, ", "))
+
+	graph, err := resolver.Resolve()
+	if err != nil {
+		return err
+	}
+	// distributions rename packages; the old name keeps working
+	if err := graph.AddAlias("glibc", "libc"); err != nil {
+		return err
+	}
+	needed, err := graph.TopologicalSortFor("Make")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "installing Make needs: %s\n", strings.Join(needed, ", "))
+
+	dependents, err := graph.Ancestors("GLIBC", 0)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "upgrading glibc affects: %v\n", dependents)
+
+	fmt.Fprintln(w, "dependency tree of build-essential:")
+	return graph.RenderTree("build-essential", w, 0)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// service is the Data of every vertex of the services scenario
+type service struct {
+	// startup is how long the service takes to start, in seconds
+	startup int
+	// layer is the service's architecture layer: 0 for infrastructure, 1 for backends, 2 for frontends
+	layer int
+}
+
+// servicesScenario plans the startup of a small web application
+func servicesScenario(w io.Writer) error {
+	// monitoring has to be up first, so it sees everything else start
+	graph := topologicalsort.NewGraphWithOptions[service](topologicalsort.PinFirst("monitoring"), topologicalsort.WithSelfCheck())
+	graph.EnforceLayers()
+
+	services := []struct {
+		key       string
+		service   service
+		dependsOn []string
+	}{
+		{"monitoring", service{startup: 2, layer: 0}, nil},
+		{"postgres", service{startup: 8, layer: 0}, nil},
+		{"redis", service{startup: 1, layer: 0}, nil},
+		{"api", service{startup: 3, layer: 1}, []string{"postgres", "redis"}},
+		{"worker", service{startup: 2, layer: 1}, []string{"postgres", "redis"}},
+		{"web", service{startup: 4, layer: 2}, []string{"api"}},
+	}
+	for _, s := range services {
+		if err := graph.RegisterVertex(s.key, s.service); err != nil {
+			return err
+		}
+		if err := graph.SetVertexLayer(s.key, s.service.layer); err != nil {
+			return err
+		}
+	}
+	for _, s := range services {
+		for _, d := range s.dependsOn {
+			if err := graph.AddDependency(s.key, d); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the layers keep backends from depending on frontends
+	if err := graph.AddDependency("api", "web"); err != nil {
+		fmt.Fprintf(w, "rejected: %v\n", err)
+	}
+
+	order, err := graph.TopologicalSort()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "sequential startup: %s\n", strings.Join(order, ", "))
+
+	levels, err := graph.Levels()
+	if err != nil {
+		return err
+	}
+	for i, level := range levels {
+		fmt.Fprintf(w, "wave %d: %s\n", i+1, strings.Join(level, ", "))
+	}
+
+	startup := func(n *topologicalsort.GraphNode[service]) int { return n.Data.startup }
+	timings, err := graph.Timings(startup)
+	if err != nil {
+		return err
+	}
+	for _, k := range order {
+		t := timings[k]
+		fmt.Fprintf(w, "%-10s starts at %2ds, ready at %2ds, slack %ds\n", k, t.EarliestStart, t.EarliestFinish, t.Slack)
+	}
+	return nil
+}
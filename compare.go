@@ -0,0 +1,114 @@
+package topologicalsort
+
+import "fmt"
+
+// OrderComparison is the result of [Graph.CompareOrders]
+type OrderComparison struct {
+	// AViolations and BViolations are the edges each order breaks (a vertex placed before one of its dependencies), sorted like [Graph.Edges].
+	// An order is a valid topological order if it breaks none.
+	AViolations []Edge
+	BViolations []Edge
+	// FirstDifference is the first position at which the orders differ, or -1 if they are identical
+	FirstDifference int
+	// Disagreements are the pairs of vertices the orders put the other way round, in the order they appear in a
+	Disagreements []OrderDisagreement
+}
+
+// OrderDisagreement is a pair of vertices which two orders put the other way round: a puts First before Second, b puts Second before First
+type OrderDisagreement struct {
+	First  string
+	Second string
+	// Constrained reports whether the graph dictates the order of the pair (one depends on the other, directly or transitively),
+	// in which case one of the two orders breaks an edge. Otherwise both orders are fine as far as this pair is concerned.
+	Constrained bool
+}
+
+// AValid reports whether a is a valid topological order of the graph
+func (c OrderComparison) AValid() bool {
+	return len(c.AViolations) == 0
+}
+
+// BValid reports whether b is a valid topological order of the graph
+func (c OrderComparison) BValid() bool {
+	return len(c.BViolations) == 0
+}
+
+// CompareOrders checks two orders of the graph's vertices (e.g. the order a user expected and the computed one) against the graph and each other:
+// which edges each one breaks, and which pairs of vertices they put the other way round, and why.
+// Both orders must contain every vertex exactly once. Finding the disagreements takes time quadratic in the number of vertices.
+func (g *Graph[T]) CompareOrders(a, b []string) (OrderComparison, error) {
+	positionA, err := g.orderPositions(a)
+	if err != nil {
+		return OrderComparison{}, fmt.Errorf("order a: %w", err)
+	}
+	positionB, err := g.orderPositions(b)
+	if err != nil {
+		return OrderComparison{}, fmt.Errorf("order b: %w", err)
+	}
+
+	comparison := OrderComparison{
+		AViolations:     make([]Edge, 0),
+		BViolations:     make([]Edge, 0),
+		FirstDifference: -1,
+		Disagreements:   make([]OrderDisagreement, 0),
+	}
+	for _, e := range g.Edges() {
+		if positionA[e.Dest] > positionA[e.Source] {
+			comparison.AViolations = append(comparison.AViolations, e)
+		}
+		if positionB[e.Dest] > positionB[e.Source] {
+			comparison.BViolations = append(comparison.BViolations, e)
+		}
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			comparison.FirstDifference = i
+			break
+		}
+	}
+
+	reachable := make(map[string]map[string]bool)
+	dependsOn := func(source, dest string) bool {
+		if reachable[source] == nil {
+			reachable[source] = make(map[string]bool)
+			groups, _ := g.Descendants(source, 0)
+			for _, group := range groups {
+				for _, k := range group {
+					reachable[source][k] = true
+				}
+			}
+		}
+		return reachable[source][dest]
+	}
+	for i, first := range a {
+		for _, second := range a[i+1:] {
+			if positionB[second] < positionB[first] {
+				comparison.Disagreements = append(comparison.Disagreements, OrderDisagreement{
+					First:       first,
+					Second:      second,
+					Constrained: dependsOn(first, second) || dependsOn(second, first),
+				})
+			}
+		}
+	}
+	return comparison, nil
+}
+
+// orderPositions returns the position of every vertex in order, checking that it lists every vertex exactly once
+func (g *Graph[T]) orderPositions(order []string) (map[string]int, error) {
+	position := make(map[string]int, len(order))
+	for i, k := range order {
+		if !g.HasVertex(k) {
+			return nil, vertexError(k, "unregistered vertex %s", k)
+		}
+		k = g.canonicalKey(k)
+		if _, ok := position[k]; ok {
+			return nil, vertexError(k, "vertex %s is listed more than once", k)
+		}
+		position[k] = i
+	}
+	if len(position) != len(g.nodes) {
+		return nil, fmt.Errorf("lists %d of the graph's %d vertices", len(position), len(g.nodes))
+	}
+	return position, nil
+}
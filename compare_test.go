@@ -0,0 +1,60 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph_CompareOrders(t *testing.T) {
+	graph := graphWithVerticesDUMMYDATA(map[string][]string{
+		"build-essential": {"make", "gcc"},
+		"gcc":             {"libc"},
+		"make":            {},
+		"libc":            {},
+	}, "")
+
+	got, err := graph.CompareOrders(
+		[]string{"libc", "make", "gcc", "build-essential"},
+		[]string{"make", "gcc", "libc", "build-essential"},
+	)
+	if err != nil {
+		t.Fatalf("Graph.CompareOrders() unexpected error %v", err)
+	}
+	want := OrderComparison{
+		AViolations:     []Edge{},
+		BViolations:     []Edge{{Source: "gcc", Dest: "libc"}},
+		FirstDifference: 0,
+		Disagreements: []OrderDisagreement{
+			{First: "libc", Second: "make", Constrained: false},
+			{First: "libc", Second: "gcc", Constrained: true},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.CompareOrders() = %+v, want %+v", got, want)
+	}
+	if !got.AValid() || got.BValid() {
+		t.Errorf("OrderComparison.AValid() = %v, BValid() = %v; want true, false", got.AValid(), got.BValid())
+	}
+}
+
+func TestGraph_CompareOrdersErrors(t *testing.T) {
+	graph := graphWithVerticesDUMMYDATA(map[string][]string{
+		"gcc":  {"libc"},
+		"libc": {},
+	}, "")
+	tests := []struct {
+		name  string
+		order []string
+	}{
+		{name: "Unregistered vertex", order: []string{"libc", "gcc", "make"}},
+		{name: "Duplicate vertex", order: []string{"libc", "libc"}},
+		{name: "Missing vertex", order: []string{"libc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := graph.CompareOrders([]string{"libc", "gcc"}, tt.order); err == nil {
+				t.Errorf("Graph.CompareOrders() expected an error")
+			}
+		})
+	}
+}
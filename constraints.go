@@ -0,0 +1,45 @@
+package topologicalsort
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrConstraintViolated is returned (wrapped) when an edge breaks a rule registered with [Graph.RegisterConstraint]
+var ErrConstraintViolated = errors.New("constraint violated")
+
+// RegisterConstraint adds a domain rule every edge has to satisfy, e.g. "test targets may not depend on deploy targets".
+// The rule is called with the vertex that depends (source) and the vertex it depends on (dest), and returns an error to reject the edge.
+// From then on AddEdge checks every new edge against every rule, and returns all of the violations at once; edges added earlier are checked by [Graph.Validate].
+func (g *Graph[T]) RegisterConstraint(rule func(source, dest *GraphNode[T]) error) {
+	g.constraints = append(g.constraints, rule)
+}
+
+// Validate checks every edge of the graph against the rules registered with [Graph.RegisterConstraint].
+// It returns every violation (joined with [errors.Join], in the order of [Graph.Edges]), or nil if the graph satisfies all rules.
+func (g *Graph[T]) Validate() error {
+	errs := make([]error, 0)
+	for _, e := range g.Edges() {
+		if err := g.checkConstraints(g.vertex(e.Source), g.vertex(e.Dest)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkConstraints returns the violations of the registered rules by the edge from source to dest, joined with [errors.Join]
+func (g *Graph[T]) checkConstraints(source, dest *GraphNode[T]) error {
+	// AddEdge calls this for every edge, so don't allocate anything unless there's a violation
+	var errs []error
+	for _, rule := range g.constraints {
+		if err := rule(source, dest); err != nil {
+			errs = append(errs, &GraphError{
+				Source: source.Key,
+				Dest:   dest.Key,
+				Index:  -1,
+				err:    fmt.Errorf("%w: edge from %s to %s: %w", ErrConstraintViolated, source.Key, dest.Key, err),
+			})
+		}
+	}
+	return errors.Join(errs...)
+}
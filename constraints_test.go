@@ -0,0 +1,112 @@
+package topologicalsort
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// noTestOnDeploy is a constraint which keeps test targets from depending on deploy targets
+func noTestOnDeploy(source, dest *GraphNode[string]) error {
+	if strings.HasPrefix(source.Key, "test:") && strings.HasPrefix(dest.Key, "deploy:") {
+		return fmt.Errorf("test target %s may not depend on deploy target %s", source.Key, dest.Key)
+	}
+	return nil
+}
+
+// sameTeam is a constraint which keeps vertices from depending on another team's vertices
+func sameTeam(source, dest *GraphNode[string]) error {
+	if source.Data != dest.Data {
+		return fmt.Errorf("%s and %s belong to different teams", source.Key, dest.Key)
+	}
+	return nil
+}
+
+func TestRegisterConstraint(t *testing.T) {
+	graph := NewGraphWithOptions[string]()
+	for _, k := range []string{"build:app", "test:app", "deploy:app"} {
+		if err := graph.RegisterVertex(k, "web"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := graph.RegisterVertex("test:db", "data"); err != nil {
+		t.Fatal(err)
+	}
+	graph.RegisterConstraint(noTestOnDeploy)
+	graph.RegisterConstraint(sameTeam)
+
+	tests := []struct {
+		name           string
+		source         string
+		dest           string
+		wantViolations int
+	}{
+		{name: "Allowed edge", source: "test:app", dest: "build:app"},
+		{name: "Test depends on deploy", source: "test:app", dest: "deploy:app", wantViolations: 1},
+		{name: "Both rules broken", source: "test:db", dest: "deploy:app", wantViolations: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := graph.AddDependency(tt.source, tt.dest)
+			if tt.wantViolations == 0 {
+				if err != nil {
+					t.Errorf("AddDependency returned unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrConstraintViolated) {
+				t.Fatalf("AddDependency returned %v, want an error wrapping ErrConstraintViolated", err)
+			}
+			if got := len(err.(interface{ Unwrap() []error }).Unwrap()); got != tt.wantViolations {
+				t.Errorf("AddDependency reported %d violations, want %d: %v", got, tt.wantViolations, err)
+			}
+			var graphErr *GraphError
+			if !errors.As(err, &graphErr) || graphErr.Source != tt.source || graphErr.Dest != tt.dest {
+				t.Errorf("AddDependency returned %v, want a *GraphError about the edge from %s to %s", err, tt.source, tt.dest)
+			}
+			if graph.hasEdge(tt.source, tt.dest) {
+				t.Errorf("the rejected edge from %s to %s was added anyway", tt.source, tt.dest)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	graph := NewGraphWithOptions[string]()
+	for _, k := range []string{"build:app", "test:app", "deploy:app", "test:lib"} {
+		if err := graph.RegisterVertex(k, k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	edges := []Edge{
+		{Source: "test:lib", Dest: "deploy:app"},
+		{Source: "test:app", Dest: "build:app"},
+		{Source: "test:app", Dest: "deploy:app"},
+	}
+	if err := graph.AddEdges(edges...); err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.Validate(); err != nil {
+		t.Errorf("Validate() without constraints returned unexpected error: %v", err)
+	}
+
+	// edges added before the constraint was registered are only caught by Validate
+	graph.RegisterConstraint(noTestOnDeploy)
+	err := graph.Validate()
+	if !errors.Is(err, ErrConstraintViolated) {
+		t.Fatalf("Validate() returned %v, want an error wrapping ErrConstraintViolated", err)
+	}
+	got := make([]Edge, 0)
+	for _, violation := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var graphErr *GraphError
+		if errors.As(violation, &graphErr) {
+			got = append(got, Edge{Source: graphErr.Source, Dest: graphErr.Dest})
+		}
+	}
+	want := []Edge{{Source: "test:app", Dest: "deploy:app"}, {Source: "test:lib", Dest: "deploy:app"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() reported violations %v, want %v", got, want)
+	}
+}
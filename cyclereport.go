@@ -0,0 +1,103 @@
+package topologicalsort
+
+import (
+	"errors"
+	"strings"
+)
+
+// CyclePath returns the cycle behind err, a cycle error returned by one of the graph's sorts, as a chain of vertex keys in which every vertex depends on the next,
+// starting and ending with the same vertex: [a b c a]. If err doesn't say which edge closed the cycle (e.g. errors from [Graph.Levels]), it picks a cycle of the graph.
+// It returns false if err isn't a cycle error, or the graph no longer contains the cycle.
+func (g *Graph[T]) CyclePath(err error) ([]string, bool) {
+	if !errors.Is(err, ErrCycle) {
+		return nil, false
+	}
+	var graphErr *GraphError
+	if errors.As(err, &graphErr) && graphErr.Source != "" {
+		if !g.hasEdge(graphErr.Source, graphErr.Dest) {
+			return nil, false
+		}
+		path := g.shortestDependencyPath(graphErr.Dest, graphErr.Source)
+		if path == nil {
+			return nil, false
+		}
+		return append([]string{graphErr.Source}, path...), true
+	}
+
+	for _, component := range g.stronglyConnectedComponents() {
+		k := component[0]
+		for _, dest := range g.dependencyKeys(k) {
+			if path := g.shortestDependencyPath(dest, k); path != nil {
+				return append([]string{k}, path...), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// FormatCycle renders the cycle behind err as a chain, e.g. "a → b → c → a" where every vertex depends on the next. See [Graph.CyclePath].
+// If err isn't a cycle error the graph still contains, it returns err's message.
+func (g *Graph[T]) FormatCycle(err error) string {
+	return g.FormatCycleWith(err, nil)
+}
+
+// FormatCycleWith works like [Graph.FormatCycle], but annotates every hop with describe (e.g. a description taken from the vertex's Data), one hop per line:
+//
+//	app: the web frontend
+//	  → auth: login service
+//	  → app
+func (g *Graph[T]) FormatCycleWith(err error, describe func(*GraphNode[T]) string) string {
+	path, ok := g.CyclePath(err)
+	if !ok {
+		if err == nil {
+			return ""
+		}
+		return err.Error()
+	}
+	if describe == nil {
+		return strings.Join(path, " → ")
+	}
+
+	var b strings.Builder
+	for i, k := range path {
+		if i > 0 {
+			b.WriteString("\n  → ")
+		}
+		b.WriteString(k)
+		// the last vertex closes the cycle, and was described already
+		if i < len(path)-1 {
+			if description := describe(g.vertex(k)); description != "" {
+				b.WriteString(": ")
+				b.WriteString(description)
+			}
+		}
+	}
+	return b.String()
+}
+
+// shortestDependencyPath returns the shortest chain of dependencies from source to dest (both included), or nil if source doesn't depend on dest
+func (g *Graph[T]) shortestDependencyPath(source, dest string) []string {
+	previous := map[string]string{source: ""}
+	queue := []string{source}
+	for len(queue) > 0 {
+		k := queue[0]
+		queue = queue[1:]
+		if k == dest {
+			path := make([]string, 0)
+			for ; k != ""; k = previous[k] {
+				path = append(path, k)
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path
+		}
+		for _, next := range g.dependencyKeys(k) {
+			if _, ok := previous[next]; !ok {
+				previous[next] = k
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
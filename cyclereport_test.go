@@ -0,0 +1,73 @@
+package topologicalsort
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func cyclicTestGraph() *Graph[string] {
+	graph := NewGraphWithOptions[string](WithSelfLoops(AllowSelfLoops))
+	for _, k := range []string{"app", "auth", "db", "cache"} {
+		graph.RegisterVertex(k, k+" service")
+	}
+	graph.AddEdge("app", "auth")
+	graph.AddEdge("auth", "db")
+	graph.AddEdge("db", "app")
+	graph.AddEdge("cache", "db")
+	return graph
+}
+
+func TestGraph_CyclePath(t *testing.T) {
+	graph := cyclicTestGraph()
+	_, sortErr := graph.TopologicalSort()
+	_, levelsErr := graph.Levels()
+
+	tests := []struct {
+		name   string
+		err    error
+		want   []string
+		wantOk bool
+	}{
+		{name: "Sort error", err: sortErr, want: []string{"db", "app", "auth", "db"}, wantOk: true},
+		{name: "Error without the closing edge", err: levelsErr, want: []string{"app", "auth", "db", "app"}, wantOk: true},
+		{name: "Other error", err: errors.New("boom")},
+		{name: "No error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := graph.CyclePath(tt.err)
+			if ok != tt.wantOk || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graph.CyclePath() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGraph_FormatCycle(t *testing.T) {
+	graph := cyclicTestGraph()
+	_, err := graph.TopologicalSort()
+
+	if got, want := graph.FormatCycle(err), "db → app → auth → db"; got != want {
+		t.Errorf("Graph.FormatCycle() = %q, want %q", got, want)
+	}
+
+	got := graph.FormatCycleWith(err, func(node *GraphNode[string]) string { return node.Data })
+	want := "db: db service\n  → app: app service\n  → auth: auth service\n  → db"
+	if got != want {
+		t.Errorf("Graph.FormatCycleWith() = %q, want %q", got, want)
+	}
+
+	other := errors.New("boom")
+	if got := graph.FormatCycle(other); got != "boom" {
+		t.Errorf("Graph.FormatCycle() = %q, want the error message", got)
+	}
+
+	selfLoop := NewGraphWithOptions[string](WithSelfLoops(AllowSelfLoops))
+	selfLoop.RegisterVertex("cache", "")
+	selfLoop.AddEdge("cache", "cache")
+	_, err = selfLoop.TopologicalSort()
+	if got, want := selfLoop.FormatCycle(err), "cache → cache"; got != want {
+		t.Errorf("Graph.FormatCycle() = %q, want %q", got, want)
+	}
+}
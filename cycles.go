@@ -0,0 +1,236 @@
+package topologicalsort
+
+import "sort"
+
+// SuggestEdgeRemovals proposes a small set of edges whose removal makes the graph acyclic (a feedback arc set), sorted like [Graph.Edges].
+// It uses the greedy heuristic of Eades, Lin and Smyth on every cyclic strongly connected component, so the set is small but not guaranteed to be minimal.
+// The result is empty if the graph has no cycles.
+func (g *Graph[T]) SuggestEdgeRemovals() ([]Edge, error) {
+	removals := make([]Edge, 0)
+	edgesBySource := make(map[string][]Edge)
+	for _, e := range g.Edges() {
+		edgesBySource[e.Source] = append(edgesBySource[e.Source], e)
+	}
+
+	for _, component := range g.stronglyConnectedComponents() {
+		inComponent := make(map[string]bool, len(component))
+		for _, k := range component {
+			inComponent[k] = true
+		}
+		internal := make([]Edge, 0)
+		for _, k := range component {
+			for _, e := range edgesBySource[k] {
+				if inComponent[e.Dest] {
+					internal = append(internal, e)
+				}
+			}
+		}
+
+		// edges pointing backwards in the heuristic order break every cycle; self loops always point backwards
+		position := greedyAcyclicOrder(component, internal)
+		for _, e := range internal {
+			if position[e.Source] >= position[e.Dest] {
+				removals = append(removals, e)
+			}
+		}
+	}
+
+	sortEdges(removals)
+	return removals, nil
+}
+
+// greedyAcyclicOrder orders the given vertices so that few edges point backwards (Eades, Lin and Smyth), returning each vertex's position
+func greedyAcyclicOrder(keys []string, edges []Edge) map[string]int {
+	out := make(map[string]int, len(keys))
+	in := make(map[string]int, len(keys))
+	for _, e := range edges {
+		if e.Source != e.Dest {
+			out[e.Source]++
+			in[e.Dest]++
+		}
+	}
+	outEdges := make(map[string][]Edge)
+	inEdges := make(map[string][]Edge)
+	for _, e := range edges {
+		if e.Source != e.Dest {
+			outEdges[e.Source] = append(outEdges[e.Source], e)
+			inEdges[e.Dest] = append(inEdges[e.Dest], e)
+		}
+	}
+
+	remaining := make([]string, len(keys))
+	copy(remaining, keys)
+	sort.Strings(remaining)
+	removed := make(map[string]bool, len(keys))
+	remove := func(k string) {
+		removed[k] = true
+		for _, e := range outEdges[k] {
+			in[e.Dest]--
+		}
+		for _, e := range inEdges[k] {
+			out[e.Source]--
+		}
+	}
+
+	head := make([]string, 0, len(keys))
+	tail := make([]string, 0)
+	for len(head)+len(tail) < len(keys) {
+		progress := true
+		for progress {
+			progress = false
+			for _, k := range remaining {
+				if removed[k] {
+					continue
+				}
+				if out[k] == 0 {
+					tail = append(tail, k)
+					remove(k)
+					progress = true
+				} else if in[k] == 0 {
+					head = append(head, k)
+					remove(k)
+					progress = true
+				}
+			}
+		}
+
+		// no sources or sinks left: pick the vertex with the biggest surplus of outgoing edges
+		best := ""
+		for _, k := range remaining {
+			if !removed[k] && (best == "" || out[k]-in[k] > out[best]-in[best]) {
+				best = k
+			}
+		}
+		if best != "" {
+			head = append(head, best)
+			remove(best)
+		}
+	}
+
+	position := make(map[string]int, len(keys))
+	for i, k := range head {
+		position[k] = i
+	}
+	// sinks were collected last-first
+	for i, k := range tail {
+		position[k] = len(keys) - 1 - i
+	}
+	return position
+}
+
+// Cycles returns the groups of vertices which are caught in a cycle: sets of vertices which all depend on each other, directly or transitively,
+// plus single vertices with a self loop. Keys within a group are sorted, and groups are sorted by their first key. The result is empty if the graph has no cycles.
+func (g *Graph[T]) Cycles() [][]string {
+	return g.stronglyConnectedComponents()
+}
+
+// stronglyConnectedComponents returns the graph's cyclic strongly connected components (Tarjan's algorithm): sets of vertices which can all reach each other,
+// plus single vertices with a self loop. Keys within a component are sorted, and components are sorted by their first key.
+func (g *Graph[T]) stronglyConnectedComponents() [][]string {
+	index := make(map[string]int, len(g.nodes))
+	lowlink := make(map[string]int, len(g.nodes))
+	onStack := make(map[string]bool)
+	stack := make([]string, 0)
+	components := make([][]string, 0)
+
+	var connect func(k string)
+	connect = func(k string) {
+		index[k] = len(index)
+		lowlink[k] = index[k]
+		stack = append(stack, k)
+		onStack[k] = true
+
+		selfLoop := false
+		for _, dest := range g.dependencyNodes(k) {
+			if dest.Key == k {
+				selfLoop = true
+			}
+			if _, ok := index[dest.Key]; !ok {
+				connect(dest.Key)
+				if lowlink[dest.Key] < lowlink[k] {
+					lowlink[k] = lowlink[dest.Key]
+				}
+			} else if onStack[dest.Key] && index[dest.Key] < lowlink[k] {
+				lowlink[k] = index[dest.Key]
+			}
+		}
+
+		if lowlink[k] == index[k] {
+			component := make([]string, 0)
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == k {
+					break
+				}
+			}
+			if len(component) > 1 || selfLoop {
+				sort.Strings(component)
+				components = append(components, component)
+			}
+		}
+	}
+
+	for _, k := range g.sortedVertexKeys() {
+		if _, ok := index[k]; !ok {
+			connect(k)
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0] < components[j][0]
+	})
+	return components
+}
+
+// TopologicalSortBestEffort sorts the graph even if it contains cycles, by ignoring the edges proposed by [Graph.SuggestEdgeRemovals].
+// It returns the (approximate) order along with the edges that had to be ignored; for an acyclic graph the order is a valid topological order and no edges are ignored.
+func (g *Graph[T]) TopologicalSortBestEffort() ([]string, []Edge, error) {
+	end := g.startSort("topologicalsort.sort_best_effort")
+	sorted, ignored, err := g.topologicalSortBestEffort()
+	end(err)
+	return sorted, ignored, err
+}
+
+func (g *Graph[T]) topologicalSortBestEffort() ([]string, []Edge, error) {
+	ignored, err := g.SuggestEdgeRemovals()
+	if err != nil {
+		return []string{}, []Edge{}, err
+	}
+	skip := make(map[Edge]bool, len(ignored))
+	for _, e := range ignored {
+		skip[e] = true
+	}
+
+	// Kahn's algorithm over the remaining edges, taking ready vertices in key order
+	remaining := make(map[string]int, len(g.nodes))
+	dependents := make(map[string][]string)
+	for _, e := range g.Edges() {
+		if !skip[e] {
+			remaining[e.Source]++
+			dependents[e.Dest] = append(dependents[e.Dest], e.Source)
+		}
+	}
+	ready := newReadyQueue(keyLess)
+	for _, k := range g.sortedVertexKeys() {
+		if remaining[k] == 0 {
+			ready.push(k)
+		}
+	}
+
+	g.topoSortedOrder = make([]*GraphNode[T], 0, len(g.nodes))
+	for ready.Len() > 0 {
+		k := ready.pop()
+		g.topoSortedOrder = append(g.topoSortedOrder, g.vertex(k))
+		for _, d := range dependents[k] {
+			remaining[d]--
+			if remaining[d] == 0 {
+				ready.push(d)
+			}
+		}
+	}
+
+	return g.SortedKeys(), ignored, nil
+}
@@ -0,0 +1,157 @@
+package topologicalsort
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestGraph_SuggestEdgeRemovals(t *testing.T) {
+	tests := []struct {
+		name           string
+		adjacency_list map[string][]string
+		want           []Edge
+	}{
+		{
+			name:           "A graph with no cycles needs no removals",
+			adjacency_list: map[string][]string{"one": {}, "two": {"one"}},
+			want:           []Edge{},
+		},
+		{
+			name:           "A two-cycle needs one removal",
+			adjacency_list: map[string][]string{"one": {"two"}, "two": {"one"}},
+			want:           []Edge{{Source: "two", Dest: "one"}},
+		},
+		{
+			name: "One edge can break several cycles",
+			adjacency_list: map[string][]string{
+				"a": {"b"},
+				"b": {"c", "d"},
+				"c": {"a"},
+				"d": {"a"},
+				"e": {"a"},
+			},
+			want: []Edge{{Source: "a", Dest: "b"}},
+		},
+		{
+			name: "Separate cycles are broken separately",
+			adjacency_list: map[string][]string{
+				"one":   {"two"},
+				"two":   {"one"},
+				"three": {"four"},
+				"four":  {"three"},
+				"five":  {"one", "three"},
+			},
+			want: []Edge{{Source: "three", Dest: "four"}, {Source: "two", Dest: "one"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphWithVerticesDUMMYDATA(tt.adjacency_list, "")
+			got, err := g.SuggestEdgeRemovals()
+			if err != nil {
+				t.Fatalf("Graph.SuggestEdgeRemovals() unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graph.SuggestEdgeRemovals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraph_SuggestEdgeRemovals_MakesAcyclic(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		g := NewGraph(0, WithSelfLoops(AllowSelfLoops))
+		for v := 0; v < 15; v++ {
+			g.RegisterVertex(string(rune('a'+v)), v)
+		}
+		for e := 0; e < 40; e++ {
+			g.AddEdge(string(rune('a'+rng.Intn(15))), string(rune('a'+rng.Intn(15))))
+		}
+
+		removals, _ := g.SuggestEdgeRemovals()
+		remove := make(map[Edge]bool)
+		for _, e := range removals {
+			remove[e] = true
+		}
+		pruned := NewGraph(0, WithSelfLoops(AllowSelfLoops))
+		for k := range g.ids {
+			pruned.RegisterVertex(k, 0)
+		}
+		for _, e := range g.Edges() {
+			if !remove[e] {
+				pruned.AddEdges(e)
+			}
+		}
+		if _, err := pruned.TopologicalSort(); err != nil {
+			t.Fatalf("removing %v still leaves a cycle: %v", removals, err)
+		}
+	}
+}
+
+func TestGraph_TopologicalSortBestEffort(t *testing.T) {
+	tests := []struct {
+		name           string
+		adjacency_list map[string][]string
+		want           []string
+		wantIgnored    []Edge
+	}{
+		{
+			name: "A graph with no cycles is sorted normally",
+			adjacency_list: map[string][]string{
+				"build-essential": {"make", "gcc"},
+				"make":            {"gcc"},
+				"gcc":             {"libc"},
+				"libc":            {},
+			},
+			want:        []string{"libc", "gcc", "make", "build-essential"},
+			wantIgnored: []Edge{},
+		},
+		{
+			name: "Cycles are broken and reported",
+			adjacency_list: map[string][]string{
+				"app":  {"lib"},
+				"lib":  {"util"},
+				"util": {"lib"},
+			},
+			want:        []string{"util", "lib", "app"},
+			wantIgnored: []Edge{{Source: "util", Dest: "lib"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphWithVerticesDUMMYDATA(tt.adjacency_list, "")
+			got, ignored, err := g.TopologicalSortBestEffort()
+			if err != nil {
+				t.Fatalf("Graph.TopologicalSortBestEffort() unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) || !reflect.DeepEqual(ignored, tt.wantIgnored) {
+				t.Errorf("Graph.TopologicalSortBestEffort() = %v, %v, want %v, %v", got, ignored, tt.want, tt.wantIgnored)
+			}
+			if !reflect.DeepEqual(g.SortedKeys(), got) {
+				t.Errorf("Graph.SortedKeys() = %v, want %v", g.SortedKeys(), got)
+			}
+		})
+	}
+}
+
+func TestGraph_Cycles(t *testing.T) {
+	graph := graphWithVerticesDUMMYDATA(map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+		"d": {"a"},
+		"e": {"f"},
+		"f": {"e"},
+	}, "")
+	want := [][]string{{"a", "b", "c"}, {"e", "f"}}
+	if got := graph.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.Cycles() = %v, want %v", got, want)
+	}
+
+	acyclic := graphWithVerticesDUMMYDATA(map[string][]string{"a": {"b"}, "b": {}}, "")
+	if got := acyclic.Cycles(); len(got) != 0 {
+		t.Errorf("Graph.Cycles() = %v, want no cycles", got)
+	}
+}
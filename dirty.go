@@ -0,0 +1,67 @@
+package topologicalsort
+
+import "sort"
+
+// ContentHasher can be implemented by a graph's Data to give every vertex a content hash (e.g. a checksum of a build target's inputs),
+// which [Graph.ContentHashes], [Graph.ChangedSince] and [PlanRebuild] use to tell whether a vertex changed
+type ContentHasher interface {
+	ContentHash() string
+}
+
+// ContentHashes returns the content hash of every vertex whose Data implements [ContentHasher], for storing and comparing later with [Graph.ChangedSince]
+func (g *Graph[T]) ContentHashes() map[string]string {
+	hashes := make(map[string]string, len(g.nodes))
+	for _, node := range g.nodes {
+		if hasher, ok := any(node.Data).(ContentHasher); ok {
+			hashes[node.Key] = hasher.ContentHash()
+		}
+	}
+	return hashes
+}
+
+// ChangedSince returns the sorted keys of the vertices whose content hash differs from the one in hashes (taken earlier by [Graph.ContentHashes]),
+// including vertices which have a hash now but didn't have one then. Vertices whose Data doesn't implement [ContentHasher] are never reported.
+func (g *Graph[T]) ChangedSince(hashes map[string]string) []string {
+	changed := make([]string, 0)
+	for k, hash := range g.ContentHashes() {
+		if old, ok := hashes[k]; !ok || old != hash {
+			changed = append(changed, k)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// DirtySet returns the vertices which have to be redone when changedKeys change: the changed vertices themselves plus everything that depends on them,
+// directly or transitively, in topological order. Use it with [Graph.ChangedSince] to only redo what a change affects.
+// It returns an error if a key is unregistered or the graph contains a cycle.
+func (g *Graph[T]) DirtySet(changedKeys ...string) ([]string, error) {
+	dirty := make(map[string]bool, len(changedKeys))
+	for _, k := range changedKeys {
+		if !g.HasVertex(k) {
+			return []string{}, vertexError(k, "attempted to mark unregistered vertex %s as dirty", k)
+		}
+		dirty[g.canonicalKey(k)] = true
+	}
+	order, err := g.TopologicalSort()
+	if err != nil {
+		return []string{}, err
+	}
+
+	// dependencies come first in the order, so dirtiness only has to be passed on in one pass
+	set := make([]string, 0)
+	for _, k := range order {
+		if !dirty[k] {
+			for _, dep := range g.dependencyKeys(k) {
+				if dirty[dep] {
+					dirty[k] = true
+					break
+				}
+			}
+		}
+		if dirty[k] {
+			set = append(set, k)
+		}
+	}
+	return set, nil
+}
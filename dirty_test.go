@@ -0,0 +1,79 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+// target is build target Data with a checksum of its inputs
+type target struct {
+	checksum string
+	// not part of the content
+	lastBuilt int
+}
+
+func (t target) ContentHash() string {
+	return t.checksum
+}
+
+func dirtyTestGraph() *Graph[target] {
+	graph := NewGraphWithOptions[target]()
+	for _, k := range []string{"proto", "lib", "cli", "server", "docs"} {
+		graph.RegisterVertex(k, target{checksum: k + "-1"})
+	}
+	graph.AddEdge("lib", "proto")
+	graph.AddEdge("cli", "lib")
+	graph.AddEdge("server", "lib")
+	return graph
+}
+
+func TestGraph_DirtySet(t *testing.T) {
+	tests := []struct {
+		name    string
+		changed []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "Nothing changed", want: []string{}},
+		{name: "Leaf changed", changed: []string{"cli"}, want: []string{"cli"}},
+		{name: "Dirtiness propagates to dependents", changed: []string{"proto"}, want: []string{"proto", "lib", "cli", "server"}},
+		{name: "Several changes", changed: []string{"docs", "lib"}, want: []string{"lib", "cli", "server", "docs"}},
+		{name: "Unregistered vertex", changed: []string{"web"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dirtyTestGraph().DirtySet(tt.changed...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Graph.DirtySet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graph.DirtySet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraph_ChangedSince(t *testing.T) {
+	graph := dirtyTestGraph()
+	hashes := graph.ContentHashes()
+
+	graph.UpdateVertexData("lib", func(t target) target { return target{checksum: "lib-2"} })
+	graph.UpdateVertexData("cli", func(t target) target { return target{checksum: t.checksum, lastBuilt: 1} })
+	graph.RegisterVertex("web", target{checksum: "web-1"})
+
+	want := []string{"lib", "web"}
+	if got := graph.ChangedSince(hashes); !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.ChangedSince() = %v, want %v", got, want)
+	}
+
+	// a rebuild plan compares content hashes too, so cli only runs because lib does
+	previous := dirtyTestGraph()
+	plan, err := PlanRebuild(previous, graph, ProgressSnapshot{Done: []string{"cli", "docs", "lib", "proto", "server"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantReasons := map[string]RebuildReason{"lib": RebuildChanged, "cli": RebuildDependency, "server": RebuildDependency, "web": RebuildNew}
+	if !reflect.DeepEqual(plan.Reasons, wantReasons) {
+		t.Errorf("PlanRebuild() reasons = %v, want %v", plan.Reasons, wantReasons)
+	}
+}
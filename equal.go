@@ -0,0 +1,22 @@
+package topologicalsort
+
+import "reflect"
+
+// Equal reports whether g and other have the same vertices (by key), the same edges (including labels) and, if dataEq isn't nil, equal Data
+// according to dataEq. The order vertices and edges were added in doesn't matter, so it's handy for testing code which builds graphs.
+// Options, aliases and other annotations aren't compared.
+func (g *Graph[T]) Equal(other *Graph[T], dataEq func(a, b T) bool) bool {
+	if len(g.nodes) != len(other.nodes) || g.edgeCount != other.edgeCount {
+		return false
+	}
+	for _, node := range g.nodes {
+		otherNode, ok := other.Vertex(node.Key)
+		if !ok || otherNode.Key != node.Key {
+			return false
+		}
+		if dataEq != nil && !dataEq(node.Data, otherNode.Data) {
+			return false
+		}
+	}
+	return reflect.DeepEqual(g.sortedEdges(), other.sortedEdges())
+}
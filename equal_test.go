@@ -0,0 +1,85 @@
+package topologicalsort
+
+import "testing"
+
+func TestEqual(t *testing.T) {
+	// build builds a graph from vertices and edges, in the given order
+	build := func(keys []string, edges ...Edge) *Graph[string] {
+		graph := NewGraphWithOptions[string](WithAllowParallelEdges())
+		for _, k := range keys {
+			if err := graph.RegisterVertex(k, "data of "+k); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := graph.AddEdges(edges...); err != nil {
+			t.Fatal(err)
+		}
+		return graph
+	}
+	base := build([]string{"a", "b", "c"}, Edge{Source: "a", Dest: "b"}, Edge{Source: "b", Dest: "c", Label: "uses"})
+	stringEq := func(a, b string) bool { return a == b }
+
+	changedData := build([]string{"a", "b", "c"}, Edge{Source: "a", Dest: "b"}, Edge{Source: "b", Dest: "c", Label: "uses"})
+	if err := changedData.SetVertexData("c", "something else"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		other  *Graph[string]
+		dataEq func(a, b string) bool
+		want   bool
+	}{
+		{
+			name:   "Same graph built in another order",
+			other:  build([]string{"c", "a", "b"}, Edge{Source: "b", Dest: "c", Label: "uses"}, Edge{Source: "a", Dest: "b"}),
+			dataEq: stringEq,
+			want:   true,
+		},
+		{
+			name:   "Missing edge",
+			other:  build([]string{"a", "b", "c"}, Edge{Source: "a", Dest: "b"}),
+			dataEq: stringEq,
+			want:   false,
+		},
+		{
+			name:   "Different vertex",
+			other:  build([]string{"a", "b", "d"}, Edge{Source: "a", Dest: "b"}, Edge{Source: "b", Dest: "d", Label: "uses"}),
+			dataEq: stringEq,
+			want:   false,
+		},
+		{
+			name:   "Different label",
+			other:  build([]string{"a", "b", "c"}, Edge{Source: "a", Dest: "b"}, Edge{Source: "b", Dest: "c", Label: "calls"}),
+			dataEq: stringEq,
+			want:   false,
+		},
+		{
+			name:   "Reversed edge",
+			other:  build([]string{"a", "b", "c"}, Edge{Source: "b", Dest: "a"}, Edge{Source: "b", Dest: "c", Label: "uses"}),
+			dataEq: stringEq,
+			want:   false,
+		},
+		{
+			name:   "Different data",
+			other:  changedData,
+			dataEq: stringEq,
+			want:   false,
+		},
+		{
+			name:  "Different data ignored without dataEq",
+			other: changedData,
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := base.Equal(tt.other, tt.dataEq); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+			if got := tt.other.Equal(base, tt.dataEq); got != tt.want {
+				t.Errorf("Equal() the other way around = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
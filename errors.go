@@ -0,0 +1,43 @@
+package topologicalsort
+
+import "fmt"
+
+// GraphError is the error returned for a problem with a specific vertex or edge, e.g. a duplicate vertex, an edge to an unregistered vertex, or a cycle.
+// Use [errors.As] to get at its fields instead of parsing the message; it wraps sentinels like [ErrCycle] and [ErrSelfLoop] where they apply.
+type GraphError struct {
+	// Key is the vertex the error is about: the duplicate or unregistered vertex, or the source of a self loop; empty for errors about an edge as a whole
+	Key string
+	// Source and Dest are the edge the error is about, if any. For cycles, they are the edge which closed the cycle.
+	Source string
+	Dest   string
+	// Index is the position of the offending entry in the input of a bulk call: the edge's position in [Graph.AddEdges],
+	// or the vertex's or edge's position (in the order they were added) in [GraphBuilder.Build]. It's -1 otherwise.
+	Index int
+	err   error
+}
+
+func (e *GraphError) Error() string {
+	return e.err.Error()
+}
+
+func (e *GraphError) Unwrap() error {
+	return e.err
+}
+
+// vertexError returns a *GraphError about the vertex key, with a message formatted like [fmt.Errorf]
+func vertexError(key, format string, args ...any) error {
+	return &GraphError{Key: key, Index: -1, err: fmt.Errorf(format, args...)}
+}
+
+// edgeError returns a *GraphError about the edge from source to dest; key names the offending vertex, if there is one
+func edgeError(source, dest, key, format string, args ...any) error {
+	return &GraphError{Key: key, Source: source, Dest: dest, Index: -1, err: fmt.Errorf(format, args...)}
+}
+
+// withIndex records the position of the offending entry of a bulk call in err, if it's a *GraphError
+func withIndex(err error, index int) error {
+	if graphErr, ok := err.(*GraphError); ok {
+		graphErr.Index = index
+	}
+	return err
+}
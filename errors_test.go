@@ -0,0 +1,76 @@
+package topologicalsort
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGraphError(t *testing.T) {
+	g := graphWithVerticesDUMMYDATA(map[string][]string{
+		"gcc":  {"libc"},
+		"libc": {},
+	}, "")
+
+	tests := []struct {
+		name     string
+		err      error
+		want     GraphError
+		sentinel error
+	}{
+		{
+			name: "Duplicate vertex",
+			err:  g.RegisterVertex("gcc", ""),
+			want: GraphError{Key: "gcc", Index: -1},
+		},
+		{
+			name: "Unregistered dest",
+			err:  g.AddEdge("gcc", "binutils"),
+			want: GraphError{Key: "binutils", Source: "gcc", Dest: "binutils", Index: -1},
+		},
+		{
+			name:     "Self loop",
+			err:      g.AddEdge("gcc", "gcc"),
+			want:     GraphError{Key: "gcc", Source: "gcc", Dest: "gcc", Index: -1},
+			sentinel: ErrSelfLoop,
+		},
+		{
+			name: "Bulk edges",
+			err:  g.AddEdges(Edge{Source: "libc", Dest: "gcc"}, Edge{Source: "gcc", Dest: "libc"}),
+			want: GraphError{Source: "gcc", Dest: "libc", Index: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *GraphError
+			if !errors.As(tt.err, &got) {
+				t.Fatalf("error = %v, want a *GraphError", tt.err)
+			}
+			if got.Key != tt.want.Key || got.Source != tt.want.Source || got.Dest != tt.want.Dest || got.Index != tt.want.Index {
+				t.Errorf("GraphError = %+v, want %+v", *got, tt.want)
+			}
+			if tt.sentinel != nil && !errors.Is(tt.err, tt.sentinel) {
+				t.Errorf("error = %v, want it to wrap %v", tt.err, tt.sentinel)
+			}
+		})
+	}
+
+	// the bulk call above added libc -> gcc before failing, which closes a cycle
+	_, err := g.TopologicalSort()
+	var cycleErr *GraphError
+	if !errors.As(err, &cycleErr) || !errors.Is(err, ErrCycle) || cycleErr.Source == "" || cycleErr.Dest == "" {
+		t.Errorf("Graph.TopologicalSort() error = %v, want a *GraphError naming the edge which closed the cycle", err)
+	}
+}
+
+func TestGraphBuilder_BuildErrorIndex(t *testing.T) {
+	_, err := NewGraphBuilder[string]().
+		AddVertex("gcc", "").
+		AddVertex("libc", "").
+		AddEdge("gcc", "libc").
+		AddEdge("gcc", "make").
+		Build()
+	var graphErr *GraphError
+	if !errors.As(err, &graphErr) || graphErr.Index != 1 || graphErr.Key != "make" {
+		t.Errorf("GraphBuilder.Build() error = %v, want a *GraphError for edge 1", err)
+	}
+}
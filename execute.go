@@ -0,0 +1,355 @@
+package topologicalsort
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// NodeFunc does the work for a single vertex during [Graph.Execute]
+type NodeFunc[T any] func(ctx context.Context, node *GraphNode[T]) error
+
+// NodePolicy controls how [Graph.Execute] runs a single vertex. The zero value runs it once, without a timeout, and stops everything if it fails.
+type NodePolicy struct {
+	// Timeout limits each attempt through its context (so the vertex's function has to respect ctx); 0 means no timeout
+	Timeout time.Duration
+	// Retries is the number of extra attempts after a failure
+	Retries int
+	// Backoff is the wait before the first retry; it doubles for every further retry
+	Backoff time.Duration
+	// ContinueOnError keeps the rest of the graph running if this vertex fails, instead of cancelling everything (fail-fast)
+	ContinueOnError bool
+	// RunDependentsOnFailure runs this vertex's dependents even if it fails; by default they are skipped
+	RunDependentsOnFailure bool
+}
+
+// ExecuteConfig configures [Graph.Execute]
+type ExecuteConfig[T any] struct {
+	// Workers caps the number of vertices running at the same time; 0 means no limit
+	Workers int
+	// Policy returns the policy for a vertex; nil uses the zero NodePolicy for every vertex
+	Policy func(node *GraphNode[T]) NodePolicy
+	// Inputs holds this run's input for some or all of the vertices, by key; a vertex's function reads its input with [NodeInput].
+	// Inputs are how runs over the same graph differ from each other, without changing the graph.
+	Inputs map[string]any
+	// Faults injects failures, delays and cancellations into attempts, for testing policies and failure handling; nil injects nothing
+	Faults *FaultConfig
+}
+
+// NodeStatus is the outcome of a single vertex in [Graph.Execute]
+type NodeStatus int
+
+const (
+	// NodeNotRun means the vertex never started, because execution stopped early
+	NodeNotRun NodeStatus = iota
+	// NodeSucceeded means the vertex's function returned nil
+	NodeSucceeded
+	// NodeFailed means every attempt of the vertex's function returned an error
+	NodeFailed
+	// NodeSkipped means the vertex didn't run because one of its dependencies failed or was skipped
+	NodeSkipped
+)
+
+func (s NodeStatus) String() string {
+	switch s {
+	case NodeSucceeded:
+		return "succeeded"
+	case NodeFailed:
+		return "failed"
+	case NodeSkipped:
+		return "skipped"
+	}
+	return "not run"
+}
+
+// MarshalText implements [encoding.TextMarshaler], so statuses read well in JSON
+func (s NodeStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler]
+func (s *NodeStatus) UnmarshalText(text []byte) error {
+	for _, status := range []NodeStatus{NodeNotRun, NodeSucceeded, NodeFailed, NodeSkipped} {
+		if status.String() == string(text) {
+			*s = status
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown node status %q", text)
+}
+
+// NodeResult is the outcome of a single vertex in [Graph.Execute]
+type NodeResult struct {
+	Status   NodeStatus
+	Attempts int
+	// Err is the error of the last attempt, if the vertex failed
+	Err error
+	// Output is the value the vertex's function passed to [SetOutput], if it succeeded
+	Output any
+}
+
+// Execute runs fn for every vertex, in parallel where dependencies allow: a vertex starts once all of its dependencies have succeeded.
+// It returns the result of every vertex, along with an error joining the errors of all failed vertices (or the context's error, if it was cancelled).
+// It returns an error without running anything if the graph contains a cycle. Execute is [Graph.Start] followed by [Run.Wait].
+func (g *Graph[T]) Execute(ctx context.Context, fn NodeFunc[T], config ExecuteConfig[T]) (map[string]NodeResult, error) {
+	run, err := g.Start(ctx, fn, config)
+	if err != nil {
+		return map[string]NodeResult{}, err
+	}
+	return run.Wait()
+}
+
+// Run is a single execution of the graph, started by [Graph.Start]. A run keeps all of its state to itself and only reads the graph,
+// so several runs over the same graph can be in progress at the same time, as long as nobody changes the graph while they are.
+type Run[T any] struct {
+	graph   *Graph[T]
+	fn      NodeFunc[T]
+	workers int
+	policy  func(node *GraphNode[T]) NodePolicy
+	inputs  map[string]any
+	faults  *FaultConfig
+	started time.Time
+	done    chan struct{}
+	// err is only written before done is closed
+	err error
+
+	mu       sync.Mutex
+	results  map[string]NodeResult
+	timeline []TimelineEvent
+	skipped  []string
+}
+
+// Start starts running fn for every vertex like [Graph.Execute], and returns without waiting for the run to finish.
+// It returns an error without running anything if the graph contains a cycle, or an input is given for an unregistered vertex.
+func (g *Graph[T]) Start(ctx context.Context, fn NodeFunc[T], config ExecuteConfig[T]) (*Run[T], error) {
+	ctx, span := g.startSpan(ctx, "topologicalsort.execute", Attribute{Key: "vertices", Value: len(g.nodes)})
+	scheduler, err := g.NewScheduler()
+	if err != nil {
+		span.End(err)
+		return nil, err
+	}
+	r, err := g.newRun(fn, config)
+	if err != nil {
+		span.End(err)
+		return nil, err
+	}
+	go func() {
+		r.err = r.run(ctx, scheduler)
+		span.End(r.err)
+		close(r.done)
+	}()
+	return r, nil
+}
+
+// newRun returns a run of fn which hasn't started yet. It returns an error if an input is given for an unregistered vertex.
+func (g *Graph[T]) newRun(fn NodeFunc[T], config ExecuteConfig[T]) (*Run[T], error) {
+	inputs := make(map[string]any, len(config.Inputs))
+	for k, v := range config.Inputs {
+		if !g.HasVertex(k) {
+			return nil, vertexError(k, "input given for unregistered vertex %s", k)
+		}
+		inputs[g.canonicalKey(k)] = v
+	}
+	r := &Run[T]{
+		graph:    g,
+		fn:       fn,
+		workers:  config.Workers,
+		policy:   config.Policy,
+		inputs:   inputs,
+		faults:   config.Faults,
+		done:     make(chan struct{}),
+		results:  make(map[string]NodeResult, len(g.nodes)),
+		started:  time.Now(),
+		timeline: make([]TimelineEvent, 0, len(g.nodes)),
+		skipped:  make([]string, 0),
+	}
+	if r.policy == nil {
+		r.policy = func(*GraphNode[T]) NodePolicy { return NodePolicy{} }
+	}
+	for _, node := range g.nodes {
+		r.results[node.Key] = NodeResult{Status: NodeNotRun}
+	}
+	return r, nil
+}
+
+// Wait waits for the run to finish, and returns the same as [Graph.Execute]
+func (r *Run[T]) Wait() (map[string]NodeResult, error) {
+	<-r.done
+	return r.Results(), r.err
+}
+
+// Done returns a channel which is closed when the run has finished
+func (r *Run[T]) Done() <-chan struct{} {
+	return r.done
+}
+
+// Results returns the result of every vertex so far; vertices which haven't finished (yet) are [NodeNotRun]
+func (r *Run[T]) Results() map[string]NodeResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	results := make(map[string]NodeResult, len(r.results))
+	for k, result := range r.results {
+		results[k] = result
+	}
+	return results
+}
+
+// skip records that a vertex was skipped; only the run's own goroutine writes results, so it can read them without locking
+func (r *Run[T]) skip(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results[key] = NodeResult{Status: NodeSkipped}
+	r.skipped = append(r.skipped, key)
+}
+
+// finish records the result of a vertex which ran, along with the end of its timeline event
+func (r *Run[T]) finish(event int, key string, result NodeResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results[key] = result
+	r.timeline[event].End = time.Now()
+	r.timeline[event].Status = result.Status
+	r.timeline[event].Attempts = result.Attempts
+	if result.Err != nil {
+		r.timeline[event].Error = result.Err.Error()
+	}
+}
+
+// run hands the vertices out to fn as the scheduler makes them ready, until everything is done or the run is stopped
+func (r *Run[T]) run(ctx context.Context, scheduler *Scheduler[T]) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type outcome struct {
+		key    string
+		result NodeResult
+		event  int
+		worker int
+	}
+	outcomes := make(chan outcome)
+	queue := make([]*GraphNode[T], 0)
+	running := 0
+	// a vertex runs on the lowest numbered worker which is free, for the timeline
+	workers := 0
+	free := newReadyQueue(func(a, b int) bool { return a < b })
+	failures := make([]error, 0)
+	stopped := false
+
+	// blocked reports whether a vertex can't run because a dependency didn't succeed
+	blocked := func(node *GraphNode[T]) bool {
+		for _, dep := range r.graph.dependencyNodes(node.Key) {
+			switch r.results[dep.Key].Status {
+			case NodeSkipped:
+				return true
+			case NodeFailed:
+				if !r.policy(dep).RunDependentsOnFailure {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	for {
+		if !stopped {
+			// skipping a vertex can make more vertices ready, so keep going until nothing new turns up
+			for next := scheduler.Next(); len(next) > 0; next = scheduler.Next() {
+				for _, node := range next {
+					if blocked(node) {
+						r.skip(node.Key)
+						scheduler.Done(node.Key)
+					} else {
+						queue = append(queue, node)
+					}
+				}
+			}
+			for len(queue) > 0 && (r.workers <= 0 || running < r.workers) {
+				node := queue[0]
+				queue = queue[1:]
+				running++
+				worker := workers
+				if free.Len() > 0 {
+					worker = free.pop()
+				} else {
+					workers++
+				}
+				event := r.startEvent(node.Key, worker)
+				go func() {
+					nodeCtx, span := r.graph.startSpan(ctx, "topologicalsort.node", Attribute{Key: "key", Value: node.Key})
+					var output any
+					result := runNode(nodeCtx, r.nodeFunc(node, &output), node, r.policy(node))
+					result.Output = output
+					span.End(result.Err)
+					outcomes <- outcome{key: node.Key, result: result, event: event, worker: worker}
+				}()
+			}
+		}
+		if running == 0 {
+			break
+		}
+
+		o := <-outcomes
+		running--
+		free.push(o.worker)
+		r.finish(o.event, o.key, o.result)
+		scheduler.Done(o.key)
+		if o.result.Status == NodeFailed {
+			failures = append(failures, nodeError(o.key, o.result))
+			if !r.policy(r.graph.vertex(o.key)).ContinueOnError {
+				stopped = true
+				cancel()
+			}
+		}
+		if ctx.Err() != nil {
+			stopped = true
+		}
+	}
+
+	if len(failures) == 0 && ctx.Err() != nil && !scheduler.Finished() {
+		return ctx.Err()
+	}
+	return errors.Join(failures...)
+}
+
+// nodeError is the error of a failed vertex, for joining into the error of a run
+func nodeError(key string, result NodeResult) error {
+	return fmt.Errorf("vertex %s failed after %d attempts: %w", key, result.Attempts, result.Err)
+}
+
+// runNode runs fn for a single vertex, applying the vertex's timeout and retry policy
+func runNode[T any](ctx context.Context, fn NodeFunc[T], node *GraphNode[T], policy NodePolicy) NodeResult {
+	backoff := policy.Backoff
+	result := NodeResult{}
+	for attempt := 0; attempt <= policy.Retries; attempt++ {
+		if attempt > 0 && backoff > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				result.Status = NodeFailed
+				result.Err = ctx.Err()
+				return result
+			}
+			backoff *= 2
+		}
+
+		attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+		if policy.Timeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		}
+		result.Attempts++
+		result.Err = fn(attemptCtx, node)
+		cancel()
+
+		if result.Err == nil {
+			result.Status = NodeSucceeded
+			return result
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	result.Status = NodeFailed
+	return result
+}
@@ -0,0 +1,227 @@
+package topologicalsort
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGraph_Execute(t *testing.T) {
+	g := progressTestGraph()
+
+	var mu sync.Mutex
+	finished := make(map[string]bool)
+	results, err := g.Execute(context.Background(), func(ctx context.Context, node *GraphNode[string]) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, d := range g.dependencyNodes(node.Key) {
+			if !finished[d.Key] {
+				t.Errorf("%s started before its dependency %s finished", node.Key, d.Key)
+			}
+		}
+		finished[node.Key] = true
+		return nil
+	}, ExecuteConfig[string]{})
+	if err != nil {
+		t.Fatalf("Graph.Execute() unexpected error %v", err)
+	}
+	for k, r := range results {
+		if r.Status != NodeSucceeded || r.Attempts != 1 {
+			t.Errorf("Graph.Execute() result for %s = %+v, want one successful attempt", k, r)
+		}
+	}
+}
+
+func TestGraph_Execute_Workers(t *testing.T) {
+	g := NewGraph("")
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		g.RegisterVertex(k, "")
+	}
+
+	var running, peak int32
+	_, err := g.Execute(context.Background(), func(ctx context.Context, node *GraphNode[string]) error {
+		now := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if now <= old || atomic.CompareAndSwapInt32(&peak, old, now) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	}, ExecuteConfig[string]{Workers: 2})
+	if err != nil {
+		t.Fatalf("Graph.Execute() unexpected error %v", err)
+	}
+	if peak > 2 {
+		t.Errorf("Graph.Execute() ran %d vertices at once, want at most 2", peak)
+	}
+}
+
+func TestGraph_Execute_Policies(t *testing.T) {
+	broken := errors.New("broken")
+	tests := []struct {
+		name    string
+		policy  NodePolicy
+		failing map[string]int // number of attempts that fail, per vertex
+		want    map[string]NodeStatus
+		wantErr bool
+	}{
+		{
+			name:    "Retries can make a flaky vertex succeed",
+			policy:  NodePolicy{Retries: 2, Backoff: time.Millisecond},
+			failing: map[string]int{"gcc": 2},
+			want:    map[string]NodeStatus{"libc": NodeSucceeded, "make": NodeSucceeded, "gcc": NodeSucceeded, "build-essential": NodeSucceeded},
+		},
+		{
+			name:    "Continuing on error skips the failed vertex's dependents",
+			policy:  NodePolicy{ContinueOnError: true},
+			failing: map[string]int{"libc": 1},
+			want:    map[string]NodeStatus{"libc": NodeFailed, "make": NodeSucceeded, "gcc": NodeSkipped, "build-essential": NodeSkipped},
+			wantErr: true,
+		},
+		{
+			name:    "Dependents can run despite a failure",
+			policy:  NodePolicy{ContinueOnError: true, RunDependentsOnFailure: true},
+			failing: map[string]int{"libc": 1},
+			want:    map[string]NodeStatus{"libc": NodeFailed, "make": NodeSucceeded, "gcc": NodeSucceeded, "build-essential": NodeSucceeded},
+			wantErr: true,
+		},
+		{
+			name:    "Failing fast stops everything",
+			policy:  NodePolicy{},
+			failing: map[string]int{"gcc": 1},
+			want:    map[string]NodeStatus{"libc": NodeSucceeded, "make": NodeSucceeded, "gcc": NodeFailed, "build-essential": NodeNotRun},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			attempts := make(map[string]int)
+			results, err := progressTestGraph().Execute(context.Background(), func(ctx context.Context, node *GraphNode[string]) error {
+				mu.Lock()
+				defer mu.Unlock()
+				attempts[node.Key]++
+				if attempts[node.Key] <= tt.failing[node.Key] {
+					return broken
+				}
+				return nil
+			}, ExecuteConfig[string]{
+				// gcc runs on its own, so that make has finished by the time it fails
+				Workers: 1,
+				Policy:  func(*GraphNode[string]) NodePolicy { return tt.policy },
+			})
+			if (err != nil) != tt.wantErr || (tt.wantErr && !errors.Is(err, broken)) {
+				t.Errorf("Graph.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for k, status := range tt.want {
+				if results[k].Status != status {
+					t.Errorf("Graph.Execute() status of %s = %v, want %v", k, results[k].Status, status)
+				}
+			}
+		})
+	}
+}
+
+func TestGraph_Execute_Timeout(t *testing.T) {
+	g := NewGraph("")
+	g.RegisterVertex("slow", "")
+	results, err := g.Execute(context.Background(), func(ctx context.Context, node *GraphNode[string]) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, ExecuteConfig[string]{
+		Policy: func(*GraphNode[string]) NodePolicy { return NodePolicy{Timeout: time.Millisecond, Retries: 1} },
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Graph.Execute() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if r := results["slow"]; r.Status != NodeFailed || r.Attempts != 2 {
+		t.Errorf("Graph.Execute() result = %+v, want two failed attempts", r)
+	}
+}
+
+func TestGraph_Start_ConcurrentRuns(t *testing.T) {
+	g := progressTestGraph()
+	broken := errors.New("broken")
+
+	// odd runs fail at gcc, which must not affect the runs next to them
+	runs := make([]*Run[string], 8)
+	for i := range runs {
+		fail := i%2 == 1
+		run, err := g.Start(context.Background(), func(ctx context.Context, node *GraphNode[string]) error {
+			time.Sleep(time.Millisecond)
+			if fail && node.Key == "gcc" {
+				return broken
+			}
+			return nil
+		}, ExecuteConfig[string]{Policy: func(*GraphNode[string]) NodePolicy { return NodePolicy{ContinueOnError: true} }})
+		if err != nil {
+			t.Fatalf("Graph.Start() unexpected error %v", err)
+		}
+		runs[i] = run
+	}
+	for i, run := range runs {
+		results, err := run.Wait()
+		want := map[string]NodeStatus{"libc": NodeSucceeded, "make": NodeSucceeded, "gcc": NodeSucceeded, "build-essential": NodeSucceeded}
+		if i%2 == 1 {
+			want["gcc"], want["build-essential"] = NodeFailed, NodeSkipped
+		}
+		if (err != nil) != (i%2 == 1) {
+			t.Errorf("Run.Wait() of run %d error = %v", i, err)
+		}
+		for k, status := range want {
+			if results[k].Status != status {
+				t.Errorf("Run.Wait() of run %d status of %s = %v, want %v", i, k, results[k].Status, status)
+			}
+		}
+	}
+}
+
+func TestRun_Results(t *testing.T) {
+	g := progressTestGraph()
+	release := make(chan struct{})
+	run, err := g.Start(context.Background(), func(ctx context.Context, node *GraphNode[string]) error {
+		if node.Key == "gcc" {
+			<-release
+		}
+		return nil
+	}, ExecuteConfig[string]{})
+	if err != nil {
+		t.Fatalf("Graph.Start() unexpected error %v", err)
+	}
+
+	// libc and make finish while gcc is held back
+	for run.Results()["make"].Status != NodeSucceeded || run.Results()["libc"].Status != NodeSucceeded {
+		time.Sleep(time.Millisecond)
+	}
+	if status := run.Results()["gcc"].Status; status != NodeNotRun {
+		t.Errorf("Run.Results() status of gcc before it finished = %v, want %v", status, NodeNotRun)
+	}
+	select {
+	case <-run.Done():
+		t.Fatal("Run.Done() closed before gcc finished")
+	default:
+	}
+
+	close(release)
+	<-run.Done()
+	if status := run.Results()["build-essential"].Status; status != NodeSucceeded {
+		t.Errorf("Run.Results() status of build-essential after the run = %v, want %v", status, NodeSucceeded)
+	}
+}
+
+func TestGraph_Start_Cycle(t *testing.T) {
+	g := NewGraph("")
+	g.RegisterVertex("a", "")
+	g.RegisterVertex("b", "")
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "a")
+	if _, err := g.Start(context.Background(), func(context.Context, *GraphNode[string]) error { return nil }, ExecuteConfig[string]{}); !errors.Is(err, ErrCycle) {
+		t.Errorf("Graph.Start() error = %v, want %v", err, ErrCycle)
+	}
+}
@@ -0,0 +1,76 @@
+package topologicalsort
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"time"
+)
+
+// ErrInjectedFault is returned (wrapped) by an attempt which failed because of [ExecuteConfig.Faults]
+var ErrInjectedFault = errors.New("injected fault")
+
+// FaultConfig injects faults into the attempts of a run, for testing failure policies without waiting for real outages (see [ExecuteConfig.Faults]).
+// Whether an attempt gets a fault only depends on the seed, the vertex's key and the attempt's number, so runs with the same seed get the same faults,
+// however their vertices end up being scheduled. A zero rate injects nothing.
+type FaultConfig struct {
+	Seed int64
+	// FailureRate is the probability of an attempt failing with [ErrInjectedFault] without calling the vertex's function
+	FailureRate float64
+	// CancelRate is the probability of an attempt calling the vertex's function with a context which is already cancelled (with [ErrInjectedFault] as its cause)
+	CancelRate float64
+	// MaxDelay is the longest an attempt is delayed before it starts; every attempt gets a random delay up to it
+	MaxDelay time.Duration
+}
+
+// fault is what a [FaultConfig] does to a single attempt
+type fault struct {
+	fail   bool
+	cancel bool
+	delay  time.Duration
+}
+
+// fault returns the fault of an attempt of the vertex registered under key, counting attempts from 1
+func (c *FaultConfig) fault(key string, attempt int) fault {
+	h := fnv.New64a()
+	binary.Write(h, binary.LittleEndian, c.Seed)
+	binary.Write(h, binary.LittleEndian, int64(attempt))
+	h.Write([]byte(key))
+	random := rand.New(rand.NewSource(int64(h.Sum64())))
+
+	// always draw everything, so one rate doesn't change what the others decide
+	f := fault{
+		fail:   random.Float64() < c.FailureRate,
+		cancel: random.Float64() < c.CancelRate,
+	}
+	delay := random.Int63()
+	if c.MaxDelay > 0 {
+		f.delay = time.Duration(delay % (int64(c.MaxDelay) + 1))
+	}
+	return f
+}
+
+// inject applies the fault of an attempt before fn is called: it waits out the delay, and then fails the attempt or cancels its context.
+// It returns the context to call fn with, or an error if the attempt fails without calling fn.
+func (c *FaultConfig) inject(ctx context.Context, key string, attempt int) (context.Context, error) {
+	f := c.fault(key, attempt)
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return ctx, ctx.Err()
+		}
+	}
+	if f.fail {
+		return ctx, fmt.Errorf("%w: attempt %d of vertex %s", ErrInjectedFault, attempt, key)
+	}
+	if f.cancel {
+		cancelled, cancel := context.WithCancelCause(ctx)
+		cancel(ErrInjectedFault)
+		return cancelled, nil
+	}
+	return ctx, nil
+}
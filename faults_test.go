@@ -0,0 +1,113 @@
+package topologicalsort
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecuteConfig_Faults(t *testing.T) {
+	g := NewGraphWithOptions[string]()
+	for i := 0; i < 20; i++ {
+		g.RegisterVertex(fmt.Sprintf("job%02d", i), "")
+	}
+	noop := func(context.Context, *GraphNode[string]) error { return nil }
+	run := func(workers int, faults FaultConfig, policy NodePolicy) (map[string]NodeResult, error) {
+		return g.Execute(context.Background(), noop, ExecuteConfig[string]{
+			Workers: workers,
+			Policy:  func(*GraphNode[string]) NodePolicy { return policy },
+			Faults:  &faults,
+		})
+	}
+	faults := FaultConfig{Seed: 7, FailureRate: 0.5}
+
+	// the same seed fails the same attempts, however the vertices are scheduled
+	policy := NodePolicy{Retries: 1, ContinueOnError: true}
+	serial, err := run(1, faults, policy)
+	if !errors.Is(err, ErrInjectedFault) {
+		t.Fatalf("Graph.Execute() error = %v, want %v", err, ErrInjectedFault)
+	}
+	parallel, _ := run(8, faults, policy)
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Errorf("Graph.Execute() with 8 workers = %v, with 1 worker %v; want the same faults", parallel, serial)
+	}
+	retried, failed := 0, 0
+	for _, r := range serial {
+		if r.Attempts > 1 {
+			retried++
+		}
+		if r.Status == NodeFailed {
+			failed++
+		}
+	}
+	if retried == 0 || failed == 0 || failed == len(serial) {
+		t.Errorf("Graph.Execute() retried %d and failed %d of %d vertices, want some of each", retried, failed, len(serial))
+	}
+
+	// enough retries get every vertex through
+	results, err := run(4, faults, NodePolicy{Retries: 10})
+	if err != nil {
+		t.Errorf("Graph.Execute() with 10 retries unexpected error %v", err)
+	}
+	for k, r := range results {
+		if r.Status != NodeSucceeded {
+			t.Errorf("Graph.Execute() with 10 retries result of %s = %+v, want it to succeed", k, r)
+		}
+	}
+}
+
+func TestExecuteConfig_Faults_Skipping(t *testing.T) {
+	var calls int32
+	results, err := progressTestGraph().Execute(context.Background(), func(context.Context, *GraphNode[string]) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}, ExecuteConfig[string]{
+		Policy: func(*GraphNode[string]) NodePolicy { return NodePolicy{ContinueOnError: true} },
+		Faults: &FaultConfig{FailureRate: 1},
+	})
+	if !errors.Is(err, ErrInjectedFault) || calls != 0 {
+		t.Errorf("Graph.Execute() error = %v after %d calls, want %v without calling anything", err, calls, ErrInjectedFault)
+	}
+	want := map[string]NodeStatus{"libc": NodeFailed, "make": NodeFailed, "gcc": NodeSkipped, "build-essential": NodeSkipped}
+	for k, status := range want {
+		if results[k].Status != status {
+			t.Errorf("Graph.Execute() status of %s = %v, want %v", k, results[k].Status, status)
+		}
+	}
+}
+
+func TestExecuteConfig_Faults_Cancel(t *testing.T) {
+	g := NewGraph("")
+	g.RegisterVertex("gcc", "")
+	results, err := g.Execute(context.Background(), func(ctx context.Context, node *GraphNode[string]) error {
+		if !errors.Is(context.Cause(ctx), ErrInjectedFault) {
+			t.Errorf("context cause = %v, want %v", context.Cause(ctx), ErrInjectedFault)
+		}
+		return ctx.Err()
+	}, ExecuteConfig[string]{Faults: &FaultConfig{CancelRate: 1}})
+	if !errors.Is(err, context.Canceled) || results["gcc"].Status != NodeFailed {
+		t.Errorf("Graph.Execute() = %v, %v; want gcc to fail with %v", results, err, context.Canceled)
+	}
+}
+
+func TestFaultConfig_Delay(t *testing.T) {
+	faults := FaultConfig{Seed: 1, MaxDelay: 10 * time.Millisecond}
+	delays := make(map[time.Duration]bool)
+	for i := 0; i < 50; i++ {
+		f := faults.fault(fmt.Sprint(i), 1)
+		if f.delay < 0 || f.delay > faults.MaxDelay || f.fail || f.cancel {
+			t.Fatalf("FaultConfig.fault() = %+v, want only a delay up to %v", f, faults.MaxDelay)
+		}
+		if f != faults.fault(fmt.Sprint(i), 1) {
+			t.Fatalf("FaultConfig.fault() differs between calls for the same attempt")
+		}
+		delays[f.delay] = true
+	}
+	if len(delays) < 10 {
+		t.Errorf("FaultConfig.fault() gave %d different delays for 50 vertices, want them spread out", len(delays))
+	}
+}
@@ -0,0 +1,102 @@
+package topologicalsort
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// String returns a human-readable description of the graph: its vertices with their Data, its edges, and the sorted order (if the graph has been sorted).
+// Vertices and edges are listed in sorted order, so the output is stable.
+func (g *Graph[T]) String() string {
+	return g.StringIndent("  ")
+}
+
+// StringIndent works like [Graph.String], using indent to indent list entries
+func (g *Graph[T]) StringIndent(indent string) string {
+	var b strings.Builder
+	edges := g.Edges()
+	fmt.Fprintf(&b, "Graph with %d vertices and %d edges\n", len(g.nodes), len(edges))
+
+	b.WriteString("vertices:\n")
+	for _, k := range g.sortedVertexKeys() {
+		fmt.Fprintf(&b, "%s%s: %v\n", indent, k, g.vertex(k).Data)
+	}
+
+	b.WriteString("edges:\n")
+	for _, e := range edges {
+		fmt.Fprintf(&b, "%s%s -> %s", indent, e.Source, e.Dest)
+		if e.Label != "" {
+			fmt.Fprintf(&b, " [%s]", e.Label)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(g.topoSortedOrder) > 0 {
+		fmt.Fprintf(&b, "sorted: %s\n", strings.Join(g.SortedKeys(), " "))
+	}
+	return b.String()
+}
+
+// GoString returns a Go-syntax-like representation of the graph's vertices and edges, used by the %#v verb
+func (g *Graph[T]) GoString() string {
+	// %T of a zero T would print <nil> for interface types like any, so ask reflect for the type itself
+	dataType := reflect.TypeFor[T]()
+	var b strings.Builder
+	fmt.Fprintf(&b, "&topologicalsort.Graph[%v]{vertices: map[string]%v{", dataType, dataType)
+	for i, k := range g.sortedVertexKeys() {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%q: %#v", k, g.vertex(k).Data)
+	}
+	b.WriteString("}, edges: []topologicalsort.Edge{")
+	for i, e := range g.Edges() {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%#v", e)
+	}
+	b.WriteString("}}")
+	return b.String()
+}
+
+// sortedVertexKeys returns the keys of all vertices, sorted
+func (g *Graph[T]) sortedVertexKeys() []string {
+	keys := make([]string, 0, len(g.nodes))
+	for _, node := range g.nodes {
+		keys = append(keys, node.Key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// dotEscaper escapes the only characters a quoted DOT string can't contain as they are; DOT doesn't understand Go's other escapes
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// dotQuote quotes s as a DOT string
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+// WriteDOT writes the graph in Graphviz DOT format, with an arrow from every vertex to each of its dependencies.
+// Vertices and edges are listed in sorted order, so the output is stable.
+func (g *Graph[T]) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph {\n")
+	for _, k := range g.sortedVertexKeys() {
+		fmt.Fprintf(&b, "  %s;\n", dotQuote(k))
+	}
+	for _, e := range g.Edges() {
+		fmt.Fprintf(&b, "  %s -> %s", dotQuote(e.Source), dotQuote(e.Dest))
+		if e.Label != "" {
+			fmt.Fprintf(&b, " [label=%s]", dotQuote(e.Label))
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,101 @@
+package topologicalsort
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func formatTestGraph() *Graph[string] {
+	graph := NewGraph("")
+	graph.AddItem("gcc", "gcc-data")
+	graph.AddItem("libc", "libc-data")
+	graph.AddItem("make", "make-data")
+	graph.AddEdges(Edge{Source: "gcc", Dest: "libc", Label: "links"}, Edge{Source: "make", Dest: "libc"})
+	return graph
+}
+
+func TestGraph_String(t *testing.T) {
+	graph := formatTestGraph()
+	want := `Graph with 3 vertices and 2 edges
+vertices:
+  gcc: gcc-data
+  libc: libc-data
+  make: make-data
+edges:
+  gcc -> libc [links]
+  make -> libc
+`
+	if got := graph.String(); got != want {
+		t.Errorf("Graph.String() = %q, want %q", got, want)
+	}
+
+	graph.TopologicalSortFor("gcc")
+	want = `Graph with 3 vertices and 2 edges
+vertices:
+	gcc: gcc-data
+	libc: libc-data
+	make: make-data
+edges:
+	gcc -> libc [links]
+	make -> libc
+sorted: libc gcc
+`
+	if got := graph.StringIndent("\t"); got != want {
+		t.Errorf("Graph.StringIndent() = %q, want %q", got, want)
+	}
+}
+
+func TestGraph_GoString(t *testing.T) {
+	want := `&topologicalsort.Graph[string]{vertices: map[string]string{"gcc": "gcc-data", "libc": "libc-data", "make": "make-data"}, ` +
+		`edges: []topologicalsort.Edge{topologicalsort.Edge{Source:"gcc", Dest:"libc", Label:"links"}, topologicalsort.Edge{Source:"make", Dest:"libc", Label:""}}}`
+	if got := fmt.Sprintf("%#v", formatTestGraph()); got != want {
+		t.Errorf("Graph.GoString() = %s, want %s", got, want)
+	}
+
+	// the type of an interface T can't be taken from a zero value, which is nil
+	anyGraph := NewGraphWithOptions[any]()
+	anyGraph.RegisterVertex("gcc", 4)
+	want = `&topologicalsort.Graph[interface {}]{vertices: map[string]interface {}{"gcc": 4}, edges: []topologicalsort.Edge{}}`
+	if got := fmt.Sprintf("%#v", anyGraph); got != want {
+		t.Errorf("Graph.GoString() = %s, want %s", got, want)
+	}
+}
+
+func TestGraph_WriteDOT(t *testing.T) {
+	var b strings.Builder
+	if err := formatTestGraph().WriteDOT(&b); err != nil {
+		t.Fatalf("Graph.WriteDOT() unexpected error %v", err)
+	}
+	want := `digraph {
+  "gcc";
+  "libc";
+  "make";
+  "gcc" -> "libc" [label="links"];
+  "make" -> "libc";
+}
+`
+	if b.String() != want {
+		t.Errorf("Graph.WriteDOT() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestGraph_WriteDOT_Quoting(t *testing.T) {
+	graph := NewGraphWithOptions[string]()
+	graph.RegisterVertex("tab\there", "")
+	graph.RegisterVertex(`say "hi" \o/`, "")
+	graph.AddEdges(Edge{Source: "tab\there", Dest: `say "hi" \o/`, Label: "soft\u00adhyphen"})
+
+	var b strings.Builder
+	if err := graph.WriteDOT(&b); err != nil {
+		t.Fatalf("Graph.WriteDOT() unexpected error %v", err)
+	}
+	want := "digraph {\n" +
+		`  "say \"hi\" \\o/";` + "\n" +
+		"  \"tab\there\";\n" +
+		"  \"tab\there\" -> " + `"say \"hi\" \\o/"` + " [label=\"soft\u00adhyphen\"];\n" +
+		"}\n"
+	if b.String() != want {
+		t.Errorf("Graph.WriteDOT() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,83 @@
+package topologicalsort_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/groovemonkey/topologicalsort"
+	"github.com/groovemonkey/topologicalsort/topotest"
+)
+
+// checkOrder reports whether order contains every vertex exactly once, with every vertex after its dependencies
+func checkOrder[T any](t *testing.T, g *topologicalsort.Graph[T], order []string, vertices int) {
+	t.Helper()
+	if len(order) != vertices {
+		t.Fatalf("sorted order has %d vertices, want %d", len(order), vertices)
+	}
+	position := make(map[string]int, len(order))
+	for i, k := range order {
+		if _, ok := position[k]; ok {
+			t.Fatalf("sorted order contains %s more than once", k)
+		}
+		position[k] = i
+	}
+	for _, e := range g.Edges() {
+		if position[e.Source] < position[e.Dest] {
+			t.Fatalf("sorted order puts %s before its dependency %s", e.Source, e.Dest)
+		}
+	}
+}
+
+func FuzzTopologicalSort_RandomDAG(f *testing.F) {
+	f.Add(int64(1), uint8(0), 0.5)
+	f.Add(int64(2), uint8(1), 0.0)
+	f.Add(int64(3), uint8(40), 0.1)
+	f.Add(int64(4), uint8(100), 1.0)
+
+	f.Fuzz(func(t *testing.T, seed int64, vertices uint8, density float64) {
+		rng := rand.New(rand.NewSource(seed))
+		g := topotest.RandomDAG(rng, int(vertices), density)
+
+		sorted, err := g.TopologicalSort()
+		if err != nil {
+			t.Fatalf("Graph.TopologicalSort() unexpected error for a DAG: %v", err)
+		}
+		checkOrder(t, g, sorted, int(vertices))
+
+		if vertices >= 2 {
+			cyclic := topotest.RandomCyclicGraph(rng, int(vertices), density)
+			if _, err := cyclic.TopologicalSort(); err == nil {
+				t.Fatalf("Graph.TopologicalSort() expected an error for a cyclic graph")
+			}
+		}
+	})
+}
+
+// FuzzTopologicalSort_Edges builds a graph from arbitrary bytes (pairs of vertex indices) and checks that DFS and level-based sorting agree about cycles
+func FuzzTopologicalSort_Edges(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{1, 0, 2, 1, 3, 1})
+	f.Add([]byte{0, 1, 1, 0})
+	f.Add([]byte{4, 4})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		const vertices = 16
+		g := topologicalsort.NewGraph(0)
+		for i := 0; i < vertices; i++ {
+			g.RegisterVertex(string(rune('a'+i)), i)
+		}
+		for i := 0; i+1 < len(data); i += 2 {
+			// duplicate edges are rejected, which is fine
+			g.AddEdge(string(rune('a'+data[i]%vertices)), string(rune('a'+data[i+1]%vertices)))
+		}
+
+		sorted, sortErr := g.TopologicalSort()
+		_, levelsErr := g.Levels()
+		if (sortErr != nil) != (levelsErr != nil) {
+			t.Fatalf("TopologicalSort error %v and Levels error %v disagree", sortErr, levelsErr)
+		}
+		if sortErr == nil {
+			checkOrder(t, g, sorted, vertices)
+		}
+	})
+}
@@ -1,3 +1,3 @@
 module github.com/groovemonkey/topologicalsort
 
-go 1.20
+go 1.23
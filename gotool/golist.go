@@ -0,0 +1,68 @@
+// Package gotool builds dependency graphs from the output of Go toolchain commands.
+package gotool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// Package is the subset of a `go list -json` package description that ends up as vertex Data
+type Package struct {
+	ImportPath string
+	Name       string
+	Dir        string
+	Standard   bool
+	Imports    []string
+}
+
+// NewGraphFromGoList builds a graph from the output of `go list -json`, with one vertex per package (keyed by import path) depending on the packages it imports.
+// Imports of packages which aren't part of the listing are left out, so use `go list -deps -json` for the complete graph.
+func NewGraphFromGoList(r io.Reader) (*topologicalsort.Graph[Package], error) {
+	// go list prints one JSON object after another, rather than an array
+	decoder := json.NewDecoder(r)
+	packages := make([]Package, 0)
+	for {
+		var p Package
+		err := decoder.Decode(&p)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode go list output: %w", err)
+		}
+		packages = append(packages, p)
+	}
+
+	graph := topologicalsort.NewGraphWithOptions[Package](topologicalsort.WithCapacity(len(packages), 0))
+	for _, p := range packages {
+		err := graph.RegisterVertex(p.ImportPath, p)
+		if err != nil {
+			return nil, fmt.Errorf("package %s: %w", p.ImportPath, err)
+		}
+	}
+	for _, p := range packages {
+		for _, imported := range p.Imports {
+			if !graph.HasVertex(imported) {
+				continue
+			}
+			err := graph.AddEdge(p.ImportPath, imported)
+			if err != nil {
+				return nil, fmt.Errorf("package %s: %w", p.ImportPath, err)
+			}
+		}
+	}
+	return graph, nil
+}
+
+// SortGoList sorts the packages in the output of `go list -json`, see [NewGraphFromGoList]. Every package comes after the packages it imports.
+func SortGoList(r io.Reader) ([]string, error) {
+	graph, err := NewGraphFromGoList(r)
+	if err != nil {
+		return []string{}, err
+	}
+	return graph.TopologicalSort()
+}
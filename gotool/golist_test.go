@@ -0,0 +1,57 @@
+package gotool
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const goListOutput = `{
+	"Dir": "/src/example.com/app",
+	"ImportPath": "example.com/app",
+	"Name": "main",
+	"Imports": ["example.com/app/internal/db", "example.com/app/internal/web", "fmt"]
+}
+{
+	"Dir": "/src/example.com/app/internal/web",
+	"ImportPath": "example.com/app/internal/web",
+	"Name": "web",
+	"Imports": ["example.com/app/internal/db", "net/http"]
+}
+{
+	"Dir": "/src/example.com/app/internal/db",
+	"ImportPath": "example.com/app/internal/db",
+	"Name": "db",
+	"Imports": ["database/sql"]
+}
+`
+
+func TestNewGraphFromGoList(t *testing.T) {
+	graph, err := NewGraphFromGoList(strings.NewReader(goListOutput))
+	if err != nil {
+		t.Fatalf("NewGraphFromGoList() unexpected error %v", err)
+	}
+	node, ok := graph.Vertex("example.com/app/internal/web")
+	if !ok || node.Data.Name != "web" || node.Data.Dir != "/src/example.com/app/internal/web" {
+		t.Errorf("NewGraphFromGoList() vertex = %+v, want the web package", node)
+	}
+	// standard library packages weren't listed, so they aren't part of the graph
+	if got := len(graph.Edges()); got != 3 {
+		t.Errorf("NewGraphFromGoList() has %d edges, want 3", got)
+	}
+}
+
+func TestSortGoList(t *testing.T) {
+	got, err := SortGoList(strings.NewReader(goListOutput))
+	if err != nil {
+		t.Fatalf("SortGoList() unexpected error %v", err)
+	}
+	want := []string{"example.com/app/internal/db", "example.com/app/internal/web", "example.com/app"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortGoList() = %v, want %v", got, want)
+	}
+
+	if _, err := SortGoList(strings.NewReader(`{"ImportPath": "broken"`)); err == nil {
+		t.Errorf("SortGoList() expected an error for malformed output")
+	}
+}
@@ -0,0 +1,61 @@
+package gotool
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// Module is a module version from the output of `go mod graph`; the main module has no Version
+type Module struct {
+	Path    string
+	Version string
+}
+
+// NewGraphFromGoModGraph builds a graph from the output of `go mod graph`, with one vertex per module version (keyed as printed, e.g. "golang.org/x/text@v0.3.0")
+// depending on the module versions it requires. The graph answers the usual queries: [topologicalsort.Graph.TopologicalSort] for a build order,
+// [topologicalsort.Graph.Depth] for the longest requirement chain, and [topologicalsort.Graph.Ancestors] for the modules requiring a module.
+func NewGraphFromGoModGraph(r io.Reader) (*topologicalsort.Graph[Module], error) {
+	graph := topologicalsort.NewGraphWithOptions[Module](topologicalsort.WithDuplicateEdges(topologicalsort.IgnoreDuplicates))
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected \"module requirement\", got %q", lineNumber, scanner.Text())
+		}
+		for _, key := range fields {
+			if !graph.HasVertex(key) {
+				graph.RegisterVertex(key, parseModule(key))
+			}
+		}
+		if err := graph.AddEdge(fields[0], fields[1]); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read go mod graph output: %w", err)
+	}
+	return graph, nil
+}
+
+// SortGoModGraph sorts the module versions in the output of `go mod graph`, see [NewGraphFromGoModGraph]. Every module comes after the modules it requires.
+func SortGoModGraph(r io.Reader) ([]string, error) {
+	graph, err := NewGraphFromGoModGraph(r)
+	if err != nil {
+		return []string{}, err
+	}
+	return graph.TopologicalSort()
+}
+
+func parseModule(key string) Module {
+	path, version, _ := strings.Cut(key, "@")
+	return Module{Path: path, Version: version}
+}
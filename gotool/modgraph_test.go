@@ -0,0 +1,57 @@
+package gotool
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const goModGraphOutput = `example.com/app golang.org/x/text@v0.3.0
+example.com/app rsc.io/quote@v1.5.2
+rsc.io/quote@v1.5.2 rsc.io/sampler@v1.3.0
+rsc.io/sampler@v1.3.0 golang.org/x/text@v0.3.0
+
+`
+
+func TestNewGraphFromGoModGraph(t *testing.T) {
+	graph, err := NewGraphFromGoModGraph(strings.NewReader(goModGraphOutput))
+	if err != nil {
+		t.Fatalf("NewGraphFromGoModGraph() unexpected error %v", err)
+	}
+	node, ok := graph.Vertex("rsc.io/quote@v1.5.2")
+	if !ok || node.Data != (Module{Path: "rsc.io/quote", Version: "v1.5.2"}) {
+		t.Errorf("NewGraphFromGoModGraph() vertex = %+v, want rsc.io/quote v1.5.2", node)
+	}
+
+	depth, chain, err := graph.Depth()
+	if err != nil {
+		t.Fatalf("Graph.Depth() unexpected error %v", err)
+	}
+	wantChain := []string{"golang.org/x/text@v0.3.0", "rsc.io/sampler@v1.3.0", "rsc.io/quote@v1.5.2", "example.com/app"}
+	if depth != 4 || !reflect.DeepEqual(chain, wantChain) {
+		t.Errorf("Graph.Depth() = %d %v, want 4 %v", depth, chain, wantChain)
+	}
+
+	dependents, err := graph.Ancestors("golang.org/x/text@v0.3.0", 1)
+	if err != nil {
+		t.Fatalf("Graph.Ancestors() unexpected error %v", err)
+	}
+	if want := [][]string{{"example.com/app", "rsc.io/sampler@v1.3.0"}}; !reflect.DeepEqual(dependents, want) {
+		t.Errorf("Graph.Ancestors() = %v, want %v", dependents, want)
+	}
+}
+
+func TestSortGoModGraph(t *testing.T) {
+	got, err := SortGoModGraph(strings.NewReader(goModGraphOutput))
+	if err != nil {
+		t.Fatalf("SortGoModGraph() unexpected error %v", err)
+	}
+	want := []string{"golang.org/x/text@v0.3.0", "rsc.io/sampler@v1.3.0", "rsc.io/quote@v1.5.2", "example.com/app"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortGoModGraph() = %v, want %v", got, want)
+	}
+
+	if _, err := SortGoModGraph(strings.NewReader("example.com/app\n")); err == nil {
+		t.Errorf("SortGoModGraph() expected an error for a malformed line")
+	}
+}
@@ -0,0 +1,124 @@
+// Package graphhttp serves read-only queries about a graph over HTTP, for services which embed a graph and want to expose it:
+//
+//	GET /sort              {"order": [...]}, or 409 with the cycle if the graph can't be sorted (500 if it fails for another reason)
+//	GET /levels            {"levels": [[...], ...]}, or 409 with the cycle (500 if it fails for another reason)
+//	GET /cycles            {"cycles": [[...], ...]}
+//	GET /dependents/{key}  {"key": ..., "dependents": [[...], ...]}, grouped by distance; ?depth=n limits the distance
+//	GET /dot               the graph in Graphviz DOT format
+package graphhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// Handler serves queries about a graph. It serializes its own access to the graph,
+// but the graph must not be changed while the handler is in use, except through [Handler.Update].
+type Handler[T any] struct {
+	mu    sync.Mutex
+	graph *topologicalsort.Graph[T]
+	mux   *http.ServeMux
+}
+
+// NewHandler returns a handler serving queries about graph
+func NewHandler[T any](graph *topologicalsort.Graph[T]) *Handler[T] {
+	h := &Handler[T]{graph: graph, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /sort", h.sort)
+	h.mux.HandleFunc("GET /levels", h.levels)
+	h.mux.HandleFunc("GET /cycles", h.cycles)
+	h.mux.HandleFunc("GET /dependents/{key}", h.dependents)
+	h.mux.HandleFunc("GET /dot", h.dot)
+	return h
+}
+
+// Update calls fn to change the graph while no query is being served
+func (h *Handler[T]) Update(fn func(graph *topologicalsort.Graph[T])) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	fn(h.graph)
+}
+
+func (h *Handler[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler[T]) sort(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	order, err := h.graph.TopologicalSort()
+	if err != nil {
+		h.writeSortError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"order": order})
+}
+
+func (h *Handler[T]) levels(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	levels, err := h.graph.Levels()
+	if err != nil {
+		h.writeSortError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"levels": levels})
+}
+
+func (h *Handler[T]) cycles(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{"cycles": h.graph.Cycles()})
+}
+
+func (h *Handler[T]) dependents(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	depth := 0
+	if s := r.URL.Query().Get("depth"); s != "" {
+		var err error
+		depth, err = strconv.Atoi(s)
+		if err != nil || depth < 0 {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"error": "depth must be a non-negative integer"})
+			return
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	dependents, err := h.graph.Ancestors(key, depth)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]any{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"key": key, "dependents": dependents})
+}
+
+func (h *Handler[T]) dot(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+	h.graph.WriteDOT(w)
+}
+
+// writeSortError reports a failed sort: a cycle is a conflict, reported along with its path, while anything else (e.g. a limit) is an internal error
+func (h *Handler[T]) writeSortError(w http.ResponseWriter, err error) {
+	body := map[string]any{"error": err.Error()}
+	if !errors.Is(err, topologicalsort.ErrCycle) {
+		writeJSON(w, http.StatusInternalServerError, body)
+		return
+	}
+	if cycle, ok := h.graph.CyclePath(err); ok {
+		body["cycle"] = cycle
+	}
+	writeJSON(w, http.StatusConflict, body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
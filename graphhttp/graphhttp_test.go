@@ -0,0 +1,82 @@
+package graphhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+func TestHandler(t *testing.T) {
+	graph := topologicalsort.NewGraphWithOptions[string]()
+	for _, k := range []string{"db", "auth", "app"} {
+		graph.RegisterVertex(k, "")
+	}
+	graph.AddEdge("auth", "db")
+	graph.AddEdge("app", "auth")
+
+	cyclic := topologicalsort.NewGraphWithOptions[string]()
+	cyclic.RegisterVertex("a", "")
+	cyclic.RegisterVertex("b", "")
+	cyclic.AddEdge("a", "b")
+	cyclic.AddEdge("b", "a")
+
+	// pinning app first contradicts its dependencies, which fails the sort without a cycle
+	pinned := topologicalsort.NewGraphWithOptions[string](topologicalsort.PinFirst("app"))
+	for _, k := range []string{"db", "app"} {
+		pinned.RegisterVertex(k, "")
+	}
+	pinned.AddEdge("app", "db")
+
+	tests := []struct {
+		name       string
+		graph      *topologicalsort.Graph[string]
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "Sort", graph: graph, path: "/sort", wantStatus: http.StatusOK, wantBody: `{"order":["db","auth","app"]}`},
+		{name: "Levels", graph: graph, path: "/levels", wantStatus: http.StatusOK, wantBody: `{"levels":[["db"],["auth"],["app"]]}`},
+		{name: "No cycles", graph: graph, path: "/cycles", wantStatus: http.StatusOK, wantBody: `{"cycles":[]}`},
+		{name: "Dependents", graph: graph, path: "/dependents/db", wantStatus: http.StatusOK, wantBody: `{"dependents":[["auth"],["app"]],"key":"db"}`},
+		{name: "Direct dependents", graph: graph, path: "/dependents/db?depth=1", wantStatus: http.StatusOK, wantBody: `{"dependents":[["auth"]],"key":"db"}`},
+		{name: "Dependents of unknown vertex", graph: graph, path: "/dependents/web", wantStatus: http.StatusNotFound},
+		{name: "Invalid depth", graph: graph, path: "/dependents/db?depth=x", wantStatus: http.StatusBadRequest},
+		{name: "DOT", graph: graph, path: "/dot", wantStatus: http.StatusOK, wantBody: `"app" -> "auth";`},
+		{name: "Sorting a cycle", graph: cyclic, path: "/sort", wantStatus: http.StatusConflict, wantBody: `"cycle":["b","a","b"]`},
+		{name: "Levels of a cycle", graph: cyclic, path: "/levels", wantStatus: http.StatusConflict, wantBody: `"error":`},
+		{name: "Sort failing without a cycle", graph: pinned, path: "/sort", wantStatus: http.StatusInternalServerError, wantBody: `"error":"vertex app is pinned first but depends on db"`},
+		{name: "Cycles", graph: cyclic, path: "/cycles", wantStatus: http.StatusOK, wantBody: `{"cycles":[["a","b"]]}`},
+		{name: "Unknown path", graph: graph, path: "/nope", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			NewHandler(tt.graph).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("GET %s status = %d, want %d", tt.path, w.Code, tt.wantStatus)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("GET %s body = %s, want it to contain %s", tt.path, w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestHandler_Update(t *testing.T) {
+	graph := topologicalsort.NewGraphWithOptions[string]()
+	graph.RegisterVertex("db", "")
+	handler := NewHandler(graph)
+	handler.Update(func(graph *topologicalsort.Graph[string]) {
+		graph.RegisterVertex("app", "")
+		graph.AddEdge("app", "db")
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sort", nil))
+	if want := `{"order":["db","app"]}`; !strings.Contains(w.Body.String(), want) {
+		t.Errorf("GET /sort after Update body = %s, want %s", w.Body.String(), want)
+	}
+}
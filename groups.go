@@ -0,0 +1,164 @@
+package topologicalsort
+
+// RegisterGroup registers a group vertex (a "phony" target like "all" or "test"), which has no Data of its own and depends on its members.
+// Sorting puts a group after all of its members; [Graph.ExpandGroups] and [Graph.CollapseGroups] turn groups into plain vertices for sorting or drawing.
+// Members have to be registered, and can be groups themselves. More members can be added later with AddDependency.
+// It returns an error, without changing the graph, if key is already registered as a vertex which isn't a group (whatever the graph's [WithDuplicateVertices] policy),
+// or if a member can't be added (e.g. because of a constraint or limit).
+func (g *Graph[T]) RegisterGroup(key string, members ...string) error {
+	edges, err := g.groupEdges(key, members)
+	if err != nil {
+		return err
+	}
+	var zero T
+	if err := g.RegisterVertex(key, zero); err != nil {
+		return err
+	}
+	g.groups[g.canonicalKey(key)] = true
+	for _, e := range edges {
+		// can't fail: groupEdges checked everything addEdge does
+		if err := g.addEdge(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupEdges checks that every member can be added to the group key before [Graph.RegisterGroup] changes anything,
+// and returns the edges to add (leaving out duplicates which the graph's [WithDuplicateEdges] policy ignores)
+func (g *Graph[T]) groupEdges(key string, members []string) ([]Edge, error) {
+	var zero T
+	group := NewGraphNode(g.canonicalKey(key), zero)
+	if node, ok := g.Vertex(key); ok {
+		if !g.groups[node.Key] {
+			return nil, vertexError(key, "attempted to register vertex %s as a group", key)
+		}
+		group = node
+	}
+	edges := make([]Edge, 0, len(members))
+	added := make(map[string]bool, len(members))
+	for _, m := range members {
+		member, ok := g.Vertex(m)
+		if !ok {
+			return nil, edgeError(key, m, m, "attempted to add unregistered vertex %s to group %s", m, key)
+		}
+		if member.Key == group.Key {
+			return nil, edgeError(key, m, "", "%w: attempted to add group %s to itself", ErrSelfLoop, key)
+		}
+		if added[member.Key] || g.hasEdge(group.Key, member.Key) {
+			if g.config.duplicateEdges != RejectDuplicates {
+				continue
+			}
+			return nil, edgeError(key, m, "", "attempted to add duplicate edge between %s and %s", group.Key, member.Key)
+		}
+		if err := g.checkConstraints(group, member); err != nil {
+			return nil, err
+		}
+		added[member.Key] = true
+		edges = append(edges, Edge{Source: group.Key, Dest: member.Key})
+	}
+	if max := g.config.limits.MaxEdges; max > 0 && g.edgeCount+len(edges) > max {
+		return nil, &LimitError{Limit: "MaxEdges", Max: max}
+	}
+	return edges, nil
+}
+
+// IsGroup reports whether key was registered with [Graph.RegisterGroup]
+func (g *Graph[T]) IsGroup(key string) bool {
+	return g.groups[g.canonicalKey(key)]
+}
+
+// GroupMembers returns the keys of a group's members (its dependencies), in the order they were added
+func (g *Graph[T]) GroupMembers(key string) ([]string, error) {
+	if !g.IsGroup(key) {
+		return []string{}, vertexError(key, "vertex %s is not a group", key)
+	}
+	return g.dependencyKeys(key), nil
+}
+
+// ExpandGroups returns a new graph without group vertices: a vertex which depended on a group depends on the group's members instead (recursively, for groups of groups).
+// Vertices keep their Data, and the new graph has g's options.
+func (g *Graph[T]) ExpandGroups() *Graph[T] {
+	expanded := g.restrict(func(key string) bool { return !g.groups[key] }, func(e Edge) bool { return true })
+	for _, node := range g.nodes {
+		if g.groups[node.Key] {
+			continue
+		}
+		for _, dep := range g.dependencyKeys(node.Key) {
+			if !g.groups[dep] {
+				continue
+			}
+			for _, member := range g.expandGroup(dep, make(map[string]bool)) {
+				if !expanded.hasEdge(node.Key, member) {
+					_ = expanded.addEdge(Edge{Source: node.Key, Dest: member})
+				}
+			}
+		}
+	}
+	return expanded
+}
+
+// expandGroup returns the members of a group which aren't groups themselves, expanding nested groups (and skipping cycles of groups)
+func (g *Graph[T]) expandGroup(key string, seen map[string]bool) []string {
+	seen[key] = true
+	members := make([]string, 0)
+	for _, m := range g.dependencyKeys(key) {
+		switch {
+		case !g.groups[m]:
+			members = append(members, m)
+		case !seen[m]:
+			members = append(members, g.expandGroup(m, seen)...)
+		}
+	}
+	return members
+}
+
+// CollapseGroups returns a new graph in which every group stands in for its members: the members are left out, and their edges to and from
+// vertices outside the group become edges of the group. A vertex in several groups goes into the one registered first; nested groups collapse into the outermost group.
+// Vertices keep their Data, and the new graph has g's options.
+func (g *Graph[T]) CollapseGroups() *Graph[T] {
+	owner := make(map[string]string)
+	var claim func(group, root string)
+	claim = func(group, root string) {
+		for _, m := range g.dependencyKeys(group) {
+			if _, ok := owner[m]; !ok && m != root {
+				owner[m] = root
+				if g.groups[m] {
+					claim(m, root)
+				}
+			}
+		}
+	}
+	for _, node := range g.nodes {
+		if g.groups[node.Key] {
+			if _, ok := owner[node.Key]; !ok {
+				claim(node.Key, node.Key)
+			}
+		}
+	}
+	// a group claimed by another group hands its members on to that one
+	representative := func(key string) string {
+		for i := 0; i < len(g.nodes); i++ {
+			root, ok := owner[key]
+			if !ok {
+				break
+			}
+			key = root
+		}
+		return key
+	}
+
+	collapsed := g.restrict(func(key string) bool { _, ok := owner[key]; return !ok }, func(e Edge) bool { return true })
+	for key := range g.groups {
+		if collapsed.HasVertex(key) {
+			collapsed.groups[key] = true
+		}
+	}
+	for _, e := range g.Edges() {
+		source, dest := representative(e.Source), representative(e.Dest)
+		if source != dest && !collapsed.hasEdge(source, dest) {
+			_ = collapsed.addEdge(Edge{Source: source, Dest: dest, Label: e.Label})
+		}
+	}
+	return collapsed
+}
@@ -0,0 +1,147 @@
+package topologicalsort
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func groupsTestGraph(t *testing.T) *Graph[string] {
+	graph := NewGraphWithOptions[string]()
+	for _, k := range []string{"libc", "gcc", "make", "unit", "integration", "release"} {
+		graph.RegisterVertex(k, k+"-data")
+	}
+	graph.AddEdge("gcc", "libc")
+	graph.AddEdge("unit", "gcc")
+	graph.AddEdge("integration", "make")
+	if err := graph.RegisterGroup("test", "unit", "integration"); err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.RegisterGroup("all", "test", "make"); err != nil {
+		t.Fatal(err)
+	}
+	graph.AddEdge("release", "test")
+	return graph
+}
+
+func TestGraph_RegisterGroup(t *testing.T) {
+	graph := groupsTestGraph(t)
+	if !graph.IsGroup("test") || graph.IsGroup("unit") {
+		t.Errorf("Graph.IsGroup() doesn't tell groups and other vertices apart")
+	}
+	if got, _ := graph.GroupMembers("all"); !reflect.DeepEqual(got, []string{"test", "make"}) {
+		t.Errorf("Graph.GroupMembers() = %v, want [test make]", got)
+	}
+	if _, err := graph.GroupMembers("unit"); err == nil {
+		t.Errorf("Graph.GroupMembers() expected an error for a vertex which isn't a group")
+	}
+	if err := graph.RegisterGroup("docs", "godoc"); err == nil {
+		t.Errorf("Graph.RegisterGroup() expected an error for an unregistered member")
+	}
+
+	want := []string{"libc", "gcc", "make", "unit", "integration", "test", "release", "all"}
+	if got, err := graph.TopologicalSort(); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.TopologicalSort() = %v, %v, want %v", got, err, want)
+	}
+}
+
+func TestGraph_ExpandGroups(t *testing.T) {
+	expanded := groupsTestGraph(t).ExpandGroups()
+	want := []Edge{
+		{Source: "gcc", Dest: "libc"},
+		{Source: "integration", Dest: "make"},
+		{Source: "release", Dest: "integration"},
+		{Source: "release", Dest: "unit"},
+		{Source: "unit", Dest: "gcc"},
+	}
+	if got := expanded.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.ExpandGroups() edges = %v, want %v", got, want)
+	}
+	if expanded.HasVertex("test") || expanded.HasVertex("all") {
+		t.Errorf("Graph.ExpandGroups() kept group vertices")
+	}
+}
+
+func TestGraph_CollapseGroups(t *testing.T) {
+	collapsed := groupsTestGraph(t).CollapseGroups()
+	want := []Edge{
+		{Source: "all", Dest: "gcc"},
+		{Source: "gcc", Dest: "libc"},
+		{Source: "release", Dest: "all"},
+	}
+	if got := collapsed.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.CollapseGroups() edges = %v, want %v", got, want)
+	}
+	if !collapsed.IsGroup("all") || collapsed.HasVertex("test") || collapsed.HasVertex("unit") {
+		t.Errorf("Graph.CollapseGroups() vertices = %v, want all to stand in for its members", collapsed.AdjacencyMap())
+	}
+}
+
+func TestGraph_RegisterGroup_LeavesGraphUnchanged(t *testing.T) {
+	noRelease := errors.New("nothing may depend on release")
+	tests := []struct {
+		name    string
+		opts    []Option
+		members []string
+	}{
+		{name: "Member listed twice", members: []string{"make", "gcc", "make"}},
+		{name: "Too many edges", opts: []Option{WithLimits(Limits{MaxEdges: 3})}, members: []string{"make", "gcc"}},
+		{name: "Constraint violation", members: []string{"make", "release"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := NewGraphWithOptions[string](tt.opts...)
+			for _, k := range []string{"libc", "gcc", "make", "release"} {
+				graph.RegisterVertex(k, "")
+			}
+			graph.AddEdge("gcc", "libc")
+			graph.AddEdge("release", "make")
+			graph.RegisterConstraint(func(source, dest *GraphNode[string]) error {
+				if dest.Key == "release" {
+					return noRelease
+				}
+				return nil
+			})
+			before := graph.String()
+
+			if err := graph.RegisterGroup("all", tt.members...); err == nil {
+				t.Fatalf("Graph.RegisterGroup() expected an error")
+			}
+			if graph.HasVertex("all") || graph.IsGroup("all") || graph.String() != before {
+				t.Errorf("Graph.RegisterGroup() changed the graph despite failing:\n%s", graph.String())
+			}
+		})
+	}
+
+	ignoring := NewGraphWithOptions[string](WithDuplicateEdges(IgnoreDuplicates))
+	ignoring.RegisterVertex("make", "")
+	if err := ignoring.RegisterGroup("all", "make", "make"); err != nil {
+		t.Fatalf("Graph.RegisterGroup() unexpected error %v for a duplicate member the graph ignores", err)
+	}
+	if got, _ := ignoring.GroupMembers("all"); !reflect.DeepEqual(got, []string{"make"}) {
+		t.Errorf("Graph.GroupMembers() = %v, want [make]", got)
+	}
+}
+
+func TestGraph_RegisterGroup_ExistingVertex(t *testing.T) {
+	policies := map[string]DuplicatePolicy{"Ignore duplicates": IgnoreDuplicates, "Replace duplicates": ReplaceDuplicates}
+	for name, policy := range policies {
+		t.Run(name, func(t *testing.T) {
+			graph := NewGraphWithOptions[string](WithDuplicateVertices(policy))
+			graph.RegisterVertex("app", "important-data")
+			graph.RegisterVertex("lib", "")
+			if err := graph.RegisterGroup("app", "lib"); err == nil {
+				t.Errorf("Graph.RegisterGroup() of an existing vertex expected an error")
+			}
+			if node, _ := graph.Vertex("app"); graph.IsGroup("app") || node.Data != "important-data" || len(graph.Edges()) != 0 {
+				t.Errorf("Graph.RegisterGroup() turned vertex app into a group:\n%s", graph.String())
+			}
+
+			// adding members to an existing group is still fine
+			graph.RegisterGroup("all", "lib")
+			if err := graph.RegisterGroup("all", "app"); err != nil {
+				t.Errorf("Graph.RegisterGroup() of an existing group unexpected error %v", err)
+			}
+		})
+	}
+}
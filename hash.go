@@ -0,0 +1,57 @@
+package topologicalsort
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+)
+
+// Hash returns a canonical SHA-256 content hash (hex-encoded) over the graph's vertices, their Data and its edges.
+// It doesn't depend on insertion order, so two graphs with the same content have the same hash. Data is encoded as JSON; use [Graph.HashWith] to encode it differently.
+func (g *Graph[T]) Hash() (string, error) {
+	return g.HashWith(func(data T) ([]byte, error) {
+		return json.Marshal(data)
+	})
+}
+
+// HashWith works like [Graph.Hash], but uses encode to turn each vertex's Data into bytes.
+// encode must be deterministic; returning nil leaves Data out of the hash, so only the graph's structure counts.
+func (g *Graph[T]) HashWith(encode func(data T) ([]byte, error)) (string, error) {
+	h := sha256.New()
+
+	keys := g.sortedVertexKeys()
+	writeUint(h, uint64(len(keys)))
+	for _, k := range keys {
+		data, err := encode(g.vertex(k).Data)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode data of vertex %s: %w", k, err)
+		}
+		writeField(h, []byte(k))
+		writeField(h, data)
+	}
+
+	edges := g.sortedEdges()
+	writeUint(h, uint64(len(edges)))
+	for _, e := range edges {
+		writeField(h, []byte(e.Source))
+		writeField(h, []byte(e.Dest))
+		writeField(h, []byte(e.Label))
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeField writes a length-prefixed field, so that ("ab", "c") and ("a", "bc") hash differently
+func writeField(h hash.Hash, field []byte) {
+	writeUint(h, uint64(len(field)))
+	h.Write(field)
+}
+
+func writeUint(h hash.Hash, n uint64) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], n)
+	h.Write(buf[:])
+}
@@ -0,0 +1,53 @@
+package topologicalsort
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGraph_Hash(t *testing.T) {
+	build := func(keys []string, data map[string]int, edges []Edge) *Graph[int] {
+		g := NewGraph(0)
+		for _, k := range keys {
+			g.RegisterVertex(k, data[k])
+		}
+		g.AddEdges(edges...)
+		return g
+	}
+	data := map[string]int{"one": 1, "two": 2, "three": 3}
+	edges := []Edge{{Source: "two", Dest: "one"}, {Source: "three", Dest: "two"}}
+
+	original := build([]string{"one", "two", "three"}, data, edges)
+	reordered := build([]string{"three", "one", "two"}, data, []Edge{edges[1], edges[0]})
+	changedData := build([]string{"one", "two", "three"}, map[string]int{"one": 1, "two": 2, "three": 4}, edges)
+	changedEdges := build([]string{"one", "two", "three"}, data, []Edge{{Source: "two", Dest: "one"}, {Source: "three", Dest: "one"}})
+	labeledEdges := build([]string{"one", "two", "three"}, data, []Edge{{Source: "two", Dest: "one", Label: "uses"}, edges[1]})
+
+	hash, err := original.Hash()
+	if err != nil {
+		t.Fatalf("Graph.Hash() unexpected error %v", err)
+	}
+	if len(hash) != 64 {
+		t.Errorf("Graph.Hash() = %s, want a hex-encoded SHA-256", hash)
+	}
+	if other, _ := reordered.Hash(); other != hash {
+		t.Errorf("Graph.Hash() depends on insertion order")
+	}
+	for name, g := range map[string]*Graph[int]{"data": changedData, "edges": changedEdges, "edge labels": labeledEdges} {
+		if other, _ := g.Hash(); other == hash {
+			t.Errorf("Graph.Hash() didn't change along with the %s", name)
+		}
+	}
+
+	structureOnly := func(int) ([]byte, error) { return nil, nil }
+	a, _ := original.HashWith(structureOnly)
+	b, _ := changedData.HashWith(structureOnly)
+	if a != b {
+		t.Errorf("Graph.HashWith() should ignore Data the encoder leaves out")
+	}
+
+	failing := errors.New("can't encode")
+	if _, err := original.HashWith(func(int) ([]byte, error) { return nil, failing }); !errors.Is(err, failing) {
+		t.Errorf("Graph.HashWith() error = %v, want %v", err, failing)
+	}
+}
@@ -0,0 +1,79 @@
+package topologicalsort
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// MutationKind says what a [Mutation] did
+type MutationKind string
+
+const (
+	// MutationVertexAdded is recorded when a vertex is registered
+	MutationVertexAdded MutationKind = "vertex_added"
+	// MutationVertexUpdated is recorded when a vertex's Data is replaced (SetVertexData, UpdateVertexData, UpsertVertex or a replaced duplicate)
+	MutationVertexUpdated MutationKind = "vertex_updated"
+	// MutationEdgeAdded is recorded when an edge is added; Source depends on Dest, whatever the graph's [EdgeSemantics]
+	MutationEdgeAdded MutationKind = "edge_added"
+	// MutationVertexMerged is recorded for every vertex Source merged into the vertex Key by [Graph.MergeVertices]
+	MutationVertexMerged MutationKind = "vertex_merged"
+)
+
+// Mutation is an entry of a graph's history, see [WithHistory]
+type Mutation struct {
+	Time   time.Time    `json:"time"`
+	Kind   MutationKind `json:"kind"`
+	Key    string       `json:"key,omitempty"`
+	Source string       `json:"source,omitempty"`
+	Dest   string       `json:"dest,omitempty"`
+	Label  string       `json:"label,omitempty"`
+}
+
+// WithHistory makes the graph keep a journal of its mutations (vertices added, updated or merged, edges added), for debugging how a graph ended up the way it is.
+// It keeps the latest limit entries; a limit of 0 keeps everything. Failed mutations aren't recorded.
+func WithHistory(limit int) Option {
+	return func(c *config) {
+		c.history = true
+		c.historyLimit = limit
+	}
+}
+
+// History returns a copy of the graph's journal, oldest mutation first. It's empty unless the graph was created with [WithHistory].
+func (g *Graph[T]) History() []Mutation {
+	kept := g.history
+	if limit := g.config.historyLimit; limit > 0 && len(kept) > limit {
+		kept = kept[len(kept)-limit:]
+	}
+	history := make([]Mutation, len(kept))
+	copy(history, kept)
+	return history
+}
+
+// WriteHistoryJSON writes the graph's journal to w as a JSON array, see [Graph.History]
+func (g *Graph[T]) WriteHistoryJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(g.History())
+}
+
+// record appends m to the journal, if the graph keeps one, and passes it on to subscribers. Structural changes also invalidate the sort cache key and the key-ordered structure.
+func (g *Graph[T]) record(m Mutation) {
+	if m.Kind != MutationVertexUpdated {
+		g.sortCacheKey = ""
+		g.keyOrder = nil
+	}
+	if !g.config.history && len(g.subscribers) == 0 {
+		return
+	}
+	m.Time = time.Now()
+	g.notify(m)
+	if !g.config.history {
+		return
+	}
+	g.history = append(g.history, m)
+	// drop the oldest entries in one go once the journal has grown to twice its limit, so trimming is cheap on average
+	if limit := g.config.historyLimit; limit > 0 && len(g.history) >= 2*limit {
+		g.history = append(g.history[:0], g.history[len(g.history)-limit:]...)
+	}
+}
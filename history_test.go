@@ -0,0 +1,58 @@
+package topologicalsort
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestWithHistory(t *testing.T) {
+	g := NewGraphWithOptions[string](WithHistory(0))
+	g.RegisterVertex("gcc", "")
+	g.RegisterVertex("libc", "")
+	g.AddEdge("gcc", "libc")
+	g.AddEdge("gcc", "make")
+	g.SetVertexData("gcc", "gcc-data")
+
+	want := []Mutation{
+		{Kind: MutationVertexAdded, Key: "gcc"},
+		{Kind: MutationVertexAdded, Key: "libc"},
+		{Kind: MutationEdgeAdded, Source: "gcc", Dest: "libc"},
+		{Kind: MutationVertexUpdated, Key: "gcc"},
+	}
+	got := g.History()
+	for i := range got {
+		if got[i].Time.IsZero() {
+			t.Errorf("Graph.History()[%d] has no time", i)
+		}
+		got[i].Time = want[i].Time
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.History() = %v, want %v", got, want)
+	}
+
+	var buf bytes.Buffer
+	if err := g.WriteHistoryJSON(&buf); err != nil {
+		t.Fatalf("Graph.WriteHistoryJSON() unexpected error %v", err)
+	}
+	var decoded []Mutation
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || len(decoded) != 4 || decoded[2].Dest != "libc" {
+		t.Errorf("Graph.WriteHistoryJSON() = %s, %v", buf.String(), err)
+	}
+
+	if got := NewGraphWithOptions[string]().History(); len(got) != 0 {
+		t.Errorf("Graph.History() = %v without WithHistory, want nothing", got)
+	}
+}
+
+func TestWithHistory_Limit(t *testing.T) {
+	g := NewGraphWithOptions[int](WithHistory(3))
+	for i, k := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		g.RegisterVertex(k, i)
+	}
+	got := g.History()
+	if len(got) != 3 || got[0].Key != "e" || got[2].Key != "g" {
+		t.Errorf("Graph.History() = %v, want the latest 3 mutations", got)
+	}
+}
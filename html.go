@@ -0,0 +1,180 @@
+package topologicalsort
+
+import (
+	"html/template"
+	"io"
+)
+
+// HTMLOption configures [Graph.ExportHTML]
+type HTMLOption func(*htmlConfig)
+
+type htmlConfig struct {
+	title string
+}
+
+// WithHTMLTitle sets the title of the page written by [Graph.ExportHTML] (by default, "Dependency graph")
+func WithHTMLTitle(title string) HTMLOption {
+	return func(c *htmlConfig) {
+		c.title = title
+	}
+}
+
+// htmlGraph is the JSON the page's renderer draws
+type htmlGraph struct {
+	Title string     `json:"title"`
+	Nodes []htmlNode `json:"nodes"`
+	Edges []htmlEdge `json:"edges"`
+}
+
+type htmlNode struct {
+	Key string `json:"key"`
+	// Level is the vertex's position in [Graph.Levels], or -1 if it's part of, or depends on, a cycle
+	Level int  `json:"level"`
+	Cycle bool `json:"cycle"`
+}
+
+type htmlEdge struct {
+	Source string `json:"source"`
+	Dest   string `json:"dest"`
+	Label  string `json:"label,omitempty"`
+	Cycle  bool   `json:"cycle"`
+}
+
+// ExportHTML writes a standalone HTML page showing the graph, for sharing a visual report without any tooling.
+// The page embeds the graph as JSON and lays it out with a small force-directed renderer: vertices are colored by level,
+// and vertices and edges which are part of a cycle are highlighted in red. Edges point from a vertex to its dependency.
+func (g *Graph[T]) ExportHTML(w io.Writer, opts ...HTMLOption) error {
+	config := htmlConfig{title: "Dependency graph"}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	level := make(map[string]int, len(g.nodes))
+	i := 0
+	for generation := range g.Generations() {
+		for _, n := range generation {
+			level[n.Key] = i
+		}
+		i++
+	}
+	component := make(map[string]int)
+	for i, keys := range g.stronglyConnectedComponents() {
+		for _, k := range keys {
+			component[k] = i + 1
+		}
+	}
+
+	data := htmlGraph{Title: config.title, Nodes: make([]htmlNode, 0, len(g.nodes)), Edges: make([]htmlEdge, 0)}
+	for _, k := range g.sortedVertexKeys() {
+		l, ok := level[k]
+		if !ok {
+			l = -1
+		}
+		data.Nodes = append(data.Nodes, htmlNode{Key: k, Level: l, Cycle: component[k] != 0})
+	}
+	for _, e := range g.Edges() {
+		cycle := component[e.Source] != 0 && component[e.Source] == component[e.Dest]
+		data.Edges = append(data.Edges, htmlEdge{Source: e.Source, Dest: e.Dest, Label: e.Label, Cycle: cycle})
+	}
+	return htmlTemplate.Execute(w, data)
+}
+
+var htmlTemplate = template.Must(template.New("graph").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+  body { margin: 0; font-family: sans-serif; }
+  h1 { font-size: 1.2em; margin: 0.5em; }
+  svg { width: 100vw; height: calc(100vh - 3em); }
+  line { stroke: #999; stroke-width: 1.5; }
+  line.cycle { stroke: #d62728; stroke-width: 2.5; }
+  circle { stroke: #333; stroke-width: 1; }
+  circle.cycle { stroke: #d62728; stroke-width: 3; }
+  text { font-size: 12px; pointer-events: none; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<svg id="graph">
+  <defs>
+    <marker id="arrow" viewBox="0 0 10 10" refX="18" refY="5" markerWidth="6" markerHeight="6" orient="auto-start-reverse">
+      <path d="M 0 0 L 10 5 L 0 10 z" fill="#999"></path>
+    </marker>
+  </defs>
+</svg>
+<script>
+const graph = {{.}};
+const svg = document.getElementById("graph");
+const ns = "http://www.w3.org/2000/svg";
+const width = svg.clientWidth, height = svg.clientHeight;
+const maxLevel = Math.max(1, ...graph.nodes.map(n => n.level));
+const byKey = {};
+
+// start every vertex at its level, so the force layout settles into something readable
+graph.nodes.forEach((n, i) => {
+  const level = n.level < 0 ? maxLevel + 1 : n.level;
+  n.x = 40 + (width - 80) * (i + 1) / (graph.nodes.length + 1);
+  n.y = 40 + (height - 80) * level / (maxLevel + 1);
+  n.vx = 0; n.vy = 0;
+  byKey[n.key] = n;
+});
+
+function color(n) {
+  if (n.level < 0) return "#f4cccc";
+  return "hsl(" + Math.round(220 * n.level / maxLevel) + ", 70%, 70%)";
+}
+
+for (let step = 0; step < 300; step++) {
+  for (const a of graph.nodes) {
+    for (const b of graph.nodes) {
+      if (a === b) continue;
+      const dx = a.x - b.x, dy = a.y - b.y, d2 = Math.max(dx * dx + dy * dy, 25);
+      a.vx += 800 * dx / d2; a.vy += 800 * dy / d2;
+    }
+  }
+  for (const e of graph.edges) {
+    const a = byKey[e.source], b = byKey[e.dest];
+    const dx = b.x - a.x, dy = b.y - a.y, d = Math.sqrt(dx * dx + dy * dy) || 1, f = (d - 80) * 0.02;
+    a.vx += f * dx / d; a.vy += f * dy / d; b.vx -= f * dx / d; b.vy -= f * dy / d;
+  }
+  for (const n of graph.nodes) {
+    // pull vertices back towards their level
+    const level = n.level < 0 ? maxLevel + 1 : n.level;
+    n.vy += (40 + (height - 80) * level / (maxLevel + 1) - n.y) * 0.05;
+    n.x = Math.min(width - 20, Math.max(20, n.x + n.vx * 0.5));
+    n.y = Math.min(height - 20, Math.max(20, n.y + n.vy * 0.5));
+    n.vx *= 0.6; n.vy *= 0.6;
+  }
+}
+
+for (const e of graph.edges) {
+  const a = byKey[e.source], b = byKey[e.dest], line = document.createElementNS(ns, "line");
+  line.setAttribute("x1", a.x); line.setAttribute("y1", a.y);
+  line.setAttribute("x2", b.x); line.setAttribute("y2", b.y);
+  line.setAttribute("marker-end", "url(#arrow)");
+  if (e.cycle) line.setAttribute("class", "cycle");
+  const title = document.createElementNS(ns, "title");
+  title.textContent = e.source + " depends on " + e.dest + (e.label ? " (" + e.label + ")" : "");
+  line.appendChild(title);
+  svg.appendChild(line);
+}
+for (const n of graph.nodes) {
+  const circle = document.createElementNS(ns, "circle");
+  circle.setAttribute("cx", n.x); circle.setAttribute("cy", n.y); circle.setAttribute("r", 8);
+  circle.setAttribute("fill", color(n));
+  if (n.cycle) circle.setAttribute("class", "cycle");
+  const title = document.createElementNS(ns, "title");
+  title.textContent = n.key + (n.level < 0 ? " (cycle)" : " (level " + n.level + ")");
+  circle.appendChild(title);
+  svg.appendChild(circle);
+  const text = document.createElementNS(ns, "text");
+  text.setAttribute("x", n.x + 11); text.setAttribute("y", n.y + 4);
+  text.textContent = n.key;
+  svg.appendChild(text);
+}
+</script>
+</body>
+</html>
+`))
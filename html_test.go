@@ -0,0 +1,33 @@
+package topologicalsort
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGraph_ExportHTML(t *testing.T) {
+	graph := graphWithVerticesDUMMYDATA(map[string][]string{
+		"app":  {"db"},
+		"db":   {},
+		"cron": {"jobs"},
+		"jobs": {"cron"},
+	}, "")
+
+	var b strings.Builder
+	if err := graph.ExportHTML(&b, WithHTMLTitle("Services </title>")); err != nil {
+		t.Fatalf("Graph.ExportHTML() unexpected error %v", err)
+	}
+	page := b.String()
+
+	for _, want := range []string{
+		"<title>Services &lt;/title&gt;</title>",
+		`{"key":"app","level":1,"cycle":false}`,
+		`{"key":"cron","level":-1,"cycle":true}`,
+		`{"source":"cron","dest":"jobs","cycle":true}`,
+		`{"source":"app","dest":"db","cycle":false}`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Graph.ExportHTML() output doesn't contain %s", want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package topologicalsort
+
+import "fmt"
+
+// IndexedGraph is a minimal, allocation-conscious graph for hot paths. Its vertices are the integers 0..n-1, chosen by the caller,
+// and it keeps no keys, Data, maps or pointers. After the first sort, sorting again allocates nothing as long as the caller reuses the output slice.
+type IndexedGraph struct {
+	// dependents[v] holds the vertices which depend on v
+	dependents [][]int32
+	// dependencies[v] is the number of vertices v depends on
+	dependencies []int32
+	// scratch space for Sort
+	remaining []int32
+}
+
+// NewIndexedGraph returns a graph with the vertices 0..n-1 and no edges
+func NewIndexedGraph(n int) *IndexedGraph {
+	g := &IndexedGraph{}
+	g.Reset(n)
+	return g
+}
+
+// Reset removes all edges and resizes the graph to the vertices 0..n-1, reusing the graph's memory where possible
+func (g *IndexedGraph) Reset(n int) {
+	if cap(g.dependencies) < n {
+		g.dependencies = make([]int32, n)
+		g.remaining = make([]int32, n)
+	}
+	g.dependencies = g.dependencies[:n]
+	g.remaining = g.remaining[:n]
+
+	// keep the old dependents slices around, so their backing arrays get reused
+	if cap(g.dependents) < n {
+		g.dependents = append(g.dependents[:cap(g.dependents)], make([][]int32, n-cap(g.dependents))...)
+	}
+	g.dependents = g.dependents[:n]
+	for i := range g.dependents {
+		g.dependents[i] = g.dependents[i][:0]
+		g.dependencies[i] = 0
+	}
+}
+
+// Len returns the number of vertices
+func (g *IndexedGraph) Len() int {
+	return len(g.dependencies)
+}
+
+// AddEdge adds an edge between two vertices: source depends on dest.
+// For speed, duplicate edges aren't detected; they are harmless to the sort.
+func (g *IndexedGraph) AddEdge(source, dest int) error {
+	if source < 0 || source >= len(g.dependencies) {
+		return fmt.Errorf("attempted to add edge to nonexistent vertex %d", source)
+	}
+	if dest < 0 || dest >= len(g.dependencies) {
+		return fmt.Errorf("attempted to add edge from nonexistent vertex %d", dest)
+	}
+	g.dependents[dest] = append(g.dependents[dest], int32(source))
+	g.dependencies[source]++
+	return nil
+}
+
+// Sort appends the vertices to out in a valid topologically sorted order (dependencies first) and returns the extended slice.
+// Pass a slice with enough spare capacity (e.g. out[:0] from the previous call) to avoid allocating.
+// It returns an error if the graph contains a cycle.
+func (g *IndexedGraph) Sort(out []int) ([]int, error) {
+	start := len(out)
+	copy(g.remaining, g.dependencies)
+	for v, count := range g.remaining {
+		if count == 0 {
+			out = append(out, v)
+		}
+	}
+
+	// the output doubles as the queue of vertices whose dependencies are all done
+	for next := start; next < len(out); next++ {
+		for _, d := range g.dependents[out[next]] {
+			g.remaining[d]--
+			if g.remaining[d] == 0 {
+				out = append(out, int(d))
+			}
+		}
+	}
+
+	if sorted := len(out) - start; sorted != len(g.dependencies) {
+		return out[:start], fmt.Errorf("%w: %d vertices are part of, or depend on, a cycle", ErrCycle, len(g.dependencies)-sorted)
+	}
+	return out, nil
+}
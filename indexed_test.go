@@ -0,0 +1,116 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIndexedGraph_Sort(t *testing.T) {
+	tests := []struct {
+		name     string
+		vertices int
+		edges    [][2]int
+		want     []int
+		wantErr  bool
+	}{
+		{
+			name:     "A graph with no vertices is already sorted.",
+			vertices: 0,
+			want:     []int{},
+		},
+		{
+			name:     "Package manager example from cmd",
+			vertices: 4,
+			// 0: build-essential, 1: make, 2: gcc, 3: libc
+			edges: [][2]int{{0, 1}, {0, 2}, {1, 2}, {2, 3}},
+			want:  []int{3, 2, 1, 0},
+		},
+		{
+			name:     "Duplicate edges are harmless",
+			vertices: 2,
+			edges:    [][2]int{{1, 0}, {1, 0}},
+			want:     []int{0, 1},
+		},
+		{
+			name:     "A graph with a cycle triggers an error",
+			vertices: 3,
+			edges:    [][2]int{{0, 1}, {1, 2}, {2, 0}},
+			wantErr:  true,
+		},
+		{
+			name:     "A self loop triggers an error",
+			vertices: 1,
+			edges:    [][2]int{{0, 0}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewIndexedGraph(tt.vertices)
+			for _, e := range tt.edges {
+				if err := g.AddEdge(e[0], e[1]); err != nil {
+					t.Fatalf("IndexedGraph.AddEdge() unexpected error %v", err)
+				}
+			}
+			got, err := g.Sort([]int{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IndexedGraph.Sort() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IndexedGraph.Sort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexedGraph_AddEdge(t *testing.T) {
+	g := NewIndexedGraph(2)
+	if err := g.AddEdge(0, 2); err == nil {
+		t.Errorf("IndexedGraph.AddEdge() expected an error for a nonexistent vertex")
+	}
+	if err := g.AddEdge(-1, 0); err == nil {
+		t.Errorf("IndexedGraph.AddEdge() expected an error for a nonexistent vertex")
+	}
+}
+
+func TestIndexedGraph_Reset(t *testing.T) {
+	g := NewIndexedGraph(3)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.Reset(2)
+	g.AddEdge(0, 1)
+	got, err := g.Sort(nil)
+	if err != nil {
+		t.Fatalf("IndexedGraph.Sort() unexpected error %v", err)
+	}
+	if want := []int{1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IndexedGraph.Sort() = %v, want %v", got, want)
+	}
+
+	for _, n := range []int{4, 5, 9, 6} {
+		g.Reset(n)
+	}
+	g.Reset(4)
+	if g.Len() != 4 {
+		t.Errorf("IndexedGraph.Len() = %d, want 4", g.Len())
+	}
+	got, _ = g.Sort(got[:0])
+	if len(got) != 4 {
+		t.Errorf("IndexedGraph.Sort() after growing = %v, want 4 vertices", got)
+	}
+}
+
+func TestIndexedGraph_Sort_NoAllocs(t *testing.T) {
+	g := NewIndexedGraph(100)
+	for i := 1; i < 100; i++ {
+		g.AddEdge(i, i-1)
+	}
+	out := make([]int, 0, 100)
+	allocs := testing.AllocsPerRun(10, func() {
+		out, _ = g.Sort(out[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("IndexedGraph.Sort() allocated %v times per run, want 0", allocs)
+	}
+}
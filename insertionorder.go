@@ -0,0 +1,48 @@
+package topologicalsort
+
+import "sort"
+
+// WithInsertionOrder makes the graph list vertices in the order they were registered wherever it would otherwise sort them by key,
+// so output follows the input (e.g. an ordered config file) where dependencies allow: within [Graph.Levels] and [Graph.Generations],
+// in [Graph.Roots], [Graph.Keys], [Graph.Descendants] and [Graph.Ancestors], and when [Graph.TopologicalSortWithPreferences],
+// [Graph.TopologicalSortByBlock] and [Graph.TopologicalSortByPhase] choose between vertices which are ready at the same time.
+// [Graph.Edges] lists edges by the registration order of their source, then in the order they were added.
+// TopologicalSort follows insertion order anyway, unless the graph was created with [WithTraversalOrder]([KeyOrder]).
+func WithInsertionOrder() Option {
+	return func(c *config) {
+		c.insertionOrder = true
+	}
+}
+
+// sortKeys sorts the keys of registered vertices by key, or in registration order with [WithInsertionOrder]
+func (g *Graph[T]) sortKeys(keys []string) {
+	if !g.config.insertionOrder {
+		sort.Strings(keys)
+		return
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return g.lessKey(keys[i], keys[j])
+	})
+}
+
+// lessKey reports whether the vertex registered under a comes before the one registered under b in the order of [Graph.sortKeys]
+func (g *Graph[T]) lessKey(a, b string) bool {
+	if !g.config.insertionOrder {
+		return a < b
+	}
+	idA, _ := g.id(a)
+	idB, _ := g.id(b)
+	return idA < idB
+}
+
+// orderedVertexKeys returns the keys of all vertices, ordered by [Graph.sortKeys]
+func (g *Graph[T]) orderedVertexKeys() []string {
+	if !g.config.insertionOrder {
+		return g.sortedVertexKeys()
+	}
+	keys := make([]string, len(g.nodes))
+	for id, node := range g.nodes {
+		keys[id] = node.Key
+	}
+	return keys
+}
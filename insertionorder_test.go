@@ -0,0 +1,106 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+// configGraph returns a graph built from an ordered config file: steps in the order they appear, where "migrate" and "seed" need "database"
+func configGraph(t *testing.T, opts ...Option) *Graph[string] {
+	t.Helper()
+	graph := NewGraphWithOptions[string](opts...)
+	for _, k := range []string{"network", "database", "seed", "migrate", "cache"} {
+		if err := graph.RegisterVertex(k, k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := graph.AddEdges(Edge{Source: "seed", Dest: "database"}, Edge{Source: "migrate", Dest: "database"}, Edge{Source: "database", Dest: "network"}); err != nil {
+		t.Fatal(err)
+	}
+	return graph
+}
+
+func TestWithInsertionOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []Option
+		wantLevels [][]string
+		wantRoots  []string
+		wantKeys   []string
+		wantEdges  []Edge
+	}{
+		{
+			name:       "Sorted by key by default",
+			wantLevels: [][]string{{"cache", "network"}, {"database"}, {"migrate", "seed"}},
+			wantRoots:  []string{"cache", "migrate", "seed"},
+			wantKeys:   []string{"cache", "database", "migrate", "network", "seed"},
+			wantEdges: []Edge{
+				{Source: "database", Dest: "network"},
+				{Source: "migrate", Dest: "database"},
+				{Source: "seed", Dest: "database"},
+			},
+		},
+		{
+			name:       "Insertion order",
+			opts:       []Option{WithInsertionOrder()},
+			wantLevels: [][]string{{"network", "cache"}, {"database"}, {"seed", "migrate"}},
+			wantRoots:  []string{"seed", "migrate", "cache"},
+			wantKeys:   []string{"network", "database", "seed", "migrate", "cache"},
+			wantEdges: []Edge{
+				{Source: "database", Dest: "network"},
+				{Source: "seed", Dest: "database"},
+				{Source: "migrate", Dest: "database"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := configGraph(t, tt.opts...)
+			levels, err := graph.Levels()
+			if err != nil {
+				t.Fatalf("Levels returned unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(levels, tt.wantLevels) {
+				t.Errorf("Levels() = %v, want %v", levels, tt.wantLevels)
+			}
+			if got := graph.Roots(); !reflect.DeepEqual(got, tt.wantRoots) {
+				t.Errorf("Roots() = %v, want %v", got, tt.wantRoots)
+			}
+			if got := graph.Keys(); !reflect.DeepEqual(got, tt.wantKeys) {
+				t.Errorf("Keys() = %v, want %v", got, tt.wantKeys)
+			}
+			if got := graph.Edges(); !reflect.DeepEqual(got, tt.wantEdges) {
+				t.Errorf("Edges() = %v, want %v", got, tt.wantEdges)
+			}
+		})
+	}
+}
+
+func TestWithInsertionOrderTieBreaks(t *testing.T) {
+	graph := configGraph(t, WithInsertionOrder())
+	got, _, err := graph.TopologicalSortWithPreferences()
+	if err != nil {
+		t.Fatalf("TopologicalSortWithPreferences returned unexpected error: %v", err)
+	}
+	want := []string{"network", "database", "seed", "migrate", "cache"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TopologicalSortWithPreferences() = %v, want %v", got, want)
+	}
+
+	ancestors, err := graph.Ancestors("network", 0)
+	if err != nil {
+		t.Fatalf("Ancestors returned unexpected error: %v", err)
+	}
+	wantAncestors := [][]string{{"database"}, {"seed", "migrate"}}
+	if !reflect.DeepEqual(ancestors, wantAncestors) {
+		t.Errorf("Ancestors() = %v, want %v", ancestors, wantAncestors)
+	}
+
+	// the hash only depends on the structure, not on the order it lists things in
+	sorted := configGraph(t)
+	a, _ := graph.Hash()
+	b, _ := sorted.Hash()
+	if a != b || !graph.Equal(sorted, nil) {
+		t.Errorf("WithInsertionOrder changed the graph's hash or equality")
+	}
+}
@@ -0,0 +1,65 @@
+package topologicalsort
+
+// KeyedGraph wraps a [Graph] so that its keys have a distinct string type (e.g. `type ServiceKey string`).
+// A KeyedGraph[ServiceKey, T] refuses a TaskKey at compile time, which prevents accidental edges between keys from different domains.
+type KeyedGraph[K ~string, T any] struct {
+	graph *Graph[T]
+}
+
+// NewKeyedGraph returns an empty graph whose keys have type K and whose vertices hold Data of type T
+func NewKeyedGraph[K ~string, T any]() *KeyedGraph[K, T] {
+	var zero T
+	return &KeyedGraph[K, T]{graph: NewGraph(zero)}
+}
+
+// Graph returns the underlying, plain-string-keyed graph, for everything KeyedGraph doesn't wrap
+func (kg *KeyedGraph[K, T]) Graph() *Graph[T] {
+	return kg.graph
+}
+
+// RegisterVertex registers a new, unconnected vertex in the graph
+func (kg *KeyedGraph[K, T]) RegisterVertex(key K, data T) error {
+	return kg.graph.RegisterVertex(string(key), data)
+}
+
+// AddItem is a more user-friendly alias for [KeyedGraph.RegisterVertex]
+func (kg *KeyedGraph[K, T]) AddItem(key K, data T) error {
+	return kg.RegisterVertex(key, data)
+}
+
+// AddEdge adds an edge between two vertices, see [Graph.AddEdge]
+func (kg *KeyedGraph[K, T]) AddEdge(source, dest K) error {
+	return kg.graph.AddEdge(string(source), string(dest))
+}
+
+// AddDependency makes source depend on dest, see [Graph.AddDependency]
+func (kg *KeyedGraph[K, T]) AddDependency(source, dest K) error {
+	return kg.graph.AddDependency(string(source), string(dest))
+}
+
+// TopologicalSort sorts the graph, see [Graph.TopologicalSort]
+func (kg *KeyedGraph[K, T]) TopologicalSort() ([]K, error) {
+	sorted, err := kg.graph.TopologicalSort()
+	if err != nil {
+		return []K{}, err
+	}
+	return toKeys[K](sorted), nil
+}
+
+// SortedKeys returns the sorted order of the graph keys, see [Graph.SortedKeys]
+func (kg *KeyedGraph[K, T]) SortedKeys() []K {
+	return toKeys[K](kg.graph.SortedKeys())
+}
+
+// SortedValues returns the sorted order of the graph values, see [Graph.SortedValues]
+func (kg *KeyedGraph[K, T]) SortedValues() []T {
+	return kg.graph.SortedValues()
+}
+
+func toKeys[K ~string](keys []string) []K {
+	returnSlice := make([]K, len(keys))
+	for i, k := range keys {
+		returnSlice[i] = K(k)
+	}
+	return returnSlice
+}
@@ -0,0 +1,33 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+type serviceKey string
+
+func TestKeyedGraph_TopologicalSort(t *testing.T) {
+	graph := NewKeyedGraph[serviceKey, int]()
+	var api, db serviceKey = "api", "db"
+	graph.AddItem(api, 1)
+	graph.AddItem(db, 2)
+	graph.AddItem("cache", 3)
+	graph.AddDependency(api, db)
+	graph.AddEdge(api, "cache")
+	graph.AddEdge("cache", db)
+
+	got, err := graph.TopologicalSort()
+	if err != nil {
+		t.Fatalf("KeyedGraph.TopologicalSort() unexpected error %v", err)
+	}
+	if want := []serviceKey{"db", "cache", "api"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("KeyedGraph.TopologicalSort() = %v, want %v", got, want)
+	}
+	if want := []int{2, 3, 1}; !reflect.DeepEqual(graph.SortedValues(), want) {
+		t.Errorf("KeyedGraph.SortedValues() = %v, want %v", graph.SortedValues(), want)
+	}
+	if len(graph.Graph().Edges()) != 3 {
+		t.Errorf("KeyedGraph.Graph() should expose the underlying graph's edges")
+	}
+}
@@ -0,0 +1,173 @@
+package topologicalsort
+
+import "sort"
+
+// Layout is a layered (Sugiyama-style) drawing of the graph, see [Graph.Layout]
+type Layout struct {
+	// Vertices are sorted by layer, then position
+	Vertices []LayoutVertex
+	// Edges are sorted like [Graph.Edges]
+	Edges []LayoutEdge
+	// Layers is the number of layers
+	Layers int
+}
+
+// LayoutVertex is the place of a vertex in a [Layout]. Coordinates are in units of the distance between neighboring vertices and layers;
+// Y is the layer, and X is centered around 0, so that every layer is centered under the others.
+type LayoutVertex struct {
+	Key      string
+	Layer    int
+	Position int
+	X, Y     float64
+}
+
+// LayoutEdge is an edge of a [Layout]. An edge which spans more than one layer passes through Points, one bend per layer in between,
+// listed from the Source end to the Dest end, so it can be drawn without crossing vertices.
+type LayoutEdge struct {
+	Edge
+	Points [][2]float64
+}
+
+// layoutNode is a vertex, or a bend of a long edge, in one layer of the layout
+type layoutNode struct {
+	key   string
+	layer int
+	// neighbors in the layer below (towards the dependencies) and above
+	down, up []int
+}
+
+// Layout assigns every vertex a layer and a position within its layer, for drawing the graph as a layered diagram with dependencies at the bottom (layer 0).
+// Layers are the graph's [Graph.Ranks]. Positions come from the median heuristic, sweeping up and down a few times and keeping the order with the fewest edge crossings.
+// It returns an error if the graph contains a cycle.
+func (g *Graph[T]) Layout() (Layout, error) {
+	levels, err := g.levels()
+	if err != nil {
+		return Layout{}, err
+	}
+
+	// one node per vertex, in key order within every layer, plus a chain of bends for every edge spanning several layers
+	nodes := make([]layoutNode, 0, len(g.nodes))
+	index := make(map[string]int, len(g.nodes))
+	layers := make([][]int, len(levels))
+	for layer, level := range levels {
+		for _, k := range level {
+			index[k] = len(nodes)
+			layers[layer] = append(layers[layer], len(nodes))
+			nodes = append(nodes, layoutNode{key: k, layer: layer})
+		}
+	}
+	edges := g.Edges()
+	bends := make([][]int, len(edges))
+	for i, e := range edges {
+		upper := index[e.Source]
+		for layer := nodes[upper].layer - 1; layer > nodes[index[e.Dest]].layer; layer-- {
+			bend := len(nodes)
+			nodes = append(nodes, layoutNode{layer: layer})
+			layers[layer] = append(layers[layer], bend)
+			bends[i] = append(bends[i], bend)
+			nodes[upper].down = append(nodes[upper].down, bend)
+			nodes[bend].up = append(nodes[bend].up, upper)
+			upper = bend
+		}
+		nodes[upper].down = append(nodes[upper].down, index[e.Dest])
+		nodes[index[e.Dest]].up = append(nodes[index[e.Dest]].up, upper)
+	}
+
+	position := make([]int, len(nodes))
+	for _, layer := range layers {
+		for p, n := range layer {
+			position[n] = p
+		}
+	}
+	best := cloneLayers(layers)
+	bestCrossings := layoutCrossings(nodes, layers, position)
+	for sweep := 0; sweep < 8 && bestCrossings > 0; sweep++ {
+		if sweep%2 == 0 {
+			for l := 1; l < len(layers); l++ {
+				orderByMedian(layers[l], position, func(n int) []int { return nodes[n].down })
+			}
+		} else {
+			for l := len(layers) - 2; l >= 0; l-- {
+				orderByMedian(layers[l], position, func(n int) []int { return nodes[n].up })
+			}
+		}
+		if crossings := layoutCrossings(nodes, layers, position); crossings < bestCrossings {
+			best, bestCrossings = cloneLayers(layers), crossings
+		}
+	}
+
+	layout := Layout{Vertices: make([]LayoutVertex, 0, len(g.nodes)), Edges: make([]LayoutEdge, len(edges)), Layers: len(levels)}
+	coordinates := make([][2]float64, len(nodes))
+	for l, layer := range best {
+		for p, n := range layer {
+			coordinates[n] = [2]float64{float64(p) - float64(len(layer)-1)/2, float64(l)}
+			if nodes[n].key != "" {
+				layout.Vertices = append(layout.Vertices, LayoutVertex{Key: nodes[n].key, Layer: l, Position: p, X: coordinates[n][0], Y: coordinates[n][1]})
+			}
+		}
+	}
+	for i, e := range edges {
+		points := make([][2]float64, len(bends[i]))
+		for j, bend := range bends[i] {
+			points[j] = coordinates[bend]
+		}
+		layout.Edges[i] = LayoutEdge{Edge: e, Points: points}
+	}
+	return layout, nil
+}
+
+// orderByMedian sorts a layer by the median position of every node's neighbors in the adjacent layer, and updates positions.
+// Nodes without neighbors keep their current position as their sort key.
+func orderByMedian(layer []int, position []int, neighbors func(int) []int) {
+	median := make(map[int]float64, len(layer))
+	for _, n := range layer {
+		ps := make([]int, 0)
+		for _, m := range neighbors(n) {
+			ps = append(ps, position[m])
+		}
+		if len(ps) == 0 {
+			median[n] = float64(position[n])
+			continue
+		}
+		sort.Ints(ps)
+		if len(ps)%2 == 1 {
+			median[n] = float64(ps[len(ps)/2])
+		} else {
+			median[n] = float64(ps[len(ps)/2-1]+ps[len(ps)/2]) / 2
+		}
+	}
+	sort.SliceStable(layer, func(i, j int) bool { return median[layer[i]] < median[layer[j]] })
+	for p, n := range layer {
+		position[n] = p
+	}
+}
+
+// layoutCrossings counts the pairs of edge segments which cross between adjacent layers
+func layoutCrossings(nodes []layoutNode, layers [][]int, position []int) int {
+	crossings := 0
+	for l := 1; l < len(layers); l++ {
+		segments := make([][2]int, 0)
+		for _, n := range layers[l] {
+			for _, m := range nodes[n].down {
+				segments = append(segments, [2]int{position[n], position[m]})
+			}
+		}
+		for i := range segments {
+			for j := i + 1; j < len(segments); j++ {
+				a, b := segments[i], segments[j]
+				if (a[0]-b[0])*(a[1]-b[1]) < 0 {
+					crossings++
+				}
+			}
+		}
+	}
+	return crossings
+}
+
+func cloneLayers(layers [][]int) [][]int {
+	clone := make([][]int, len(layers))
+	for i, layer := range layers {
+		clone[i] = append([]int{}, layer...)
+	}
+	return clone
+}
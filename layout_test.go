@@ -0,0 +1,46 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph_Layout(t *testing.T) {
+	graph := graphWithVerticesDUMMYDATA(map[string][]string{
+		"a": {},
+		"b": {},
+		"x": {"b"},
+		"y": {"a"},
+		"z": {"y", "a"},
+	}, "")
+
+	// x and y swap places to avoid crossing, and the edge from z to a bends in layer 1
+	got, err := graph.Layout()
+	if err != nil {
+		t.Fatalf("Graph.Layout() unexpected error %v", err)
+	}
+	want := Layout{
+		Vertices: []LayoutVertex{
+			{Key: "a", Layer: 0, Position: 0, X: -0.5, Y: 0},
+			{Key: "b", Layer: 0, Position: 1, X: 0.5, Y: 0},
+			{Key: "y", Layer: 1, Position: 0, X: -1, Y: 1},
+			{Key: "x", Layer: 1, Position: 2, X: 1, Y: 1},
+			{Key: "z", Layer: 2, Position: 0, X: 0, Y: 2},
+		},
+		Edges: []LayoutEdge{
+			{Edge: Edge{Source: "x", Dest: "b"}, Points: [][2]float64{}},
+			{Edge: Edge{Source: "y", Dest: "a"}, Points: [][2]float64{}},
+			{Edge: Edge{Source: "z", Dest: "a"}, Points: [][2]float64{{0, 1}}},
+			{Edge: Edge{Source: "z", Dest: "y"}, Points: [][2]float64{}},
+		},
+		Layers: 3,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.Layout() = %+v, want %+v", got, want)
+	}
+
+	cyclic := graphWithVerticesDUMMYDATA(map[string][]string{"a": {"b"}, "b": {"a"}}, "")
+	if _, err := cyclic.Layout(); err == nil {
+		t.Errorf("Graph.Layout() expected an error for a cyclic graph")
+	}
+}
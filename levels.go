@@ -0,0 +1,252 @@
+package topologicalsort
+
+import (
+	"fmt"
+	"iter"
+	"sort"
+)
+
+// Levels groups the graph's vertices into levels for parallel execution: every vertex only depends on vertices in earlier levels,
+// so all vertices within a level can be processed at the same time. Keys within a level are sorted.
+// It returns an error if the graph contains a cycle.
+func (g *Graph[T]) Levels() ([][]string, error) {
+	end := g.startSort("topologicalsort.levels")
+	levels, err := g.levels()
+	end(err)
+	return levels, err
+}
+
+func (g *Graph[T]) levels() ([][]string, error) {
+	levels := make([][]string, 0)
+	placed := make(map[string]bool, len(g.nodes))
+	for generation := range g.Generations() {
+		level := make([]string, len(generation))
+		for i, n := range generation {
+			level[i] = n.Key
+			placed[n.Key] = true
+		}
+		levels = append(levels, level)
+	}
+
+	if len(placed) != len(g.nodes) {
+		return [][]string{}, g.levelCycleError(placed)
+	}
+	return levels, nil
+}
+
+// Ranks returns the rank of every vertex: the index of its level in [Graph.Levels], which is the length of the longest chain of dependencies below it.
+// Vertices without dependencies have rank 0. It returns an error if the graph contains a cycle.
+func (g *Graph[T]) Ranks() (map[string]int, error) {
+	levels, err := g.levels()
+	if err != nil {
+		return map[string]int{}, err
+	}
+	ranks := make(map[string]int, len(g.nodes))
+	for i, level := range levels {
+		for _, k := range level {
+			ranks[k] = i
+		}
+	}
+	return ranks, nil
+}
+
+// Generations iterates over the same levels as [Graph.Levels], one generation at a time, without materializing all of them up front.
+// Each generation is sorted by key. If the graph contains a cycle, iteration stops before the vertices which are part of, or depend on, the cycle;
+// use [Graph.Levels] or [Graph.TopologicalSort] to get an error for that.
+func (g *Graph[T]) Generations() iter.Seq[[]*GraphNode[T]] {
+	return func(yield func([]*GraphNode[T]) bool) {
+		remaining, dependents := g.dependencyCounts()
+
+		current := make([]string, 0)
+		for k, count := range remaining {
+			if count == 0 {
+				current = append(current, k)
+			}
+		}
+
+		for len(current) > 0 {
+			g.sortKeys(current)
+			generation := make([]*GraphNode[T], len(current))
+			for i, k := range current {
+				generation[i] = g.vertex(k)
+			}
+			if !yield(generation) {
+				return
+			}
+
+			next := make([]string, 0)
+			for _, k := range current {
+				for _, d := range dependents[k] {
+					remaining[d]--
+					if remaining[d] == 0 {
+						next = append(next, d)
+					}
+				}
+			}
+			current = next
+		}
+	}
+}
+
+// LevelsWithMaxWeight works like [Graph.Levels], but caps the total weight of every level at maxWeight, pushing work into later levels to keep peak resource use down.
+// weight returns the resource weight of a vertex (e.g. derived from its Data); a nil weight counts every vertex as 1, which caps the number of vertices per level.
+// A vertex heavier than maxWeight gets a level of its own. When there is more ready work than fits, vertices with the longest chain of dependents go first.
+func (g *Graph[T]) LevelsWithMaxWeight(maxWeight int, weight func(*GraphNode[T]) int) ([][]string, error) {
+	end := g.startSort("topologicalsort.levels_with_max_weight")
+	levels, err := g.levelsWithMaxWeight(maxWeight, weight)
+	end(err)
+	return levels, err
+}
+
+func (g *Graph[T]) levelsWithMaxWeight(maxWeight int, weight func(*GraphNode[T]) int) ([][]string, error) {
+	if maxWeight <= 0 {
+		return [][]string{}, fmt.Errorf("maximum level weight must be positive, got %d", maxWeight)
+	}
+	if weight == nil {
+		weight = func(*GraphNode[T]) int { return 1 }
+	}
+
+	priority, err := g.chainPriorities()
+	if err != nil {
+		return [][]string{}, err
+	}
+	remaining, dependents := g.dependencyCounts()
+
+	ready := make([]string, 0)
+	for k, count := range remaining {
+		if count == 0 {
+			ready = append(ready, k)
+		}
+	}
+
+	levels := make([][]string, 0)
+	for len(ready) > 0 {
+		sortByPriority(ready, priority)
+
+		level := make([]string, 0)
+		deferred := make([]string, 0)
+		used := 0
+		for _, k := range ready {
+			w := weight(g.vertex(k))
+			if w < 0 {
+				return [][]string{}, fmt.Errorf("vertex %s has negative weight %d", k, w)
+			}
+			if len(level) == 0 || used+w <= maxWeight {
+				level = append(level, k)
+				used += w
+			} else {
+				deferred = append(deferred, k)
+			}
+		}
+
+		ready = deferred
+		for _, k := range level {
+			for _, d := range dependents[k] {
+				remaining[d]--
+				if remaining[d] == 0 {
+					ready = append(ready, d)
+				}
+			}
+		}
+		sort.Strings(level)
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
+
+// LevelsWithMaxWidth works like [Graph.Levels], but splits every level with more than maxWidth vertices into several sequential sub-levels.
+// Vertices with the longest chain of dependents go into the earliest sub-level, so the critical path isn't held up.
+func (g *Graph[T]) LevelsWithMaxWidth(maxWidth int) ([][]string, error) {
+	end := g.startSort("topologicalsort.levels_with_max_width")
+	levels, err := g.levelsWithMaxWidth(maxWidth)
+	end(err)
+	return levels, err
+}
+
+func (g *Graph[T]) levelsWithMaxWidth(maxWidth int) ([][]string, error) {
+	if maxWidth <= 0 {
+		return [][]string{}, fmt.Errorf("maximum level width must be positive, got %d", maxWidth)
+	}
+	levels, err := g.levels()
+	if err != nil {
+		return [][]string{}, err
+	}
+	priority, err := g.chainPriorities()
+	if err != nil {
+		return [][]string{}, err
+	}
+
+	adjusted := make([][]string, 0, len(levels))
+	for _, level := range levels {
+		if len(level) <= maxWidth {
+			adjusted = append(adjusted, level)
+			continue
+		}
+
+		sortByPriority(level, priority)
+		for start := 0; start < len(level); start += maxWidth {
+			end := start + maxWidth
+			if end > len(level) {
+				end = len(level)
+			}
+			subLevel := make([]string, end-start)
+			copy(subLevel, level[start:end])
+			sort.Strings(subLevel)
+			adjusted = append(adjusted, subLevel)
+		}
+	}
+	return adjusted, nil
+}
+
+// dependencyCounts returns the number of dependencies of every vertex, along with the reverse adjacency list
+func (g *Graph[T]) dependencyCounts() (map[string]int, map[string][]string) {
+	remaining := make(map[string]int, len(g.nodes))
+	for id, node := range g.nodes {
+		remaining[node.Key] = len(g.adjacency[id])
+	}
+	return remaining, g.dependentsOf()
+}
+
+// levelCycleError reports the vertices which could never be placed in a level
+func (g *Graph[T]) levelCycleError(placed map[string]bool) error {
+	stuck := make([]string, 0)
+	for _, k := range g.sortedVertexKeys() {
+		if !placed[k] {
+			stuck = append(stuck, k)
+		}
+	}
+	return fmt.Errorf("%w: vertices %v are part of, or depend on, a cycle", ErrCycle, stuck)
+}
+
+// chainPriorities returns, for every vertex, the length of the longest chain of dependents starting at it (a vertex nothing depends on has priority 1)
+func (g *Graph[T]) chainPriorities() (map[string]int, error) {
+	levels, err := g.levels()
+	if err != nil {
+		return nil, err
+	}
+	dependents := g.dependentsOf()
+
+	priority := make(map[string]int, len(g.nodes))
+	for i := len(levels) - 1; i >= 0; i-- {
+		for _, k := range levels[i] {
+			p := 1
+			for _, d := range dependents[k] {
+				if priority[d]+1 > p {
+					p = priority[d] + 1
+				}
+			}
+			priority[k] = p
+		}
+	}
+	return priority, nil
+}
+
+// sortByPriority sorts keys by descending priority, breaking ties by key
+func sortByPriority(keys []string, priority map[string]int) {
+	sort.Slice(keys, func(i, j int) bool {
+		if priority[keys[i]] != priority[keys[j]] {
+			return priority[keys[i]] > priority[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+}
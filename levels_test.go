@@ -0,0 +1,225 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph_Levels(t *testing.T) {
+	tests := []struct {
+		name           string
+		adjacency_list map[string][]string
+		want           [][]string
+		wantErr        bool
+	}{
+		{
+			name:           "A graph with no vertices has no levels",
+			adjacency_list: map[string][]string{},
+			want:           [][]string{},
+		},
+		{
+			name: "Package manager example from cmd",
+			adjacency_list: map[string][]string{
+				"build-essential": {"make", "gcc"},
+				"make":            {},
+				"gcc":             {"libc"},
+				"libc":            {},
+			},
+			want: [][]string{{"libc", "make"}, {"gcc"}, {"build-essential"}},
+		},
+		{
+			name: "A graph with a cycle triggers an error",
+			adjacency_list: map[string][]string{
+				"one":   {},
+				"cycle": {"one", "three"},
+				"three": {"cycle", "one"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphWithVerticesDUMMYDATA(tt.adjacency_list, "")
+			got, err := g.Levels()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Graph.Levels() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graph.Levels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraph_LevelsWithMaxWeight(t *testing.T) {
+	adjacencyList := map[string][]string{
+		"app":     {"compile", "assets"},
+		"compile": {"deps"},
+		"deps":    {},
+		"assets":  {},
+		"docs":    {},
+		"lint":    {},
+	}
+	weights := map[string]int{"app": 1, "compile": 4, "deps": 2, "assets": 3, "docs": 1, "lint": 1}
+
+	tests := []struct {
+		name      string
+		maxWeight int
+		weight    func(*GraphNode[int]) int
+		want      [][]string
+		wantErr   bool
+	}{
+		{
+			name:      "Without weights the cap limits the number of vertices per level",
+			maxWeight: 2,
+			want:      [][]string{{"assets", "deps"}, {"compile", "docs"}, {"app", "lint"}},
+		},
+		{
+			name:      "Weights from Data are respected, and the longest chains go first",
+			maxWeight: 5,
+			weight:    func(n *GraphNode[int]) int { return n.Data },
+			want:      [][]string{{"assets", "deps"}, {"compile", "docs"}, {"app", "lint"}},
+		},
+		{
+			name:      "Vertices heavier than the cap get a level of their own",
+			maxWeight: 3,
+			weight:    func(n *GraphNode[int]) int { return n.Data },
+			want:      [][]string{{"deps", "docs"}, {"assets"}, {"compile"}, {"app", "lint"}},
+		},
+		{
+			name:      "A non-positive cap triggers an error",
+			maxWeight: 0,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphWithVerticesDUMMYDATA(adjacencyList, 0)
+			for k, w := range weights {
+				g.SetVertexData(k, w)
+			}
+			got, err := g.LevelsWithMaxWeight(tt.maxWeight, tt.weight)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Graph.LevelsWithMaxWeight() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graph.LevelsWithMaxWeight() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraph_LevelsWithMaxWidth(t *testing.T) {
+	adjacencyList := map[string][]string{
+		"app":     {"compile", "assets"},
+		"compile": {"deps"},
+		"deps":    {},
+		"assets":  {},
+		"docs":    {},
+		"lint":    {},
+	}
+	tests := []struct {
+		name     string
+		maxWidth int
+		want     [][]string
+		wantErr  bool
+	}{
+		{
+			name:     "Levels narrower than the cap are left alone",
+			maxWidth: 4,
+			want:     [][]string{{"assets", "deps", "docs", "lint"}, {"compile"}, {"app"}},
+		},
+		{
+			name:     "Wide levels are split, longest chains first",
+			maxWidth: 2,
+			want:     [][]string{{"assets", "deps"}, {"docs", "lint"}, {"compile"}, {"app"}},
+		},
+		{
+			name:     "A cap of one produces a total order",
+			maxWidth: 1,
+			want:     [][]string{{"deps"}, {"assets"}, {"docs"}, {"lint"}, {"compile"}, {"app"}},
+		},
+		{
+			name:     "A non-positive cap triggers an error",
+			maxWidth: -1,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphWithVerticesDUMMYDATA(adjacencyList, "")
+			got, err := g.LevelsWithMaxWidth(tt.maxWidth)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Graph.LevelsWithMaxWidth() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graph.LevelsWithMaxWidth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraph_Generations(t *testing.T) {
+	g := graphWithVerticesDUMMYDATA(map[string][]string{
+		"build-essential": {"make", "gcc"},
+		"make":            {},
+		"gcc":             {"libc"},
+		"libc":            {},
+	}, "")
+
+	got := make([][]string, 0)
+	for generation := range g.Generations() {
+		keys := make([]string, len(generation))
+		for i, n := range generation {
+			keys[i] = n.Key
+		}
+		got = append(got, keys)
+	}
+	want, _ := g.Levels()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.Generations() = %v, want %v", got, want)
+	}
+
+	count := 0
+	for range g.Generations() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Graph.Generations() kept going after break")
+	}
+
+	cyclic := graphWithVerticesDUMMYDATA(map[string][]string{"one": {}, "two": {"three", "one"}, "three": {"two"}}, "")
+	got = make([][]string, 0)
+	for generation := range cyclic.Generations() {
+		got = append(got, []string{generation[0].Key})
+	}
+	if want := [][]string{{"one"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.Generations() = %v, want %v for a graph with a cycle", got, want)
+	}
+}
+
+func TestGraph_Ranks(t *testing.T) {
+	graph := graphWithVerticesDUMMYDATA(map[string][]string{
+		"app":   {"auth", "db"},
+		"auth":  {"db"},
+		"db":    {},
+		"cache": {},
+	}, "")
+	want := map[string]int{"db": 0, "cache": 0, "auth": 1, "app": 2}
+	got, err := graph.Ranks()
+	if err != nil {
+		t.Fatalf("Graph.Ranks() unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.Ranks() = %v, want %v", got, want)
+	}
+
+	cyclic := graphWithVerticesDUMMYDATA(map[string][]string{"a": {"b"}, "b": {"a"}}, "")
+	if _, err := cyclic.Ranks(); err == nil {
+		t.Errorf("Graph.Ranks() expected an error for a cyclic graph")
+	}
+}
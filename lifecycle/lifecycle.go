@@ -0,0 +1,141 @@
+// Package lifecycle starts and stops components (servers, connection pools, workers, ...) in dependency order.
+// Components start after the components they depend on, and stop before them; independent components start and stop in parallel.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// Component is a part of an application that has to be started and stopped. Start and Stop may be nil.
+type Component struct {
+	Name      string
+	DependsOn []string
+	Start     func(ctx context.Context) error
+	Stop      func(ctx context.Context) error
+}
+
+// Manager starts and stops registered components. It is safe for concurrent use.
+type Manager struct {
+	mu         sync.Mutex
+	components []Component
+	names      map[string]bool
+	started    map[string]bool
+}
+
+// NewManager returns a Manager without any components
+func NewManager() *Manager {
+	return &Manager{names: make(map[string]bool), started: make(map[string]bool)}
+}
+
+// Register adds a component. Its dependencies don't have to be registered yet, but they have to be by the time StartAll is called.
+func (m *Manager) Register(c Component) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c.Name == "" {
+		return fmt.Errorf("attempted to register a component without a name")
+	}
+	if m.names[c.Name] {
+		return fmt.Errorf("attempted to register component %s twice", c.Name)
+	}
+	m.names[c.Name] = true
+	m.components = append(m.components, c)
+	return nil
+}
+
+// StartAll starts every component which isn't running yet, after the components it depends on have started.
+// If a component fails to start, StartAll cancels the rest and returns the error; the components which did start keep running, so call StopAll to stop them.
+// It returns an error without starting anything if a dependency isn't registered or the dependencies contain a cycle.
+func (m *Manager) StartAll(ctx context.Context) error {
+	graph, err := m.graph(false)
+	if err != nil {
+		return err
+	}
+	_, err = graph.Execute(ctx, func(ctx context.Context, node *topologicalsort.GraphNode[Component]) error {
+		if m.isStarted(node.Key) {
+			return nil
+		}
+		if node.Data.Start != nil {
+			if err := node.Data.Start(ctx); err != nil {
+				return err
+			}
+		}
+		m.setStarted(node.Key, true)
+		return nil
+	}, topologicalsort.ExecuteConfig[Component]{})
+	return err
+}
+
+// StopAll stops every running component, before the components it depends on are stopped.
+// Components keep being stopped if one of them fails to stop; the returned error joins all of the failures.
+func (m *Manager) StopAll(ctx context.Context) error {
+	graph, err := m.graph(true)
+	if err != nil {
+		return err
+	}
+	_, err = graph.Execute(ctx, func(ctx context.Context, node *topologicalsort.GraphNode[Component]) error {
+		if !m.isStarted(node.Key) {
+			return nil
+		}
+		m.setStarted(node.Key, false)
+		if node.Data.Stop != nil {
+			return node.Data.Stop(ctx)
+		}
+		return nil
+	}, topologicalsort.ExecuteConfig[Component]{
+		Policy: func(*topologicalsort.GraphNode[Component]) topologicalsort.NodePolicy {
+			return topologicalsort.NodePolicy{ContinueOnError: true, RunDependentsOnFailure: true}
+		},
+	})
+	return err
+}
+
+// Running reports whether a component has been started and not stopped since
+func (m *Manager) Running(name string) bool {
+	return m.isStarted(name)
+}
+
+// graph builds the dependency graph of the registered components; reversed makes every component depend on its dependents instead, for stopping
+func (m *Manager) graph(reversed bool) (*topologicalsort.Graph[Component], error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	graph := topologicalsort.NewGraphWithOptions[Component](
+		topologicalsort.WithCapacity(len(m.components), 0),
+		topologicalsort.WithDuplicateEdges(topologicalsort.IgnoreDuplicates),
+	)
+	for _, c := range m.components {
+		graph.RegisterVertex(c.Name, c)
+	}
+	for _, c := range m.components {
+		for _, dep := range c.DependsOn {
+			if !graph.HasVertex(dep) {
+				return nil, fmt.Errorf("component %s depends on unregistered component %s", c.Name, dep)
+			}
+			var err error
+			if reversed {
+				err = graph.AddEdge(dep, c.Name)
+			} else {
+				err = graph.AddEdge(c.Name, dep)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("component %s: %w", c.Name, err)
+			}
+		}
+	}
+	return graph, nil
+}
+
+func (m *Manager) isStarted(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.started[name]
+}
+
+func (m *Manager) setStarted(name string, started bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.started[name] = started
+}
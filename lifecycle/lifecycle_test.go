@@ -0,0 +1,116 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// recorder records the order components start and stop in
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) component(name string, deps ...string) Component {
+	record := func(event string) func(context.Context) error {
+		return func(context.Context) error {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.events = append(r.events, event+" "+name)
+			return nil
+		}
+	}
+	return Component{Name: name, DependsOn: deps, Start: record("start"), Stop: record("stop")}
+}
+
+func (r *recorder) position(event string) int {
+	for i, e := range r.events {
+		if e == event {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestManager_StartAllStopAll(t *testing.T) {
+	r := &recorder{}
+	m := NewManager()
+	for _, c := range []Component{
+		r.component("server", "cache", "database"),
+		r.component("cache"),
+		r.component("database", "config"),
+		r.component("config"),
+	} {
+		if err := m.Register(c); err != nil {
+			t.Fatalf("Manager.Register() unexpected error %v", err)
+		}
+	}
+
+	if err := m.StartAll(context.Background()); err != nil {
+		t.Fatalf("Manager.StartAll() unexpected error %v", err)
+	}
+	if !m.Running("server") {
+		t.Errorf("Manager.Running() = false after StartAll")
+	}
+	if err := m.StopAll(context.Background()); err != nil {
+		t.Fatalf("Manager.StopAll() unexpected error %v", err)
+	}
+	if m.Running("server") {
+		t.Errorf("Manager.Running() = true after StopAll")
+	}
+
+	if len(r.events) != 8 {
+		t.Fatalf("events = %v, want every component started and stopped once", r.events)
+	}
+	for _, pair := range [][2]string{{"config", "database"}, {"database", "server"}, {"cache", "server"}} {
+		dep, dependent := pair[0], pair[1]
+		if r.position("start "+dep) > r.position("start "+dependent) {
+			t.Errorf("events = %v, started %s before %s", r.events, dependent, dep)
+		}
+		if r.position("stop "+dependent) > r.position("stop "+dep) {
+			t.Errorf("events = %v, stopped %s before %s", r.events, dep, dependent)
+		}
+	}
+}
+
+func TestManager_StartFailure(t *testing.T) {
+	r := &recorder{}
+	m := NewManager()
+	failing := r.component("database")
+	failing.Start = func(context.Context) error { return errors.New("connection refused") }
+	m.Register(r.component("config"))
+	m.Register(failing)
+	m.Register(r.component("server", "database", "config"))
+
+	if err := m.StartAll(context.Background()); err == nil {
+		t.Fatalf("Manager.StartAll() expected an error")
+	}
+	if m.Running("server") || m.Running("database") {
+		t.Errorf("Manager.StartAll() left a component depending on the failure running")
+	}
+	if err := m.StopAll(context.Background()); err != nil {
+		t.Fatalf("Manager.StopAll() unexpected error %v", err)
+	}
+	if r.position("stop server") != -1 || r.position("stop database") != -1 {
+		t.Errorf("events = %v, stopped components which never started", r.events)
+	}
+}
+
+func TestManager_Errors(t *testing.T) {
+	m := NewManager()
+	m.Register(Component{Name: "a", DependsOn: []string{"b"}})
+	if err := m.Register(Component{Name: "a"}); err == nil {
+		t.Errorf("Manager.Register() expected an error for a duplicate")
+	}
+	if err := m.StartAll(context.Background()); err == nil {
+		t.Errorf("Manager.StartAll() expected an error for an unregistered dependency")
+	}
+	m.Register(Component{Name: "b", DependsOn: []string{"a"}})
+	if err := m.StartAll(context.Background()); !errors.Is(err, topologicalsort.ErrCycle) {
+		t.Errorf("Manager.StartAll() error = %v, want ErrCycle", err)
+	}
+}
@@ -0,0 +1,41 @@
+package topologicalsort
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLimitExceeded is returned (wrapped, as a *[LimitError]) when a graph created with [WithLimits] would grow past a limit
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// Limits caps the size of a graph, see [WithLimits]. A zero field means no limit.
+type Limits struct {
+	// MaxVertices caps the number of vertices RegisterVertex accepts
+	MaxVertices int
+	// MaxEdges caps the number of edges AddEdge accepts
+	MaxEdges int
+	// MaxDepth caps the length of the dependency chains TopologicalSort follows (and so the size of its stack)
+	MaxDepth int
+}
+
+// LimitError says which limit a graph ran into. It wraps [ErrLimitExceeded].
+type LimitError struct {
+	// Limit is the name of the exceeded field of [Limits], e.g. "MaxVertices"
+	Limit string
+	Max   int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s: %s is %d", ErrLimitExceeded, e.Limit, e.Max)
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// WithLimits makes the graph fail cleanly, with a *[LimitError], instead of growing without bounds. It's meant for graphs built from untrusted input.
+func WithLimits(limits Limits) Option {
+	return func(c *config) {
+		c.limits = limits
+	}
+}
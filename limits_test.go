@@ -0,0 +1,35 @@
+package topologicalsort
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithLimits(t *testing.T) {
+	g := NewGraphWithOptions[string](WithLimits(Limits{MaxVertices: 3, MaxEdges: 2, MaxDepth: 2}))
+	for _, k := range []string{"build-essential", "gcc", "libc"} {
+		if err := g.RegisterVertex(k, ""); err != nil {
+			t.Fatalf("Graph.RegisterVertex() unexpected error %v", err)
+		}
+	}
+	err := g.RegisterVertex("make", "")
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != "MaxVertices" || !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Graph.RegisterVertex() error = %v, want a MaxVertices LimitError", err)
+	}
+
+	g.AddEdge("build-essential", "gcc")
+	if _, err := g.TopologicalSort(); err != nil {
+		t.Errorf("Graph.TopologicalSort() unexpected error %v for a chain of 2 vertices", err)
+	}
+	g.AddEdge("gcc", "libc")
+	if _, err := g.TopologicalSort(); !errors.As(err, &limitErr) || limitErr.Limit != "MaxDepth" {
+		t.Errorf("Graph.TopologicalSort() error = %v, want a MaxDepth LimitError", err)
+	}
+	if err := g.AddEdge("build-essential", "libc"); !errors.As(err, &limitErr) || limitErr.Limit != "MaxEdges" {
+		t.Errorf("Graph.AddEdge() error = %v, want a MaxEdges LimitError", err)
+	}
+	if got := limitErr.Error(); got != "limit exceeded: MaxEdges is 2" {
+		t.Errorf("LimitError.Error() = %q", got)
+	}
+}
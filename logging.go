@@ -0,0 +1,39 @@
+package topologicalsort
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// WithLogger makes the graph emit debug-level logs to logger: vertices and edges added, graphs built by [NewGraphFromData], sorts with their timings,
+// and the cycles they find. Graphs without a logger don't log anything.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// logDebug logs msg at debug level with the graph's logger, if it has one
+func (g *Graph[T]) logDebug(msg string, attrs ...slog.Attr) {
+	if g.config.logger == nil {
+		return
+	}
+	g.config.logger.LogAttrs(context.Background(), slog.LevelDebug, msg, attrs...)
+}
+
+// logSort logs a finished sort, and the cycle it found if that's why it failed
+func (g *Graph[T]) logSort(duration time.Duration, err error) {
+	if g.config.logger == nil {
+		return
+	}
+	if errors.Is(err, ErrCycle) {
+		g.logDebug("topologicalsort: cycle found", slog.String("error", err.Error()))
+	}
+	attrs := []slog.Attr{slog.Int("vertices", len(g.nodes)), slog.Int("edges", g.edgeCount), slog.Duration("duration", duration)}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	g.logDebug("topologicalsort: sorted graph", attrs...)
+}
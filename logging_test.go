@@ -0,0 +1,52 @@
+package topologicalsort
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	g := NewGraph("", WithLogger(logger))
+	g.RegisterVertex("one", "")
+	g.RegisterVertex("two", "")
+	g.AddEdge("two", "one")
+	g.TopologicalSort()
+	g.AddEdge("one", "two")
+	g.TopologicalSort()
+
+	logs := buf.String()
+	for _, want := range []string{
+		`msg="topologicalsort: vertex added" key=one`,
+		`msg="topologicalsort: edge added" source=two dest=one`,
+		`msg="topologicalsort: sorted graph" vertices=2 edges=1 duration=`,
+		`msg="topologicalsort: cycle found" error=`,
+		`msg="topologicalsort: sorted graph" vertices=2 edges=2 duration=`,
+	} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("logs don't contain %q:\n%s", want, logs)
+		}
+	}
+	if got := strings.Count(logs, "level=DEBUG"); got != strings.Count(logs, "\n") {
+		t.Errorf("not every log line is at debug level:\n%s", logs)
+	}
+
+	buf.Reset()
+	_, err := NewGraphFromData(map[*GraphNode[string]][]string{NewGraphNode("a", ""): {}}, WithLogger(logger))
+	if err != nil {
+		t.Fatalf("NewGraphFromData() unexpected error %v", err)
+	}
+	if !strings.Contains(buf.String(), `msg="topologicalsort: built graph" vertices=1 edges=0`) {
+		t.Errorf("logs don't report the built graph:\n%s", buf.String())
+	}
+
+	// without a logger, nothing is logged (and nothing breaks)
+	quiet := NewGraph("")
+	quiet.RegisterVertex("one", "")
+	if _, err := quiet.TopologicalSort(); err != nil {
+		t.Errorf("Graph.TopologicalSort() unexpected error %v", err)
+	}
+}
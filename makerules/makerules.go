@@ -0,0 +1,79 @@
+// Package makerules builds dependency graphs from simple Makefile-style rule files.
+//
+// Every rule is a line of the form "target: dep dep ...", optionally followed by tab-indented recipe lines.
+// Blank lines and lines starting with # are ignored, and a trailing backslash continues a rule on the next line.
+package makerules
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// Parse reads rules from r and returns a graph with one vertex per target, depending on its prerequisites.
+// A vertex's Data is its recipe (the recipe lines joined by newlines, without their leading tab).
+// Prerequisites which aren't targets themselves (e.g. source files) become vertices with an empty recipe,
+// and a target listed in several rules gets all of their prerequisites, like in make.
+func Parse(r io.Reader) (*topologicalsort.Graph[string], error) {
+	graph := topologicalsort.NewGraphWithOptions[string](topologicalsort.WithDuplicateEdges(topologicalsort.IgnoreDuplicates))
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	target := ""
+
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		start := lineNumber
+		for strings.HasSuffix(line, "\\") && scanner.Scan() {
+			lineNumber++
+			line = strings.TrimSuffix(line, "\\") + " " + scanner.Text()
+		}
+
+		if strings.HasPrefix(line, "\t") {
+			if target == "" {
+				return nil, fmt.Errorf("line %d: recipe line outside of a rule", start)
+			}
+			err := graph.UpdateVertexData(target, func(recipe string) string {
+				if recipe == "" {
+					return line[1:]
+				}
+				return recipe + "\n" + line[1:]
+			})
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", start, err)
+			}
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		name, prerequisites, ok := strings.Cut(trimmed, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("line %d: expected \"target: prerequisites\", got %q", start, trimmed)
+		}
+
+		target = name
+		if !graph.HasVertex(target) {
+			graph.RegisterVertex(target, "")
+		}
+		for _, dep := range strings.Fields(prerequisites) {
+			if !graph.HasVertex(dep) {
+				graph.RegisterVertex(dep, "")
+			}
+			err := graph.AddEdge(target, dep)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", start, err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rules: %w", err)
+	}
+	return graph, nil
+}
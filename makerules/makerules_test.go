@@ -0,0 +1,80 @@
+package makerules
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+const rules = `# build the app
+app: main.o util.o
+	cc -o app main.o util.o
+
+main.o: main.c util.h
+	cc -c main.c
+util.o: util.c \
+	util.h
+	cc -c util.c
+	strip util.o
+
+# extra prerequisites are merged, like in make
+app: util.o config.h
+`
+
+func TestParse(t *testing.T) {
+	graph, err := Parse(strings.NewReader(rules))
+	if err != nil {
+		t.Fatalf("Parse() unexpected error %v", err)
+	}
+
+	app, _ := graph.Vertex("app")
+	if app.Data != "cc -o app main.o util.o" {
+		t.Errorf("Parse() recipe of app = %q", app.Data)
+	}
+	utilObject, _ := graph.Vertex("util.o")
+	if utilObject.Data != "cc -c util.c\nstrip util.o" {
+		t.Errorf("Parse() recipe of util.o = %q", utilObject.Data)
+	}
+
+	deps, _ := graph.Descendants("app", 1)
+	if want := [][]string{{"config.h", "main.o", "util.o"}}; !reflect.DeepEqual(deps, want) {
+		t.Errorf("Parse() prerequisites of app = %v, want %v", deps, want)
+	}
+	deps, _ = graph.Descendants("util.o", 1)
+	if want := [][]string{{"util.c", "util.h"}}; !reflect.DeepEqual(deps, want) {
+		t.Errorf("Parse() prerequisites of util.o = %v, want %v", deps, want)
+	}
+
+	order, err := graph.TopologicalSortFor("app")
+	if err != nil || order[len(order)-1] != "app" || len(order) != 7 {
+		t.Errorf("TopologicalSortFor(app) = %v, %v", order, err)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr string
+	}{
+		{name: "Lines need a colon", rules: "app main.o\n", wantErr: "line 1"},
+		{name: "Recipes need a rule", rules: "\n\tcc -c main.c\n", wantErr: "line 2"},
+		{name: "Targets can't contain spaces", rules: "# comment\nmy app: main.o\n", wantErr: "line 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.rules))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, want one mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	_, err := Parse(strings.NewReader("a: b\nb: a\nc: c\n"))
+	if !errors.Is(err, topologicalsort.ErrSelfLoop) {
+		t.Errorf("Parse() error = %v, want %v", err, topologicalsort.ErrSelfLoop)
+	}
+}
@@ -0,0 +1,143 @@
+package topologicalsort
+
+// MergeVertices collapses the vertices from into the vertex into, e.g. to model packages which ship as one bundle.
+// Edges to and from the merged vertices are rewired to into. Duplicate edges are dropped, and so are the edges between merged vertices,
+// which would otherwise become self loops. Merging can create a cycle, e.g. when a vertex outside the merge sits between two merged vertices;
+// sorting then reports it. into's Data becomes mergeData(into's Data, then the Data of from in the given order), or stays as it is if mergeData is nil.
+// Phases, blocks, layers and other annotations of the merged vertices are dropped, and their aliases name into from then on.
+// It returns an error, without changing the graph, if a vertex is unregistered or listed twice.
+func (g *Graph[T]) MergeVertices(into string, from []string, mergeData func(data ...T) T) error {
+	intoID, ok := g.id(into)
+	if !ok {
+		return vertexError(into, "attempted to merge into unregistered vertex %s", into)
+	}
+	merged := make(map[int32]bool, len(from))
+	data := []T{g.nodes[intoID].Data}
+	for _, k := range from {
+		id, ok := g.id(k)
+		if !ok {
+			return vertexError(k, "attempted to merge unregistered vertex %s", k)
+		}
+		if id == intoID || merged[id] {
+			return vertexError(k, "vertex %s is listed more than once in the merge", k)
+		}
+		merged[id] = true
+		data = append(data, g.nodes[id].Data)
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+
+	// merged vertices take into's ID; the others keep their order, with the gaps closed
+	newID := make([]int32, len(g.nodes))
+	next := int32(0)
+	for id := range g.nodes {
+		if !merged[int32(id)] {
+			newID[id] = next
+			next++
+		}
+	}
+	for id := range merged {
+		newID[id] = newID[intoID]
+	}
+
+	nodes := make([]*GraphNode[T], 0, next)
+	adjacency := make([][]int32, next)
+	edgeLabels := make([][]string, next)
+	edgeCount := 0
+	for id, dests := range g.adjacency {
+		source := newID[id]
+		for i, dest := range dests {
+			dest = newID[dest]
+			label := g.edgeLabel(int32(id), i)
+			if source == dest || g.containsRewiredEdge(adjacency[source], edgeLabels[source], dest, label) {
+				continue
+			}
+			if label != "" && edgeLabels[source] == nil {
+				edgeLabels[source] = make([]string, len(adjacency[source]))
+			}
+			adjacency[source] = append(adjacency[source], dest)
+			if edgeLabels[source] != nil {
+				edgeLabels[source] = append(edgeLabels[source], label)
+			}
+			edgeCount++
+		}
+	}
+	for id, node := range g.nodes {
+		if !merged[int32(id)] {
+			nodes = append(nodes, node)
+		}
+	}
+
+	intoKey := g.nodes[intoID].Key
+	mergedKeys := make([]string, 0, len(merged))
+	for _, k := range from {
+		mergedKeys = append(mergedKeys, g.canonicalKey(k))
+	}
+	for alias, canonical := range g.aliases {
+		for _, k := range mergedKeys {
+			if canonical == k {
+				g.aliases[alias] = intoKey
+			}
+		}
+	}
+	for _, k := range mergedKeys {
+		delete(g.phases, k)
+		delete(g.blocks, k)
+		delete(g.groups, k)
+		delete(g.namespaces, k)
+		delete(g.layers, k)
+		for dest, sources := range g.pendingOptional {
+			for i, source := range sources {
+				if source == k {
+					sources[i] = intoKey
+					g.pendingOptional[dest] = sortedUnique(sources)
+					break
+				}
+			}
+		}
+	}
+
+	if mergeData != nil {
+		g.nodes[intoID].Data = mergeData(data...)
+	}
+	g.nodes = nodes
+	g.adjacency = adjacency
+	g.edgeLabels = edgeLabels
+	g.edgeCount = edgeCount
+	g.topoSortedOrder = make([]*GraphNode[T], 0)
+	ids := make(map[string]int32, len(g.ids))
+	for id, node := range g.nodes {
+		ids[g.normalizeKey(node.Key)] = int32(id)
+	}
+	for alias, canonical := range g.aliases {
+		ids[alias] = ids[g.normalizeKey(canonical)]
+	}
+	g.ids = ids
+
+	for _, k := range mergedKeys {
+		g.record(Mutation{Kind: MutationVertexMerged, Key: intoKey, Source: k})
+	}
+	return nil
+}
+
+// containsRewiredEdge reports whether the edges being rewired by [Graph.MergeVertices] already include one to dest,
+// with the same label if the graph allows parallel edges
+func (g *Graph[T]) containsRewiredEdge(dests []int32, labels []string, dest int32, label string) bool {
+	for i, d := range dests {
+		if d != dest {
+			continue
+		}
+		if !g.config.parallelEdges {
+			return true
+		}
+		existing := ""
+		if labels != nil {
+			existing = labels[i]
+		}
+		if existing == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// bundleGraph returns a graph where app depends on three libraries which ship as one bundle (libssl, libcrypto and openssl-conf),
+// and the libraries depend on each other and on libc
+func bundleGraph(t *testing.T, opts ...Option) *Graph[string] {
+	t.Helper()
+	graph := NewGraphWithOptions[string](opts...)
+	for _, k := range []string{"libc", "libcrypto", "libssl", "openssl-conf", "app"} {
+		if err := graph.RegisterVertex(k, k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	edges := []Edge{
+		{Source: "libcrypto", Dest: "libc"},
+		{Source: "libssl", Dest: "libcrypto"},
+		{Source: "libssl", Dest: "libc"},
+		{Source: "libssl", Dest: "openssl-conf"},
+		{Source: "app", Dest: "libssl"},
+		{Source: "app", Dest: "libcrypto"},
+	}
+	if err := graph.AddEdges(edges...); err != nil {
+		t.Fatal(err)
+	}
+	return graph
+}
+
+func TestMergeVertices(t *testing.T) {
+	graph := bundleGraph(t, WithHistory(0))
+	if err := graph.AddAlias("ssl", "libssl"); err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.SetVertexPhase("libcrypto", "install"); err != nil {
+		t.Fatal(err)
+	}
+
+	join := func(data ...string) string { return strings.Join(data, "+") }
+	if err := graph.MergeVertices("openssl-conf", []string{"libssl", "libcrypto"}, join); err != nil {
+		t.Fatalf("MergeVertices returned unexpected error: %v", err)
+	}
+
+	wantEdges := []Edge{{Source: "app", Dest: "openssl-conf"}, {Source: "openssl-conf", Dest: "libc"}}
+	if got := graph.Edges(); !reflect.DeepEqual(got, wantEdges) {
+		t.Errorf("Edges() = %v, want %v", got, wantEdges)
+	}
+	got, err := graph.TopologicalSort()
+	if err != nil {
+		t.Fatalf("TopologicalSort returned unexpected error: %v", err)
+	}
+	want := []string{"libc", "openssl-conf", "app"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TopologicalSort() = %v, want %v", got, want)
+	}
+
+	if node, ok := graph.Vertex("openssl-conf"); !ok || node.Data != "openssl-conf+libssl+libcrypto" {
+		t.Errorf("Vertex(%q) = %v, want the merged Data", "openssl-conf", node)
+	}
+	if graph.HasVertex("libssl") || graph.HasVertex("libcrypto") {
+		t.Errorf("merged vertices are still registered")
+	}
+	if node, ok := graph.Vertex("ssl"); !ok || node.Key != "openssl-conf" {
+		t.Errorf("Vertex(%q) = %v, %v, want the alias to name the merged vertex", "ssl", node, ok)
+	}
+	if _, ok := graph.VertexPhase("libcrypto"); ok {
+		t.Errorf("VertexPhase(%q) kept the phase of a merged vertex", "libcrypto")
+	}
+	// the merged keys can be registered again
+	if err := graph.RegisterVertex("libssl", "new"); err != nil {
+		t.Errorf("RegisterVertex(%q) after merging returned unexpected error: %v", "libssl", err)
+	}
+
+	history := graph.History()
+	wantMerges := []Mutation{
+		{Kind: MutationVertexMerged, Key: "openssl-conf", Source: "libssl"},
+		{Kind: MutationVertexMerged, Key: "openssl-conf", Source: "libcrypto"},
+	}
+	gotMerges := make([]Mutation, 0)
+	for _, m := range history {
+		if m.Kind == MutationVertexMerged {
+			m.Time = wantMerges[0].Time
+			gotMerges = append(gotMerges, m)
+		}
+	}
+	if !reflect.DeepEqual(gotMerges, wantMerges) {
+		t.Errorf("History() merges = %v, want %v", gotMerges, wantMerges)
+	}
+}
+
+func TestMergeVerticesKeepsData(t *testing.T) {
+	graph := bundleGraph(t)
+	if err := graph.MergeVertices("libssl", []string{"libcrypto"}, nil); err != nil {
+		t.Fatalf("MergeVertices returned unexpected error: %v", err)
+	}
+	if node, _ := graph.Vertex("libssl"); node.Data != "libssl" {
+		t.Errorf("Vertex(%q).Data = %q, want it unchanged without mergeData", "libssl", node.Data)
+	}
+	wantEdges := []Edge{
+		{Source: "app", Dest: "libssl"},
+		{Source: "libssl", Dest: "libc"},
+		{Source: "libssl", Dest: "openssl-conf"},
+	}
+	if got := graph.Edges(); !reflect.DeepEqual(got, wantEdges) {
+		t.Errorf("Edges() = %v, want %v", got, wantEdges)
+	}
+}
+
+func TestMergeVerticesCreatesCycle(t *testing.T) {
+	graph := bundleGraph(t)
+	// libssl depends on libcrypto, which depends on libc: merging libssl and libc leaves libcrypto in between
+	if err := graph.MergeVertices("libssl", []string{"libc"}, nil); err != nil {
+		t.Fatalf("MergeVertices returned unexpected error: %v", err)
+	}
+	if _, err := graph.TopologicalSort(); err == nil {
+		t.Errorf("TopologicalSort should have found the cycle created by the merge")
+	}
+}
+
+func TestMergeVerticesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		into string
+		from []string
+	}{
+		{name: "Unregistered target", into: "missing", from: []string{"libc"}},
+		{name: "Unregistered vertex to merge", into: "libc", from: []string{"missing"}},
+		{name: "Vertex merged into itself", into: "libc", from: []string{"libc"}},
+		{name: "Vertex listed twice", into: "libc", from: []string{"libssl", "libssl"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := bundleGraph(t)
+			before := graph.Edges()
+			if err := graph.MergeVertices(tt.into, tt.from, nil); err == nil {
+				t.Errorf("MergeVertices(%q, %v) should have failed", tt.into, tt.from)
+			}
+			if !reflect.DeepEqual(graph.Edges(), before) {
+				t.Errorf("a failed MergeVertices changed the graph")
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package topologicalsort
+
+import (
+	"errors"
+	"expvar"
+	"time"
+)
+
+// Metrics receives operational metrics from a graph, see [WithMetrics].
+// Implementations are called synchronously and must be safe for concurrent use if the graph is; adapting them to Prometheus counters and histograms is straightforward.
+type Metrics interface {
+	// VertexAdded is called for every vertex registered in the graph
+	VertexAdded()
+	// EdgeAdded is called for every edge added to the graph
+	EdgeAdded()
+	// Sorted is called after every sort (the same entry points [WithTracer] traces, plus [Sorter.Sort]), with how long it took and its error (nil if it succeeded)
+	Sorted(duration time.Duration, err error)
+	// CycleDetected is called whenever a sort fails because of a cycle
+	CycleDetected()
+}
+
+// WithMetrics makes the graph report counters and timings to m
+func WithMetrics(m Metrics) Option {
+	return func(c *config) {
+		c.metrics = m
+	}
+}
+
+// ExpvarMetrics is a [Metrics] implementation which publishes its counters through expvar (and therefore /debug/vars)
+type ExpvarMetrics struct {
+	vars *expvar.Map
+}
+
+// NewExpvarMetrics publishes a map of counters under name: vertices, edges, sorts, sort_errors, cycles, and sort_nanoseconds (the total time spent sorting).
+// Like [expvar.Publish], it panics if name is already in use.
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	return &ExpvarMetrics{vars: expvar.NewMap(name)}
+}
+
+// VertexAdded implements [Metrics]
+func (m *ExpvarMetrics) VertexAdded() {
+	m.vars.Add("vertices", 1)
+}
+
+// EdgeAdded implements [Metrics]
+func (m *ExpvarMetrics) EdgeAdded() {
+	m.vars.Add("edges", 1)
+}
+
+// Sorted implements [Metrics]
+func (m *ExpvarMetrics) Sorted(duration time.Duration, err error) {
+	m.vars.Add("sorts", 1)
+	m.vars.Add("sort_nanoseconds", int64(duration))
+	if err != nil {
+		m.vars.Add("sort_errors", 1)
+	}
+}
+
+// CycleDetected implements [Metrics]
+func (m *ExpvarMetrics) CycleDetected() {
+	m.vars.Add("cycles", 1)
+}
+
+// String returns the counters as JSON, like [expvar.Map.String]
+func (m *ExpvarMetrics) String() string {
+	return m.vars.String()
+}
+
+// observeSort reports a finished sort to the graph's metrics and logger, if it has any
+func (g *Graph[T]) observeSort(start time.Time, err error) {
+	duration := time.Since(start)
+	g.logSort(duration, err)
+	if g.config.metrics == nil {
+		return
+	}
+	g.config.metrics.Sorted(duration, err)
+	if errors.Is(err, ErrCycle) {
+		g.config.metrics.CycleDetected()
+	}
+}
@@ -0,0 +1,74 @@
+package topologicalsort
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// expvar names can only be published once per process, which -count would otherwise trip over
+var expvarTestRuns atomic.Int32
+
+func TestWithMetrics(t *testing.T) {
+	metrics := NewExpvarMetrics(fmt.Sprintf("topologicalsort_test_%d", expvarTestRuns.Add(1)))
+	g := NewGraph("", WithMetrics(metrics))
+	g.RegisterVertex("one", "")
+	g.RegisterVertex("two", "")
+	g.RegisterVertex("two", "")
+	g.AddEdge("two", "one")
+	g.TopologicalSort()
+	g.AddEdge("one", "two")
+	g.TopologicalSort()
+
+	var got map[string]int64
+	if err := json.Unmarshal([]byte(metrics.String()), &got); err != nil {
+		t.Fatalf("ExpvarMetrics.String() isn't valid JSON: %v", err)
+	}
+	want := map[string]int64{"vertices": 2, "edges": 2, "sorts": 2, "sort_errors": 1, "cycles": 1}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("counter %s = %d, want %d", k, got[k], v)
+		}
+	}
+	if got["sort_nanoseconds"] <= 0 {
+		t.Errorf("counter sort_nanoseconds = %d, want a positive duration", got["sort_nanoseconds"])
+	}
+}
+
+// countingMetrics counts sorts and cycles
+type countingMetrics struct {
+	sorts, cycles int
+}
+
+func (m *countingMetrics) VertexAdded()                {}
+func (m *countingMetrics) EdgeAdded()                  {}
+func (m *countingMetrics) Sorted(time.Duration, error) { m.sorts++ }
+func (m *countingMetrics) CycleDetected()              { m.cycles++ }
+
+func TestWithMetrics_SortEntryPoints(t *testing.T) {
+	metrics := &countingMetrics{}
+	g := NewGraph("", WithMetrics(metrics))
+	g.RegisterVertex("libc", "")
+	g.RegisterVertex("gcc", "")
+	g.AddEdge("gcc", "libc")
+
+	g.TopologicalSortFor("gcc")
+	g.TopologicalSortBestEffort()
+	g.TopologicalSortWithPreferences()
+	g.TopologicalSortByBlock()
+	g.Levels()
+	g.LevelsWithMaxWidth(1)
+	NewSorter[string]().Sort(g)
+	if metrics.sorts != 7 || metrics.cycles != 0 {
+		t.Errorf("recorded %d sorts and %d cycles, want 7 sorts and no cycles", metrics.sorts, metrics.cycles)
+	}
+
+	g.AddEdge("libc", "gcc")
+	g.TopologicalSortFor("gcc")
+	g.Levels()
+	if metrics.sorts != 9 || metrics.cycles != 2 {
+		t.Errorf("recorded %d sorts and %d cycles, want 9 sorts and 2 cycles", metrics.sorts, metrics.cycles)
+	}
+}
@@ -0,0 +1,203 @@
+// Package migrations orders database migration files by the "requires" headers they declare.
+//
+// A header is a comment line at the top of a file, before any statement, like
+//
+//	-- requires: 0001_create_users, 0002_create_orders
+//
+// Comments may start with "--", "#" or "//". A migration's name is its file name without the extension,
+// and requirements may be written with or without the extension.
+package migrations
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// Migration is the Data of every vertex
+type Migration struct {
+	Name     string
+	File     string
+	Requires []Requirement
+	Body     string
+}
+
+// Requirement is a migration named in a "requires" header, along with the line declaring it
+type Requirement struct {
+	Name string
+	Line int
+}
+
+// CycleError is returned when migrations require each other in a cycle.
+// It wraps [topologicalsort.ErrCycle] and lists requirements which have to be removed to break every cycle.
+type CycleError struct {
+	Requirements []CycleRequirement
+}
+
+// CycleRequirement is a requirement which is part of a cycle
+type CycleRequirement struct {
+	File     string
+	Line     int
+	Requires string
+}
+
+func (e *CycleError) Error() string {
+	parts := make([]string, len(e.Requirements))
+	for i, r := range e.Requirements {
+		parts[i] = fmt.Sprintf("%s:%d requires %s", r.File, r.Line, r.Requires)
+	}
+	return fmt.Sprintf("migrations require each other in a cycle: %s", strings.Join(parts, "; "))
+}
+
+func (e *CycleError) Unwrap() error {
+	return topologicalsort.ErrCycle
+}
+
+// Parse reads a migration from r, using file for its name and in error messages
+func Parse(file string, r io.Reader) (Migration, error) {
+	migration := Migration{Name: migrationName(file), File: file, Requires: []Requirement{}}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	inHeader := true
+	body := make([]string, 0)
+
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		body = append(body, line)
+		if !inHeader {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		comment, isComment := trimComment(trimmed)
+		if trimmed == "" {
+			continue
+		}
+		if !isComment {
+			inHeader = false
+			continue
+		}
+		key, value, ok := strings.Cut(comment, ":")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "requires") {
+			continue
+		}
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			migration.Requires = append(migration.Requires, Requirement{Name: migrationName(name), Line: lineNumber})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Migration{}, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	migration.Body = strings.Join(body, "\n")
+	return migration, nil
+}
+
+// Load parses every file in fsys matching pattern (see [fs.Glob]) and builds their graph with [NewGraph]
+func Load(fsys fs.FS, pattern string) (*topologicalsort.Graph[Migration], error) {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	migrations := make([]Migration, 0, len(files))
+	for _, file := range files {
+		f, err := fsys.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		migration, err := Parse(file, f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, migration)
+	}
+	return NewGraph(migrations)
+}
+
+// NewGraph returns a graph with one vertex per migration, depending on the migrations it requires.
+// It returns an error pointing at the header line if a migration requires one which doesn't exist.
+func NewGraph(migrations []Migration) (*topologicalsort.Graph[Migration], error) {
+	graph := topologicalsort.NewGraphWithOptions[Migration](topologicalsort.WithDuplicateEdges(topologicalsort.IgnoreDuplicates))
+	for _, m := range migrations {
+		if err := graph.RegisterVertex(m.Name, m); err != nil {
+			return nil, fmt.Errorf("%s: %w", m.File, err)
+		}
+	}
+	for _, m := range migrations {
+		for _, r := range m.Requires {
+			if !graph.HasVertex(r.Name) {
+				return nil, fmt.Errorf("%s:%d: requires unknown migration %s", m.File, r.Line, r.Name)
+			}
+			if err := graph.AddEdge(m.Name, r.Name); err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", m.File, r.Line, err)
+			}
+		}
+	}
+	return graph, nil
+}
+
+// ApplyOrder returns the names of the migrations in the order they should be applied: every migration after the ones it requires,
+// and otherwise by name, so the order is stable across runs. If the migrations require each other in a cycle, it returns a *[CycleError].
+func ApplyOrder(graph *topologicalsort.Graph[Migration]) ([]string, error) {
+	removals, err := graph.SuggestEdgeRemovals()
+	if err != nil {
+		return []string{}, err
+	}
+	if len(removals) > 0 {
+		cycleErr := &CycleError{Requirements: make([]CycleRequirement, 0, len(removals))}
+		for _, e := range removals {
+			m, _ := graph.Vertex(e.Source)
+			cycleErr.Requirements = append(cycleErr.Requirements, CycleRequirement{
+				File:     m.Data.File,
+				Line:     requirementLine(m.Data, e.Dest),
+				Requires: e.Dest,
+			})
+		}
+		sort.Slice(cycleErr.Requirements, func(i, j int) bool {
+			a, b := cycleErr.Requirements[i], cycleErr.Requirements[j]
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			return a.Line < b.Line
+		})
+		return []string{}, cycleErr
+	}
+
+	// without cycles, the best-effort sort ignores no edges, and breaks ties by name
+	order, _, err := graph.TopologicalSortBestEffort()
+	return order, err
+}
+
+func requirementLine(m Migration, name string) int {
+	for _, r := range m.Requires {
+		if r.Name == name {
+			return r.Line
+		}
+	}
+	return 0
+}
+
+func trimComment(line string) (string, bool) {
+	for _, prefix := range []string{"--", "#", "//"} {
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimPrefix(line, prefix), true
+		}
+	}
+	return line, false
+}
+
+func migrationName(file string) string {
+	base := path.Base(file)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
@@ -0,0 +1,83 @@
+package migrations
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+func TestParse(t *testing.T) {
+	const input = `-- add the orders table
+-- requires: 0001_users, 0002_products.sql
+
+--   Requires: 0000_schema
+CREATE TABLE orders (id int);
+-- requires: ignored
+`
+	got, err := Parse("db/0003_orders.sql", strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() unexpected error %v", err)
+	}
+	want := []Requirement{{Name: "0001_users", Line: 2}, {Name: "0002_products", Line: 2}, {Name: "0000_schema", Line: 4}}
+	if got.Name != "0003_orders" || !reflect.DeepEqual(got.Requires, want) {
+		t.Errorf("Parse() = %s %v, want 0003_orders %v", got.Name, got.Requires, want)
+	}
+	if !strings.Contains(got.Body, "CREATE TABLE orders") {
+		t.Errorf("Parse() body = %q", got.Body)
+	}
+}
+
+func TestApplyOrder(t *testing.T) {
+	fsys := fstest.MapFS{
+		"db/b_orders.sql":   {Data: []byte("# requires: c_users, a_products\nCREATE TABLE orders;")},
+		"db/a_products.sql": {Data: []byte("CREATE TABLE products;")},
+		"db/c_users.sql":    {Data: []byte("CREATE TABLE users;")},
+		"db/README.md":      {Data: []byte("not a migration")},
+	}
+	graph, err := Load(fsys, "db/*.sql")
+	if err != nil {
+		t.Fatalf("Load() unexpected error %v", err)
+	}
+	got, err := ApplyOrder(graph)
+	if err != nil {
+		t.Fatalf("ApplyOrder() unexpected error %v", err)
+	}
+	if want := []string{"a_products", "c_users", "b_orders"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ApplyOrder() = %v, want %v", got, want)
+	}
+}
+
+func TestApplyOrder_Cycle(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.sql": {Data: []byte("-- requires: b\n")},
+		"b.sql": {Data: []byte("-- first\n-- requires: a\n")},
+	}
+	graph, err := Load(fsys, "*.sql")
+	if err != nil {
+		t.Fatalf("Load() unexpected error %v", err)
+	}
+	_, err = ApplyOrder(graph)
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) || !errors.Is(err, topologicalsort.ErrCycle) {
+		t.Fatalf("ApplyOrder() error = %v, want a CycleError", err)
+	}
+	if len(cycleErr.Requirements) != 1 {
+		t.Fatalf("ApplyOrder() cycle = %v, want one requirement", cycleErr.Requirements)
+	}
+	r := cycleErr.Requirements[0]
+	if !(r.File == "a.sql" && r.Line == 1 && r.Requires == "b") && !(r.File == "b.sql" && r.Line == 2 && r.Requires == "a") {
+		t.Errorf("ApplyOrder() cycle = %+v, want a requirement on the cycle", r)
+	}
+}
+
+func TestNewGraph_UnknownRequirement(t *testing.T) {
+	migrations := []Migration{{Name: "a", File: "a.sql", Requires: []Requirement{{Name: "missing", Line: 3}}}}
+	_, err := NewGraph(migrations)
+	if err == nil || !strings.Contains(err.Error(), "a.sql:3") {
+		t.Errorf("NewGraph() error = %v, want one pointing at a.sql:3", err)
+	}
+}
@@ -0,0 +1,85 @@
+package topologicalsort
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrMissingVertices is wrapped by [MissingVerticesError]
+var ErrMissingVertices = errors.New("missing vertices")
+
+// MissingVertexPolicy decides what the bulk constructors ([NewGraphFromData] and [GraphBuilder.Build]) do with edges to keys which are never registered,
+// see [WithMissingVertices]
+type MissingVertexPolicy int
+
+const (
+	// ReportEachMissing reports an error for every edge to a missing vertex, like AddEdge would. This is the default.
+	ReportEachMissing MissingVertexPolicy = iota
+	// ReportMissingTogether reports a single *[MissingVerticesError] listing every missing key, instead of one error per edge
+	ReportMissingTogether
+	// CreateMissing registers every missing key as a vertex with the zero value of T as its Data, so the edges can be added
+	CreateMissing
+)
+
+// MissingVerticesError lists the keys which edges refer to, but which are never registered as vertices, see [ReportMissingTogether]
+type MissingVerticesError struct {
+	// Keys are sorted, and every key is listed once
+	Keys []string
+}
+
+func (e *MissingVerticesError) Error() string {
+	return fmt.Sprintf("%s: edges refer to unregistered vertices %s", ErrMissingVertices, strings.Join(e.Keys, ", "))
+}
+
+func (e *MissingVerticesError) Unwrap() error {
+	return ErrMissingVertices
+}
+
+// WithMissingVertices sets what [NewGraphFromData] and [GraphBuilder.Build] do with edges to keys which are never registered
+// (by default, every such edge is an error). It doesn't change AddEdge.
+func WithMissingVertices(policy MissingVertexPolicy) Option {
+	return func(c *config) {
+		c.missingVertices = policy
+	}
+}
+
+// addMissing deals with the endpoints of edges which aren't registered, according to the graph's [MissingVertexPolicy].
+// The bulk constructors call it once all vertices are registered, before adding edges; it returns the error to report, if any.
+func (g *Graph[T]) addMissing(edges []Edge) error {
+	if g.config.missingVertices == ReportEachMissing {
+		return nil
+	}
+	// every missing key is listed once, in the order edges first refer to it
+	missing := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, e := range edges {
+		for _, k := range []string{e.Source, e.Dest} {
+			if !g.HasVertex(k) && !seen[g.normalizeKey(k)] {
+				seen[g.normalizeKey(k)] = true
+				missing = append(missing, k)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if g.config.missingVertices == CreateMissing {
+		var zero T
+		for _, k := range missing {
+			if err := g.RegisterVertex(k, zero); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	sort.Strings(missing)
+	return &MissingVerticesError{Keys: missing}
+}
+
+// reportedMissing reports whether e is left out by the bulk constructors because [Graph.addMissing] already reported its missing endpoint
+func (g *Graph[T]) reportedMissing(e Edge) bool {
+	return g.config.missingVertices == ReportMissingTogether && (!g.HasVertex(e.Source) || !g.HasVertex(e.Dest))
+}
@@ -0,0 +1,80 @@
+package topologicalsort
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWithMissingVerticesFromData(t *testing.T) {
+	data := map[*GraphNode[string]][]string{
+		NewGraphNode("app", "app"):   {"libc", "openssl", "zlib"},
+		NewGraphNode("curl", "curl"): {"openssl", "app"},
+	}
+
+	t.Run("Report each missing", func(t *testing.T) {
+		_, err := NewGraphFromData(data)
+		if got := len(err.(interface{ Unwrap() []error }).Unwrap()); got != 4 {
+			t.Errorf("NewGraphFromData reported %d errors, want one per edge to a missing vertex (4): %v", got, err)
+		}
+	})
+
+	t.Run("Report missing together", func(t *testing.T) {
+		_, err := NewGraphFromData(data, WithMissingVertices(ReportMissingTogether))
+		var missingErr *MissingVerticesError
+		if !errors.As(err, &missingErr) || !errors.Is(err, ErrMissingVertices) {
+			t.Fatalf("NewGraphFromData returned %v, want a *MissingVerticesError", err)
+		}
+		want := []string{"libc", "openssl", "zlib"}
+		if !reflect.DeepEqual(missingErr.Keys, want) {
+			t.Errorf("MissingVerticesError.Keys = %v, want %v", missingErr.Keys, want)
+		}
+		if got := len(err.(interface{ Unwrap() []error }).Unwrap()); got != 1 {
+			t.Errorf("NewGraphFromData reported %d errors, want only the missing vertices: %v", got, err)
+		}
+	})
+
+	t.Run("Create missing", func(t *testing.T) {
+		graph, err := NewGraphFromData(data, WithMissingVertices(CreateMissing))
+		if err != nil {
+			t.Fatalf("NewGraphFromData returned unexpected error: %v", err)
+		}
+		for _, k := range []string{"libc", "openssl", "zlib"} {
+			node, ok := graph.Vertex(k)
+			if !ok || node.Data != "" {
+				t.Errorf("Vertex(%q) = %v, %v, want a created vertex with empty Data", k, node, ok)
+			}
+		}
+		if got := len(graph.Edges()); got != 5 {
+			t.Errorf("graph has %d edges, want 5", got)
+		}
+	})
+}
+
+func TestWithMissingVerticesBuilder(t *testing.T) {
+	build := func(opts ...Option) (*Graph[int], error) {
+		return NewGraphBuilder[int](opts...).
+			AddVertex("a", 1).
+			AddEdge("a", "b").
+			AddEdge("c", "a").
+			AddEdge("c", "b").
+			Build()
+	}
+
+	if _, err := build(WithMissingVertices(ReportMissingTogether)); err == nil || err.Error() != "missing vertices: edges refer to unregistered vertices b, c" {
+		t.Errorf("Build() returned %v, want a single error listing b and c", err)
+	}
+
+	graph, err := build(WithMissingVertices(CreateMissing))
+	if err != nil {
+		t.Fatalf("Build() returned unexpected error: %v", err)
+	}
+	sorted, err := graph.TopologicalSort()
+	if err != nil {
+		t.Fatalf("TopologicalSort returned unexpected error: %v", err)
+	}
+	want := []string{"b", "a", "c"}
+	if !reflect.DeepEqual(sorted, want) {
+		t.Errorf("TopologicalSort() = %v, want %v", sorted, want)
+	}
+}
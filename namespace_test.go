@@ -0,0 +1,65 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph_RegisterVertexNS(t *testing.T) {
+	graph := NewGraphWithOptions[string]()
+	for _, v := range []struct{ ns, key string }{{"payments", "db"}, {"payments", "api"}, {"search", "db"}, {"search", "github.com/x/y"}} {
+		if err := graph.RegisterVertexNS(v.ns, v.key, ""); err != nil {
+			t.Fatalf("Graph.RegisterVertexNS() unexpected error %v", err)
+		}
+	}
+	graph.RegisterVertex("shared", "")
+	graph.AddEdge(NamespacedKey("payments", "api"), NamespacedKey("payments", "db"))
+	graph.AddEdge(NamespacedKey("payments", "api"), "shared")
+
+	if err := graph.RegisterVertexNS("payments", "db", ""); err == nil {
+		t.Errorf("Graph.RegisterVertexNS() expected an error for a duplicate vertex")
+	}
+	for _, ns := range []string{"", "a/b"} {
+		if err := graph.RegisterVertexNS(ns, "x", ""); err == nil {
+			t.Errorf("Graph.RegisterVertexNS() expected an error for namespace %q", ns)
+		}
+	}
+
+	if ns, local, ok := graph.VertexNamespace("search/github.com/x/y"); !ok || ns != "search" || local != "github.com/x/y" {
+		t.Errorf("Graph.VertexNamespace() = %s, %s, %v, want search, github.com/x/y, true", ns, local, ok)
+	}
+	if _, _, ok := graph.VertexNamespace("shared"); ok {
+		t.Errorf("Graph.VertexNamespace() found a namespace for a plain vertex")
+	}
+	if got := graph.Namespaces(); !reflect.DeepEqual(got, []string{"payments", "search"}) {
+		t.Errorf("Graph.Namespaces() = %v, want [payments search]", got)
+	}
+
+	view := graph.NamespaceView("payments")
+	if got, want := view.Keys(), []string{"payments/api", "payments/db"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NamespaceView().Keys() = %v, want %v", got, want)
+	}
+	if got, err := view.TopologicalSort(); err != nil || !reflect.DeepEqual(got, []string{"payments/db", "payments/api"}) {
+		t.Errorf("NamespaceView().TopologicalSort() = %v, %v, want [payments/db payments/api]", got, err)
+	}
+}
+
+func TestGraph_AddGraphNS(t *testing.T) {
+	team := graphWithVerticesDUMMYDATA(map[string][]string{"api": {"db"}, "db": {}}, "")
+	graph := NewGraphWithOptions[string]()
+	for _, ns := range []string{"payments", "search"} {
+		if err := graph.AddGraphNS(ns, team); err != nil {
+			t.Fatalf("Graph.AddGraphNS() unexpected error %v", err)
+		}
+	}
+	want := []Edge{{Source: "payments/api", Dest: "payments/db"}, {Source: "search/api", Dest: "search/db"}}
+	if got := graph.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.AddGraphNS() edges = %v, want %v", got, want)
+	}
+	if err := graph.AddGraphNS("search", team); err == nil {
+		t.Errorf("Graph.AddGraphNS() expected an error for a namespace which is already used")
+	}
+	if got := len(graph.AdjacencyMap()); got != 4 {
+		t.Errorf("Graph.AddGraphNS() left %d vertices after a failed join, want 4", got)
+	}
+}
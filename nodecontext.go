@@ -0,0 +1,95 @@
+package topologicalsort
+
+import (
+	"context"
+	"sync"
+)
+
+// nodeScopeKey is the context key of the [nodeScope] a vertex's function runs in
+type nodeScopeKey struct{}
+
+// nodeScope is what an attempt of a vertex's function can see of its [Run], through its context
+type nodeScope struct {
+	key      string
+	input    any
+	hasInput bool
+	output   any
+	// dependencyOutput returns the output of one of the vertex's dependencies
+	dependencyOutput func(dep string) (any, bool)
+}
+
+// nodeFunc wraps the run's function for node, injecting the run's faults and running every attempt in a scope of its own.
+// The output of the attempt which succeeds ends up in *output.
+func (r *Run[T]) nodeFunc(node *GraphNode[T], output *any) NodeFunc[T] {
+	attempt := 0
+	// the vertex's dependencies are collected once, the first time one of its attempts asks for an output
+	dependencies := sync.OnceValue(func() []bool { return r.graph.dependencyClosure(node.Key) })
+	return func(ctx context.Context, node *GraphNode[T]) error {
+		attempt++
+		if r.faults != nil {
+			var err error
+			if ctx, err = r.faults.inject(ctx, node.Key, attempt); err != nil {
+				return err
+			}
+		}
+		scope := &nodeScope{key: node.Key}
+		scope.input, scope.hasInput = r.inputs[node.Key]
+		scope.dependencyOutput = func(dep string) (any, bool) {
+			return r.dependencyOutput(dependencies(), dep)
+		}
+		err := r.fn(context.WithValue(ctx, nodeScopeKey{}, scope), node)
+		if err == nil {
+			*output = scope.output
+		}
+		return err
+	}
+}
+
+// dependencyOutput returns the output of dep, if it's in dependencies (indexed by vertex ID) and it succeeded
+func (r *Run[T]) dependencyOutput(dependencies []bool, dep string) (any, bool) {
+	id, ok := r.graph.id(dep)
+	if !ok || !dependencies[id] {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := r.results[r.graph.nodes[id].Key]
+	return result.Output, result.Status == NodeSucceeded
+}
+
+// NodeInput returns the input the current run was given for the vertex ctx belongs to (see [ExecuteConfig.Inputs]), if ctx is the context of a vertex's function during [Graph.Execute].
+// It returns false if there's no input for the vertex, or it isn't a V.
+func NodeInput[V any](ctx context.Context) (V, bool) {
+	scope, _ := ctx.Value(nodeScopeKey{}).(*nodeScope)
+	if scope == nil || !scope.hasInput {
+		var zero V
+		return zero, false
+	}
+	input, ok := scope.input.(V)
+	return input, ok
+}
+
+// SetOutput sets the output of the vertex ctx belongs to, if ctx is the context of a vertex's function during [Graph.Execute]; it does nothing otherwise.
+// Once the function has returned successfully, the output is available to the vertex's dependents through [Output], and ends up in its [NodeResult].
+// Outputs set by failed attempts are dropped. SetOutput has to be called by the function itself, not by goroutines it leaves running.
+func SetOutput(ctx context.Context, output any) {
+	if scope, _ := ctx.Value(nodeScopeKey{}).(*nodeScope); scope != nil {
+		scope.output = output
+	}
+}
+
+// Output returns the output of the vertex registered under key, if ctx is the context of a vertex's function during [Graph.Execute] and that vertex depends on key, directly or transitively.
+// Only dependencies are guaranteed to have finished, so it returns false for any other vertex, as well as for a dependency which didn't succeed, set no output or set one which isn't a U.
+func Output[U any](ctx context.Context, key string) (U, bool) {
+	var zero U
+	scope, _ := ctx.Value(nodeScopeKey{}).(*nodeScope)
+	if scope == nil {
+		return zero, false
+	}
+	output, ok := scope.dependencyOutput(key)
+	if !ok {
+		return zero, false
+	}
+	typed, ok := output.(U)
+	return typed, ok
+}
@@ -0,0 +1,112 @@
+package topologicalsort
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestNodeInput(t *testing.T) {
+	g := progressTestGraph()
+	g.AddAlias("glibc", "libc")
+
+	// two runs over the same graph at the same time, with different inputs
+	inputs := []map[string]any{
+		{"gcc": 13, "glibc": 2, "make": "not an int"},
+		{"gcc": 14},
+	}
+	var mu sync.Mutex
+	seen := make([]map[string]int, len(inputs))
+	runs := make([]*Run[string], len(inputs))
+	for i := range inputs {
+		seen[i] = make(map[string]int)
+		run, err := g.Start(context.Background(), func(ctx context.Context, node *GraphNode[string]) error {
+			if version, ok := NodeInput[int](ctx); ok {
+				mu.Lock()
+				seen[i][node.Key] = version
+				mu.Unlock()
+			}
+			return nil
+		}, ExecuteConfig[string]{Inputs: inputs[i]})
+		if err != nil {
+			t.Fatalf("Graph.Start() unexpected error %v", err)
+		}
+		runs[i] = run
+	}
+	for _, run := range runs {
+		if _, err := run.Wait(); err != nil {
+			t.Fatalf("Run.Wait() unexpected error %v", err)
+		}
+	}
+
+	want := []map[string]int{{"gcc": 13, "libc": 2}, {"gcc": 14}}
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("NodeInput() saw %v, want %v", seen, want)
+	}
+	if _, ok := NodeInput[int](context.Background()); ok {
+		t.Errorf("NodeInput() outside of a run returned an input")
+	}
+
+	_, err := g.Start(context.Background(), func(context.Context, *GraphNode[string]) error { return nil }, ExecuteConfig[string]{Inputs: map[string]any{"clang": 18}})
+	if err == nil {
+		t.Errorf("Graph.Start() with an input for an unregistered vertex returned no error")
+	}
+}
+
+func TestOutput(t *testing.T) {
+	g := progressTestGraph()
+	g.AddAlias("glibc", "libc")
+	g.RegisterVertex("docs", "")
+
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+	got := make(map[string]string)
+	results, err := g.Execute(context.Background(), func(ctx context.Context, node *GraphNode[string]) error {
+		mu.Lock()
+		attempts[node.Key]++
+		attempt := attempts[node.Key]
+		mu.Unlock()
+
+		switch node.Key {
+		case "libc":
+			SetOutput(ctx, "libc.so")
+		case "make":
+			// the output of the failed first attempt is dropped
+			if attempt == 1 {
+				SetOutput(ctx, "broken make")
+				return context.DeadlineExceeded
+			}
+		case "gcc":
+			libc, _ := Output[string](ctx, "glibc")
+			SetOutput(ctx, "gcc linked against "+libc)
+		case "build-essential":
+			gcc, _ := Output[string](ctx, "gcc")
+			libc, _ := Output[string](ctx, "libc")
+			_, fromMake := Output[string](ctx, "make")
+			_, fromDocs := Output[string](ctx, "docs")
+			_, wrongType := Output[int](ctx, "libc")
+			mu.Lock()
+			got["gcc"], got["libc"] = gcc, libc
+			if fromMake || fromDocs || wrongType {
+				t.Errorf("Output() returned an output for make %v, docs %v or as an int %v", fromMake, fromDocs, wrongType)
+			}
+			mu.Unlock()
+		}
+		return nil
+	}, ExecuteConfig[string]{Workers: 1, Policy: func(*GraphNode[string]) NodePolicy { return NodePolicy{Retries: 1} }})
+	if err != nil {
+		t.Fatalf("Graph.Execute() unexpected error %v", err)
+	}
+
+	want := map[string]string{"gcc": "gcc linked against libc.so", "libc": "libc.so"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Output() in build-essential = %v, want %v", got, want)
+	}
+	if results["gcc"].Output != "gcc linked against libc.so" || results["make"].Output != nil {
+		t.Errorf("Graph.Execute() outputs of gcc %v and make %v, want gcc's and none for make", results["gcc"].Output, results["make"].Output)
+	}
+	if _, ok := Output[string](context.Background(), "libc"); ok {
+		t.Errorf("Output() outside of a run returned an output")
+	}
+}
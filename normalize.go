@@ -0,0 +1,33 @@
+package topologicalsort
+
+// WithKeyNormalizer makes the graph look up every key through fn, so keys which normalize to the same string name the same vertex,
+// e.g. WithKeyNormalizer(strings.ToLower) makes "LibC" and "libc" interchangeable. A vertex keeps the key it was registered under,
+// and that's the key the graph reports (in sorted orders, edges, errors about cycles, ...). fn has to be deterministic.
+func WithKeyNormalizer(fn func(string) string) Option {
+	return func(c *config) {
+		c.keyNormalizer = fn
+	}
+}
+
+// normalizeKey returns the form of key the graph's ids are stored under
+func (g *Graph[T]) normalizeKey(key string) string {
+	if g.config.keyNormalizer == nil {
+		return key
+	}
+	return g.config.keyNormalizer(key)
+}
+
+// id returns the ID of the vertex registered under key (or any key which normalizes the same), and whether there is one
+func (g *Graph[T]) id(key string) (int32, bool) {
+	id, ok := g.ids[g.normalizeKey(key)]
+	return id, ok
+}
+
+// canonicalKey returns the key the vertex named by key was registered under, or key itself if there's no such vertex.
+// Maps keyed by vertex key have to go through it, since callers may spell a key differently than it was registered.
+func (g *Graph[T]) canonicalKey(key string) string {
+	if id, ok := g.id(key); ok {
+		return g.nodes[id].Key
+	}
+	return key
+}
@@ -0,0 +1,86 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithKeyNormalizer(t *testing.T) {
+	graph := NewGraphWithOptions[string](WithKeyNormalizer(strings.ToLower))
+	for _, k := range []string{"LibC", "openssl", "curl"} {
+		if err := graph.RegisterVertex(k, k); err != nil {
+			t.Fatalf("RegisterVertex(%q) returned unexpected error: %v", k, err)
+		}
+	}
+
+	if err := graph.RegisterVertex("libc", "libc"); err == nil {
+		t.Errorf("RegisterVertex(%q) should have failed as a duplicate of %q", "libc", "LibC")
+	}
+	if !graph.HasVertex("LIBC") {
+		t.Errorf("HasVertex(%q) = false, want true", "LIBC")
+	}
+	if node, ok := graph.Vertex("libc"); !ok || node.Key != "LibC" {
+		t.Errorf("Vertex(%q) = %v, %v, want the vertex registered as %q", "libc", node, ok, "LibC")
+	}
+
+	for _, e := range []Edge{{Source: "OpenSSL", Dest: "libc"}, {Source: "Curl", Dest: "OPENSSL"}} {
+		if err := graph.AddEdge(e.Source, e.Dest); err != nil {
+			t.Fatalf("AddEdge(%q, %q) returned unexpected error: %v", e.Source, e.Dest, err)
+		}
+	}
+	if err := graph.AddEdge("curl", "Curl"); err == nil {
+		t.Errorf("AddEdge(%q, %q) should have failed as a self loop", "curl", "Curl")
+	}
+
+	wantEdges := []Edge{{Source: "curl", Dest: "openssl"}, {Source: "openssl", Dest: "LibC"}}
+	if got := graph.Edges(); !reflect.DeepEqual(got, wantEdges) {
+		t.Errorf("Edges() = %v, want %v", got, wantEdges)
+	}
+
+	got, err := graph.TopologicalSortFor("CURL")
+	if err != nil {
+		t.Fatalf("TopologicalSortFor returned unexpected error: %v", err)
+	}
+	want := []string{"LibC", "openssl", "curl"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TopologicalSortFor(%q) = %v, want %v", "CURL", got, want)
+	}
+
+	ancestors, err := graph.Ancestors("libc", 0)
+	if err != nil {
+		t.Fatalf("Ancestors returned unexpected error: %v", err)
+	}
+	wantAncestors := [][]string{{"openssl"}, {"curl"}}
+	if !reflect.DeepEqual(ancestors, wantAncestors) {
+		t.Errorf("Ancestors(%q) = %v, want %v", "libc", ancestors, wantAncestors)
+	}
+
+	if err := graph.SetVertexPhase("CURL", "build"); err != nil {
+		t.Fatalf("SetVertexPhase returned unexpected error: %v", err)
+	}
+	if phase, ok := graph.VertexPhase("curl"); !ok || phase != "build" {
+		t.Errorf("VertexPhase(%q) = %q, %v, want %q, true", "curl", phase, ok, "build")
+	}
+}
+
+func TestWithKeyNormalizerOptionalDependency(t *testing.T) {
+	graph := NewGraphWithOptions[string](WithKeyNormalizer(strings.ToLower))
+	if err := graph.RegisterVertex("plugin", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.AddOptionalDependency("plugin", "Core"); err != nil {
+		t.Fatalf("AddOptionalDependency returned unexpected error: %v", err)
+	}
+	if err := graph.RegisterVertex("CORE", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Edge{{Source: "plugin", Dest: "CORE"}}
+	if got := graph.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Edges() = %v, want %v", got, want)
+	}
+	if got := graph.UnmetOptionalDependencies(); len(got) != 0 {
+		t.Errorf("UnmetOptionalDependencies() = %v, want none", got)
+	}
+}
@@ -0,0 +1,168 @@
+// Package npm builds dependency graphs from npm lockfiles.
+package npm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// Package is an installed package from a package-lock.json, used as vertex Data
+type Package struct {
+	// Path is the package's key in the lockfile, like "node_modules/@babel/core" ("" for the root project)
+	Path     string
+	Name     string
+	Version  string
+	Dev      bool
+	Optional bool
+}
+
+// lockfile is the subset of package-lock.json read by NewGraphFromPackageLock
+type lockfile struct {
+	Name            string                  `json:"name"`
+	LockfileVersion int                     `json:"lockfileVersion"`
+	Packages        map[string]lockfileItem `json:"packages"`
+}
+
+type lockfileItem struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Dev                  bool              `json:"dev"`
+	Optional             bool              `json:"optional"`
+	Link                 bool              `json:"link"`
+	Resolved             string            `json:"resolved"`
+	Dependencies         map[string]string `json:"dependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+}
+
+// NewGraphFromPackageLock builds a graph from a package-lock.json (lockfile version 2 or 3), with one vertex per installed package (keyed by its path in the lockfile,
+// so the root project is keyed by "" and its name is only in its Data) depending on the packages it requires. Requirements are resolved the way Node does:
+// the closest node_modules directory containing the package wins. Optional and peer dependencies which aren't installed are left out.
+func NewGraphFromPackageLock(r io.Reader) (*topologicalsort.Graph[Package], error) {
+	var lock lockfile
+	if err := json.NewDecoder(r).Decode(&lock); err != nil {
+		return nil, fmt.Errorf("failed to decode package-lock.json: %w", err)
+	}
+	if lock.LockfileVersion < 2 || lock.Packages == nil {
+		return nil, fmt.Errorf("unsupported package-lock.json version %d: only versions 2 and 3 list packages", lock.LockfileVersion)
+	}
+
+	paths := make([]string, 0, len(lock.Packages))
+	for p := range lock.Packages {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	graph := topologicalsort.NewGraphWithOptions[Package](
+		topologicalsort.WithCapacity(len(paths), 0),
+		topologicalsort.WithDuplicateEdges(topologicalsort.IgnoreDuplicates),
+	)
+	for _, p := range paths {
+		item := lock.Packages[p]
+		name := item.Name
+		if name == "" {
+			name = packageName(p)
+		}
+		if p == "" && name == "" {
+			name = lock.Name
+		}
+		err := graph.RegisterVertex(p, Package{Path: p, Name: name, Version: item.Version, Dev: item.Dev, Optional: item.Optional})
+		if err != nil {
+			return nil, fmt.Errorf("package %q: %w", p, err)
+		}
+	}
+
+	for _, p := range paths {
+		item := lock.Packages[p]
+		if item.Link {
+			if _, ok := lock.Packages[item.Resolved]; ok {
+				if err := graph.AddEdge(p, item.Resolved); err != nil {
+					return nil, fmt.Errorf("package %q: %w", p, err)
+				}
+			}
+			continue
+		}
+
+		required := sortedNames(item.Dependencies)
+		// devDependencies are only installed for the root project and workspaces
+		if !strings.Contains(p, "node_modules/") {
+			required = append(required, sortedNames(item.DevDependencies)...)
+		}
+		optional := append(sortedNames(item.OptionalDependencies), sortedNames(item.PeerDependencies)...)
+
+		for i, name := range append(required, optional...) {
+			dep, ok := resolve(lock.Packages, p, name)
+			if !ok {
+				if i >= len(required) {
+					continue
+				}
+				return nil, fmt.Errorf("package %q requires %s, which isn't installed", p, name)
+			}
+			if dep == p {
+				continue
+			}
+			if err := graph.AddEdge(p, dep); err != nil {
+				return nil, fmt.Errorf("package %q: %w", p, err)
+			}
+		}
+	}
+	return graph, nil
+}
+
+// SortPackageLock sorts the packages in a package-lock.json, see [NewGraphFromPackageLock]. Every package comes after the packages it requires.
+func SortPackageLock(r io.Reader) ([]string, error) {
+	graph, err := NewGraphFromPackageLock(r)
+	if err != nil {
+		return []string{}, err
+	}
+	return graph.TopologicalSort()
+}
+
+// resolve finds the package name required from the package at from, looking in from's own node_modules and then in its parents'
+func resolve(packages map[string]lockfileItem, from, name string) (string, bool) {
+	dir := from
+	for {
+		candidate := path.Join(dir, "node_modules", name)
+		if dir == "" {
+			candidate = "node_modules/" + name
+		}
+		if _, ok := packages[candidate]; ok {
+			return candidate, true
+		}
+		if dir == "" {
+			return "", false
+		}
+		// step out of the innermost node_modules directory (or out of a workspace folder, to the root)
+		i := strings.LastIndex(dir, "/node_modules/")
+		if i < 0 {
+			dir = ""
+		} else {
+			dir = dir[:i]
+		}
+	}
+}
+
+// packageName derives a package's name from its lockfile path, e.g. "node_modules/a/node_modules/@scope/b" is "@scope/b"
+func packageName(p string) string {
+	i := strings.LastIndex(p, "node_modules/")
+	if i < 0 {
+		return path.Base(p)
+	}
+	return p[i+len("node_modules/"):]
+}
+
+func sortedNames(deps map[string]string) []string {
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,117 @@
+package npm
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+const packageLock = `{
+	"name": "app",
+	"lockfileVersion": 3,
+	"packages": {
+		"": {
+			"name": "app",
+			"dependencies": {"express": "^4.0.0", "widgets": "*"},
+			"devDependencies": {"@types/node": "^20.0.0"}
+		},
+		"node_modules/express": {
+			"version": "4.18.2",
+			"dependencies": {"debug": "2.6.9", "ms": "2.1.3"},
+			"optionalDependencies": {"fsevents": "*"}
+		},
+		"node_modules/express/node_modules/debug": {
+			"version": "2.6.9",
+			"dependencies": {"ms": "2.0.0"}
+		},
+		"node_modules/express/node_modules/debug/node_modules/ms": {"version": "2.0.0"},
+		"node_modules/ms": {"version": "2.1.3"},
+		"node_modules/@types/node": {"version": "20.1.0", "dev": true},
+		"node_modules/widgets": {"resolved": "packages/widgets", "link": true},
+		"packages/widgets": {"name": "widgets", "version": "1.0.0", "dependencies": {"ms": "*"}}
+	}
+}`
+
+func TestNewGraphFromPackageLock(t *testing.T) {
+	graph, err := NewGraphFromPackageLock(strings.NewReader(packageLock))
+	if err != nil {
+		t.Fatalf("NewGraphFromPackageLock() unexpected error %v", err)
+	}
+
+	if root, ok := graph.Vertex(""); !ok || root.Data.Name != "app" {
+		t.Errorf("NewGraphFromPackageLock() root vertex = %+v, want the project app", root)
+	}
+	types, ok := graph.Vertex("node_modules/@types/node")
+	if !ok || types.Data.Name != "@types/node" || !types.Data.Dev {
+		t.Errorf("NewGraphFromPackageLock() vertex = %+v, want the dev package @types/node", types)
+	}
+
+	want := []topologicalsort.Edge{
+		{Source: "", Dest: "node_modules/@types/node"},
+		{Source: "", Dest: "node_modules/express"},
+		{Source: "", Dest: "node_modules/widgets"},
+		{Source: "node_modules/express", Dest: "node_modules/express/node_modules/debug"},
+		{Source: "node_modules/express", Dest: "node_modules/ms"},
+		{Source: "node_modules/express/node_modules/debug", Dest: "node_modules/express/node_modules/debug/node_modules/ms"},
+		{Source: "node_modules/widgets", Dest: "packages/widgets"},
+		{Source: "packages/widgets", Dest: "node_modules/ms"},
+	}
+	if got := graph.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewGraphFromPackageLock() edges = %v, want %v", got, want)
+	}
+}
+
+func TestSortPackageLock(t *testing.T) {
+	got, err := SortPackageLock(strings.NewReader(packageLock))
+	if err != nil {
+		t.Fatalf("SortPackageLock() unexpected error %v", err)
+	}
+	if len(got) != 8 || got[len(got)-1] != "" {
+		t.Errorf("SortPackageLock() = %v, want all 8 packages ending with the root project", got)
+	}
+
+	cyclic := `{"lockfileVersion": 2, "packages": {"": {}, "node_modules/a": {"dependencies": {"b": "*"}}, "node_modules/b": {"dependencies": {"a": "*"}}}}`
+	if _, err := SortPackageLock(strings.NewReader(cyclic)); !errors.Is(err, topologicalsort.ErrCycle) {
+		t.Errorf("SortPackageLock() error = %v, want ErrCycle", err)
+	}
+}
+
+func TestNewGraphFromPackageLock_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "Malformed JSON", input: `{"packages": `},
+		{name: "Version 1", input: `{"lockfileVersion": 1, "dependencies": {}}`},
+		{name: "Missing dependency", input: `{"lockfileVersion": 3, "packages": {"": {"dependencies": {"left-pad": "*"}}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewGraphFromPackageLock(strings.NewReader(tt.input)); err == nil {
+				t.Errorf("NewGraphFromPackageLock() expected an error")
+			}
+		})
+	}
+}
+
+func TestNewGraphFromPackageLock_WorkspaceNamedLikeRoot(t *testing.T) {
+	lock := `{
+		"name": "web",
+		"lockfileVersion": 3,
+		"packages": {
+			"": {"name": "web", "dependencies": {"web-ui": "*"}},
+			"node_modules/web-ui": {"resolved": "web", "link": true},
+			"web": {"name": "web-ui", "version": "1.0.0"}
+		}
+	}`
+	got, err := SortPackageLock(strings.NewReader(lock))
+	if err != nil {
+		t.Fatalf("SortPackageLock() unexpected error %v", err)
+	}
+	if want := []string{"web", "node_modules/web-ui", ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortPackageLock() = %v, want %v", got, want)
+	}
+}
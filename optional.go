@@ -0,0 +1,52 @@
+package topologicalsort
+
+// AddOptionalDependency makes source depend on dest if dest is registered (now or later), and is silently dropped otherwise,
+// e.g. for a plugin which has to load after another plugin, but only if that one is installed.
+// source has to be registered. The optional dependencies which are still waiting for their dest are reported by [Graph.UnmetOptionalDependencies].
+func (g *Graph[T]) AddOptionalDependency(source, dest string) error {
+	if !g.HasVertex(source) {
+		return edgeError(source, dest, source, "attempted to add optional dependency to unregistered vertex %s", source)
+	}
+	if g.HasVertex(dest) {
+		return g.addEdge(Edge{Source: source, Dest: dest})
+	}
+	source, dest = g.canonicalKey(source), g.normalizeKey(dest)
+	for _, s := range g.pendingOptional[dest] {
+		if s == source {
+			if g.config.duplicateEdges != RejectDuplicates {
+				return nil
+			}
+			return edgeError(source, dest, "", "attempted to add duplicate optional dependency between %s and %s", source, dest)
+		}
+	}
+	g.pendingOptional[dest] = append(g.pendingOptional[dest], source)
+	return nil
+}
+
+// UnmetOptionalDependencies returns the optional dependencies whose dest isn't registered, sorted like [Graph.Edges].
+// They aren't part of the graph, so they don't affect sorting.
+func (g *Graph[T]) UnmetOptionalDependencies() []Edge {
+	edges := make([]Edge, 0)
+	for dest, sources := range g.pendingOptional {
+		for _, source := range sources {
+			edges = append(edges, Edge{Source: source, Dest: dest})
+		}
+	}
+	sortEdges(edges)
+	return edges
+}
+
+// addPendingOptional adds the optional dependencies that were waiting for key to be registered
+func (g *Graph[T]) addPendingOptional(key string) error {
+	sources, ok := g.pendingOptional[g.normalizeKey(key)]
+	if !ok {
+		return nil
+	}
+	delete(g.pendingOptional, g.normalizeKey(key))
+	for _, source := range sources {
+		if err := g.addEdge(Edge{Source: source, Dest: key}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph_AddOptionalDependency(t *testing.T) {
+	graph := NewGraphWithOptions[string]()
+	for _, k := range []string{"core", "auth", "metrics"} {
+		graph.RegisterVertex(k, "")
+	}
+	for _, e := range []Edge{
+		{Source: "auth", Dest: "core"},
+		{Source: "metrics", Dest: "auth"},
+		{Source: "metrics", Dest: "tracing"},
+		{Source: "auth", Dest: "ldap"},
+	} {
+		if err := graph.AddOptionalDependency(e.Source, e.Dest); err != nil {
+			t.Fatalf("Graph.AddOptionalDependency() unexpected error %v", err)
+		}
+	}
+
+	wantUnmet := []Edge{{Source: "auth", Dest: "ldap"}, {Source: "metrics", Dest: "tracing"}}
+	if got := graph.UnmetOptionalDependencies(); !reflect.DeepEqual(got, wantUnmet) {
+		t.Errorf("Graph.UnmetOptionalDependencies() = %v, want %v", got, wantUnmet)
+	}
+	wantOrder := []string{"core", "auth", "metrics"}
+	if got, err := graph.TopologicalSort(); err != nil || !reflect.DeepEqual(got, wantOrder) {
+		t.Errorf("Graph.TopologicalSort() = %v, %v, want %v", got, err, wantOrder)
+	}
+
+	// registering a missing dest turns the optional dependency into an edge
+	if err := graph.RegisterVertex("tracing", ""); err != nil {
+		t.Fatal(err)
+	}
+	wantUnmet = []Edge{{Source: "auth", Dest: "ldap"}}
+	if got := graph.UnmetOptionalDependencies(); !reflect.DeepEqual(got, wantUnmet) {
+		t.Errorf("Graph.UnmetOptionalDependencies() = %v, want %v", got, wantUnmet)
+	}
+	wantOrder = []string{"core", "auth", "tracing", "metrics"}
+	if got, err := graph.TopologicalSort(); err != nil || !reflect.DeepEqual(got, wantOrder) {
+		t.Errorf("Graph.TopologicalSort() = %v, %v, want %v", got, err, wantOrder)
+	}
+}
+
+func TestGraph_AddOptionalDependencyErrors(t *testing.T) {
+	graph := graphWithVerticesDUMMYDATA(map[string][]string{"auth": {}}, "")
+	if err := graph.AddOptionalDependency("metrics", "auth"); err == nil {
+		t.Errorf("Graph.AddOptionalDependency() expected an error for an unregistered source")
+	}
+	if err := graph.AddOptionalDependency("auth", "ldap"); err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.AddOptionalDependency("auth", "ldap"); err == nil {
+		t.Errorf("Graph.AddOptionalDependency() expected an error for a duplicate optional dependency")
+	}
+}
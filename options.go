@@ -0,0 +1,145 @@
+package topologicalsort
+
+import "log/slog"
+
+// Option configures optional behaviour of a graph, see [NewGraphWithOptions]
+type Option func(*config)
+
+type config struct {
+	selfCheck         bool
+	selfLoops         SelfLoopPolicy
+	parallelEdges     bool
+	duplicateVertices DuplicatePolicy
+	duplicateEdges    DuplicatePolicy
+	vertexCapacity    int
+	edgeCapacity      int
+	tracer            Tracer
+	metrics           Metrics
+	edgeSemantics     EdgeSemantics
+	parallelism       int
+	limits            Limits
+	history           bool
+	historyLimit      int
+	pinFirst          []string
+	pinLast           []string
+	sortCache         SortStore
+	keyNormalizer     func(string) string
+	missingVertices   MissingVertexPolicy
+	traversalOrder    TraversalOrder
+	insertionOrder    bool
+	logger            *slog.Logger
+}
+
+// DuplicatePolicy decides what happens when a vertex or edge is added twice, see [WithDuplicateVertices] and [WithDuplicateEdges]
+type DuplicatePolicy int
+
+const (
+	// RejectDuplicates returns an error for the duplicate. This is the default.
+	RejectDuplicates DuplicatePolicy = iota
+	// IgnoreDuplicates silently keeps the original
+	IgnoreDuplicates
+	// ReplaceDuplicates replaces the original vertex's Data with the duplicate's (for edges, it's the same as IgnoreDuplicates)
+	ReplaceDuplicates
+)
+
+// SelfLoopPolicy decides what AddEdge does with an edge from a vertex to itself, see [WithSelfLoops]
+type SelfLoopPolicy int
+
+const (
+	// RejectSelfLoops makes AddEdge return an error wrapping [ErrSelfLoop]. This is the default.
+	RejectSelfLoops SelfLoopPolicy = iota
+	// IgnoreSelfLoops makes AddEdge silently drop self loops
+	IgnoreSelfLoops
+	// AllowSelfLoops adds self loops like any other edge; sorting the graph will then fail with a cycle error
+	AllowSelfLoops
+)
+
+// EdgeSemantics decides what the direction of an edge passed to AddEdge means, see [WithEdgeSemantics]
+type EdgeSemantics int
+
+const (
+	// DependsOn means AddEdge(source, dest) makes source depend on dest, so dest comes first in the sorted order. This is the default.
+	DependsOn EdgeSemantics = iota
+	// Precedes means AddEdge(source, dest) makes source come before dest in the sorted order, like the textbook definition of topological sorting
+	Precedes
+)
+
+// WithSelfCheck makes the graph validate every sorted order against all of its edges before returning it.
+// If the check fails, the sort returns an error wrapping [ErrSelfCheckFailed]. This costs an extra pass over the graph.
+func WithSelfCheck() Option {
+	return func(c *config) {
+		c.selfCheck = true
+	}
+}
+
+// WithSelfLoops sets how edges from a vertex to itself are handled (by default, they are rejected)
+func WithSelfLoops(policy SelfLoopPolicy) Option {
+	return func(c *config) {
+		c.selfLoops = policy
+	}
+}
+
+// WithAllowParallelEdges lets AddEdge add more than one edge between the same two vertices, as long as each edge has a different [Edge.Label].
+// Sorting treats parallel edges like a single dependency.
+func WithAllowParallelEdges() Option {
+	return func(c *config) {
+		c.parallelEdges = true
+	}
+}
+
+// WithDuplicateVertices sets what RegisterVertex does with a key that is already registered (by default, it returns an error)
+func WithDuplicateVertices(policy DuplicatePolicy) Option {
+	return func(c *config) {
+		c.duplicateVertices = policy
+	}
+}
+
+// WithDuplicateEdges sets what AddEdge does with an edge that already exists (by default, it returns an error)
+func WithDuplicateEdges(policy DuplicatePolicy) Option {
+	return func(c *config) {
+		c.duplicateEdges = policy
+	}
+}
+
+// WithCapacity pre-sizes the graph for the expected number of vertices, and the expected number of edges (dependencies) per vertex.
+// It's only a hint, so graphs can still grow past it, but it saves a lot of map growth and slice reallocation when building very large graphs.
+func WithCapacity(vertices, edgesPerVertex int) Option {
+	return func(c *config) {
+		c.vertexCapacity = vertices
+		c.edgeCapacity = edgesPerVertex
+	}
+}
+
+// WithTracer makes the graph emit spans for construction (via [NewGraphFromData]), execution, and every sort: TopologicalSort and its variants
+// (TopologicalSortFor, TopologicalSortBestEffort, ...), Levels and its variants, and SampleTopologicalOrder. See [Tracer].
+func WithTracer(tracer Tracer) Option {
+	return func(c *config) {
+		c.tracer = tracer
+	}
+}
+
+// WithEdgeSemantics sets what AddEdge and AddEdges mean (by default, [DependsOn]).
+// It only changes how edges are added: the graph still stores and reports edges (e.g. in [Graph.Edges]) as "source depends on dest",
+// and AddDependency and [NewGraphFromData] always take dependencies.
+func WithEdgeSemantics(semantics EdgeSemantics) Option {
+	return func(c *config) {
+		c.edgeSemantics = semantics
+	}
+}
+
+// WithParallelism makes TopologicalSort use up to n goroutines. It splits the graph into its independent parts (weakly connected components) and sorts
+// small ones side by side; a part too big to be one goroutine's share (like a graph which is one big connected component) is sorted with Kahn's algorithm,
+// releasing the dependents of each frontier of ready vertices in parallel, so it comes out frontier by frontier. n <= 1 sorts sequentially.
+func WithParallelism(n int) Option {
+	return func(c *config) {
+		c.parallelism = n
+	}
+}
+
+func newConfig(opts []Option) config {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
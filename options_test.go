@@ -0,0 +1,126 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewGraphWithOptions(t *testing.T) {
+	g := NewGraphWithOptions[int](WithSelfCheck(), WithSelfLoops(IgnoreSelfLoops))
+	if !g.config.selfCheck || g.config.selfLoops != IgnoreSelfLoops {
+		t.Errorf("NewGraphWithOptions() didn't apply its options: %+v", g.config)
+	}
+
+	g.RegisterVertex("one", 1)
+	g.RegisterVertex("two", 2)
+	g.AddEdge("two", "one")
+	got, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatalf("Graph.TopologicalSort() unexpected error %v", err)
+	}
+	if want := []string{"one", "two"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.TopologicalSort() = %v, want %v", got, want)
+	}
+}
+
+func TestWithDuplicateVertices(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   DuplicatePolicy
+		wantErr  bool
+		wantData string
+	}{
+		{name: "Duplicate vertices are rejected by default", policy: RejectDuplicates, wantErr: true, wantData: "original"},
+		{name: "Duplicate vertices can be ignored", policy: IgnoreDuplicates, wantData: "original"},
+		{name: "Duplicate vertices can replace the original Data", policy: ReplaceDuplicates, wantData: "duplicate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGraphWithOptions[string](WithDuplicateVertices(tt.policy))
+			g.RegisterVertex("key", "original")
+			if err := g.RegisterVertex("key", "duplicate"); (err != nil) != tt.wantErr {
+				t.Errorf("Graph.RegisterVertex() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := g.vertex("key").Data; got != tt.wantData {
+				t.Errorf("vertex Data = %s, want %s", got, tt.wantData)
+			}
+		})
+	}
+}
+
+func TestWithDuplicateEdges(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{name: "Duplicate edges are rejected by default", wantErr: true},
+		{name: "Duplicate edges can be ignored", opts: []Option{WithDuplicateEdges(IgnoreDuplicates)}},
+		{name: "Duplicate parallel edges can be ignored", opts: []Option{WithDuplicateEdges(IgnoreDuplicates), WithAllowParallelEdges()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGraphWithOptions[string](tt.opts...)
+			g.RegisterVertex("one", "")
+			g.RegisterVertex("two", "")
+			g.AddEdge("two", "one")
+			if err := g.AddEdge("two", "one"); (err != nil) != tt.wantErr {
+				t.Errorf("Graph.AddEdge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(g.Edges()); got != 1 {
+				t.Errorf("Graph.Edges() has %d edges, want 1", got)
+			}
+		})
+	}
+}
+
+func TestWithCapacity(t *testing.T) {
+	g := NewGraphWithOptions[string](WithCapacity(10, 3))
+	g.RegisterVertex("one", "")
+	g.RegisterVertex("two", "")
+	g.AddEdge("two", "one")
+	if got := cap(g.adjacency[g.ids["two"]]); got != 3 {
+		t.Errorf("adjacency list capacity = %d, want 3", got)
+	}
+	if got := cap(g.nodes); got != 10 {
+		t.Errorf("vertex capacity = %d, want 10", got)
+	}
+}
+
+func TestWithEdgeSemantics(t *testing.T) {
+	tests := []struct {
+		name      string
+		semantics EdgeSemantics
+		want      []string
+	}{
+		{name: "DependsOn", semantics: DependsOn, want: []string{"libc", "gcc", "build-essential"}},
+		{name: "Precedes", semantics: Precedes, want: []string{"build-essential", "gcc", "libc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGraphWithOptions[string](WithEdgeSemantics(tt.semantics))
+			g.RegisterVertex("build-essential", "")
+			g.RegisterVertex("gcc", "")
+			g.RegisterVertex("libc", "")
+			g.AddEdge("build-essential", "gcc")
+			g.AddEdges(Edge{Source: "gcc", Dest: "libc"})
+
+			got, err := g.TopologicalSort()
+			if err != nil {
+				t.Fatalf("Graph.TopologicalSort() unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graph.TopologicalSort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// AddDependency always means "depends on"
+	g := NewGraphWithOptions[string](WithEdgeSemantics(Precedes))
+	g.RegisterVertex("gcc", "")
+	g.RegisterVertex("libc", "")
+	g.AddDependency("gcc", "libc")
+	if got := g.Edges(); !reflect.DeepEqual(got, []Edge{{Source: "gcc", Dest: "libc"}}) {
+		t.Errorf("Graph.Edges() = %v, want gcc depending on libc", got)
+	}
+}
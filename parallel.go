@@ -0,0 +1,212 @@
+package topologicalsort
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// minParallelFrontier is the smallest frontier which [Graph.frontierOrder] splits across goroutines; smaller ones aren't worth the coordination
+var minParallelFrontier = 256
+
+// parallelTopologicalSort sorts the graph using up to g.config.parallelism goroutines. It partitions the graph into weakly connected components,
+// which don't share edges, so concatenating their orders gives a valid order for the whole graph.
+// Components small enough to be a worker's share of the graph are spread across the goroutines and sorted depth-first, each on one goroutine.
+// Larger components (like a graph which is one big connected component) are sorted one at a time with Kahn's algorithm,
+// processing every frontier of ready vertices with all of the goroutines at once (see [Graph.frontierOrder]).
+// Components are concatenated in the order of their first vertex ID (or key, with [KeyOrder]), so the result doesn't depend on scheduling.
+func (g *Graph[T]) parallelTopologicalSort() ([]string, error) {
+	components := g.weakComponents()
+	// the workers only read g.keyOrder, so it has to be ready before they start
+	g.prepareTraversal()
+	if g.keyOrder != nil {
+		rank := g.keyOrder.rank
+		for _, component := range components {
+			sort.Slice(component, func(i, j int) bool { return rank[component[i]] < rank[component[j]] })
+		}
+		sort.Slice(components, func(i, j int) bool { return rank[components[i][0]] < rank[components[j][0]] })
+	}
+
+	orders := make([][]*GraphNode[T], len(components))
+	errs := make([]error, len(components))
+	share := max(1, len(g.nodes)/g.config.parallelism)
+	small := make([]int, 0, len(components))
+	var dependents [][]int32
+	for c, component := range components {
+		if len(component) <= share {
+			small = append(small, c)
+			continue
+		}
+		if dependents == nil {
+			dependents = g.dependentIDs()
+		}
+		orders[c], errs[c] = g.frontierOrder(component, dependents)
+	}
+
+	// components are disjoint, so the workers can share one state slice without touching the same elements
+	state := make([]dfsState, len(g.nodes))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(g.config.parallelism, len(small)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scratch := dfsScratch{state: state}
+			for c := range next {
+				order := make([]*GraphNode[T], 0, len(components[c]))
+				for _, id := range components[c] {
+					order, errs[c] = g.depthFirstOrder(id, &scratch, order)
+					if errs[c] != nil {
+						break
+					}
+				}
+				orders[c] = order
+			}
+		}()
+	}
+	for _, c := range small {
+		next <- c
+	}
+	close(next)
+	wg.Wait()
+
+	g.topoSortedOrder = make([]*GraphNode[T], 0, len(g.nodes))
+	for c, order := range orders {
+		if errs[c] != nil {
+			g.topoSortedOrder = g.topoSortedOrder[:0]
+			return []string{}, errs[c]
+		}
+		g.topoSortedOrder = append(g.topoSortedOrder, order...)
+	}
+
+	if err := g.applyPins(); err != nil {
+		return []string{}, err
+	}
+	if err := g.selfCheck(true); err != nil {
+		return []string{}, err
+	}
+	return g.SortedKeys(), nil
+}
+
+// frontierOrder sorts one component with Kahn's algorithm, one frontier (the vertices which became ready together) at a time.
+// Every frontier is split across up to g.config.parallelism goroutines, which release its vertices' dependents with atomic counters;
+// the next frontier is sorted by ID (or key, with [KeyOrder]), so the order doesn't depend on scheduling.
+func (g *Graph[T]) frontierOrder(component []int32, dependents [][]int32) ([]*GraphNode[T], error) {
+	remaining := make([]int32, len(g.nodes))
+	frontier := make([]int32, 0)
+	for _, id := range component {
+		remaining[id] = int32(len(g.adjacency[id]))
+		if remaining[id] == 0 {
+			frontier = append(frontier, id)
+		}
+	}
+
+	order := make([]*GraphNode[T], 0, len(component))
+	next := make([]int32, 0)
+	for len(frontier) > 0 {
+		for _, id := range frontier {
+			order = append(order, g.nodes[id])
+		}
+		// the two frontiers swap buffers, so narrow frontiers don't allocate
+		frontier, next = g.nextFrontier(frontier, next[:0], remaining, dependents), frontier
+	}
+	if len(order) == len(component) {
+		return order, nil
+	}
+
+	// vertices on (or depending on) a cycle never become ready; a depth-first search reports the cycle the way a sequential sort would
+	var scratch dfsScratch
+	scratch.reset(len(g.nodes))
+	for _, id := range component {
+		if _, err := g.depthFirstOrder(id, &scratch, nil); err != nil {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("%w: %d vertices never became ready", ErrCycle, len(component)-len(order))
+}
+
+// nextFrontier releases the dependents of every vertex in frontier, and appends the ones which became ready to next, in sorted order
+func (g *Graph[T]) nextFrontier(frontier, next []int32, remaining []int32, dependents [][]int32) []int32 {
+	workers := min(g.config.parallelism, len(frontier)/minParallelFrontier)
+	if workers <= 1 {
+		next = releaseDependents(frontier, next, remaining, dependents)
+	} else {
+		chunks := make([][]int32, workers)
+		size := (len(frontier) + workers - 1) / workers
+		var wg sync.WaitGroup
+		for w := range chunks {
+			start, end := w*size, min((w+1)*size, len(frontier))
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				chunks[w] = releaseDependents(frontier[start:end], make([]int32, 0), remaining, dependents)
+			}()
+		}
+		wg.Wait()
+		next = slices.Concat(append([][]int32{next}, chunks...)...)
+	}
+
+	if g.keyOrder != nil {
+		rank := g.keyOrder.rank
+		sort.Slice(next, func(i, j int) bool { return rank[next[i]] < rank[next[j]] })
+	} else {
+		slices.Sort(next)
+	}
+	return next
+}
+
+// releaseDependents counts down the remaining dependencies of the dependents of ids, appending the ones which reach zero to next
+func releaseDependents(ids, next []int32, remaining []int32, dependents [][]int32) []int32 {
+	for _, id := range ids {
+		for _, d := range dependents[id] {
+			if atomic.AddInt32(&remaining[d], -1) == 0 {
+				next = append(next, d)
+			}
+		}
+	}
+	return next
+}
+
+// weakComponents returns the vertex IDs of every weakly connected component (ignoring edge direction), in ID order,
+// with components ordered by their first ID
+func (g *Graph[T]) weakComponents() [][]int32 {
+	// union-find with path halving
+	parent := make([]int32, len(g.nodes))
+	for id := range parent {
+		parent[id] = int32(id)
+	}
+	find := func(id int32) int32 {
+		for parent[id] != id {
+			parent[id] = parent[parent[id]]
+			id = parent[id]
+		}
+		return id
+	}
+	for source, dests := range g.adjacency {
+		for _, dest := range dests {
+			a, b := find(int32(source)), find(dest)
+			// keep the smaller ID as the root, so a component's root is its first vertex
+			if a < b {
+				parent[b] = a
+			} else if b < a {
+				parent[a] = b
+			}
+		}
+	}
+
+	// a root's component index, plus one (so the zero value means "no component yet")
+	index := make([]int32, len(g.nodes))
+	components := make([][]int32, 0)
+	for id := range g.nodes {
+		root := find(int32(id))
+		if index[root] == 0 {
+			components = append(components, nil)
+			index[root] = int32(len(components))
+		}
+		c := index[root] - 1
+		components[c] = append(components[c], int32(id))
+	}
+	return components
+}
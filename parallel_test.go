@@ -0,0 +1,121 @@
+package topologicalsort
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestGraph_TopologicalSortWithParallelism(t *testing.T) {
+	g := NewGraphWithOptions[string](WithParallelism(4), WithSelfCheck())
+	// 20 separate chains of 5 vertices each: c<i>-4 depends on c<i>-3, which depends on c<i>-2, ...
+	for i := 0; i < 20; i++ {
+		for j := 0; j < 5; j++ {
+			g.RegisterVertex(fmt.Sprintf("c%d-%d", i, j), "")
+			if j > 0 {
+				g.AddEdge(fmt.Sprintf("c%d-%d", i, j), fmt.Sprintf("c%d-%d", i, j-1))
+			}
+		}
+	}
+
+	got, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatalf("Graph.TopologicalSort() unexpected error %v", err)
+	}
+	// components come out whole, in the order their vertices were registered
+	if len(got) != 100 || got[0] != "c0-0" || got[4] != "c0-4" || got[99] != "c19-4" {
+		t.Errorf("Graph.TopologicalSort() = %v", got)
+	}
+	again, _ := g.TopologicalSort()
+	if !reflect.DeepEqual(got, again) {
+		t.Errorf("Graph.TopologicalSort() = %v, then %v; want the same order", got, again)
+	}
+
+	g.AddEdge("c7-0", "c7-4")
+	if _, err := g.TopologicalSort(); !errors.Is(err, ErrCycle) {
+		t.Errorf("Graph.TopologicalSort() error = %v, want ErrCycle", err)
+	}
+}
+
+func TestGraph_weakComponents(t *testing.T) {
+	g := graphWithVerticesDUMMYDATA(map[string][]string{
+		"build-essential": {"make", "gcc"},
+		"gcc":             {"libc"},
+		"make":            {},
+		"libc":            {},
+		"python":          {"zlib"},
+		"zlib":            {},
+		"bash":            {},
+	}, "")
+
+	keys := make([][]string, 0)
+	for _, component := range g.weakComponents() {
+		group := make([]string, len(component))
+		for i, id := range component {
+			group[i] = g.nodes[id].Key
+		}
+		keys = append(keys, group)
+	}
+	if len(keys) != 3 {
+		t.Fatalf("Graph.weakComponents() = %v, want 3 components", keys)
+	}
+	sizes := map[int]int{}
+	for _, group := range keys {
+		sizes[len(group)]++
+	}
+	if !reflect.DeepEqual(sizes, map[int]int{4: 1, 2: 1, 1: 1}) {
+		t.Errorf("Graph.weakComponents() = %v, want components of 4, 2 and 1 vertices", keys)
+	}
+}
+
+func TestGraph_TopologicalSortWithParallelism_Connected(t *testing.T) {
+	// one connected component: layers of 1000 vertices, each depending on two vertices of the layer before,
+	// so every frontier is wide enough to be split across goroutines
+	const layers, width = 5, 1000
+	build := func(opts ...Option) *Graph[int] {
+		g := NewGraphWithOptions[int](opts...)
+		for l := 0; l < layers; l++ {
+			for i := 0; i < width; i++ {
+				g.RegisterVertex(fmt.Sprintf("l%d-%d", l, i), i)
+				if l > 0 {
+					g.AddEdge(fmt.Sprintf("l%d-%d", l, i), fmt.Sprintf("l%d-%d", l-1, i))
+					g.AddEdge(fmt.Sprintf("l%d-%d", l, i), fmt.Sprintf("l%d-%d", l-1, (i+1)%width))
+				}
+			}
+		}
+		return g
+	}
+
+	g := build(WithParallelism(4), WithSelfCheck())
+	if len(g.weakComponents()) != 1 {
+		t.Fatalf("test graph has %d components, want 1", len(g.weakComponents()))
+	}
+	got, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatalf("Graph.TopologicalSort() unexpected error %v", err)
+	}
+	// the component comes out frontier by frontier, each in registration order
+	for l := 0; l < layers; l++ {
+		for i := 0; i < width; i += 250 {
+			if want := fmt.Sprintf("l%d-%d", l, i); got[l*width+i] != want {
+				t.Fatalf("Graph.TopologicalSort()[%d] = %s, want %s", l*width+i, got[l*width+i], want)
+			}
+		}
+	}
+	again, _ := g.TopologicalSort()
+	if !reflect.DeepEqual(got, again) {
+		t.Errorf("Graph.TopologicalSort() gave different orders for the same graph")
+	}
+
+	g.AddEdge("l0-7", "l4-3")
+	_, err = g.TopologicalSort()
+	if !errors.Is(err, ErrCycle) {
+		t.Fatalf("Graph.TopologicalSort() error = %v, want ErrCycle", err)
+	}
+	sequential := build()
+	sequential.AddEdge("l0-7", "l4-3")
+	if _, want := sequential.TopologicalSort(); err.Error() != want.Error() {
+		t.Errorf("Graph.TopologicalSort() error = %v, want the sequential sort's %v", err, want)
+	}
+}
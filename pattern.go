@@ -0,0 +1,116 @@
+package topologicalsort
+
+import "fmt"
+
+// Pattern is a small "pattern DAG" that can be searched for inside a larger graph with [Graph.FindPattern].
+// Each pattern vertex carries a predicate that a graph vertex has to satisfy, and each pattern edge has to exist between the matched graph vertices.
+type Pattern[T any] struct {
+	predicates map[string]func(*GraphNode[T]) bool
+	edges      map[string][]string
+	// keys in the order they were added, so that matching is deterministic
+	order []string
+}
+
+// NewPattern returns an empty pattern for graphs holding Data of type T
+func NewPattern[T any]() *Pattern[T] {
+	return &Pattern[T]{
+		predicates: make(map[string]func(*GraphNode[T]) bool),
+		edges:      make(map[string][]string),
+		order:      make([]string, 0),
+	}
+}
+
+// AddVertex adds a pattern vertex. A nil predicate matches any graph vertex.
+func (p *Pattern[T]) AddVertex(key string, predicate func(*GraphNode[T]) bool) error {
+	if _, ok := p.predicates[key]; ok {
+		return fmt.Errorf("attempted to add duplicate pattern vertex %s", key)
+	}
+	if predicate == nil {
+		predicate = func(*GraphNode[T]) bool { return true }
+	}
+	p.predicates[key] = predicate
+	p.order = append(p.order, key)
+	return nil
+}
+
+// AddEdge adds a pattern edge; a match requires the same edge (source depends on dest) between the matched graph vertices
+func (p *Pattern[T]) AddEdge(source, dest string) error {
+	if _, ok := p.predicates[source]; !ok {
+		return fmt.Errorf("attempted to add pattern edge to unregistered vertex %s", source)
+	}
+	if _, ok := p.predicates[dest]; !ok {
+		return fmt.Errorf("attempted to add pattern edge from unregistered vertex %s", dest)
+	}
+	for _, d := range p.edges[source] {
+		if d == dest {
+			return fmt.Errorf("attempted to add duplicate pattern edge between %s and %s", source, dest)
+		}
+	}
+	p.edges[source] = append(p.edges[source], dest)
+	return nil
+}
+
+// FindPattern returns every occurrence of the pattern in the graph.
+// Each match maps pattern vertex keys to distinct graph vertex keys. Matches are returned in a deterministic order.
+func (g *Graph[T]) FindPattern(p *Pattern[T]) []map[string]string {
+	matches := make([]map[string]string, 0)
+	if len(p.order) == 0 {
+		return matches
+	}
+
+	// candidates are tried in sorted order so results are stable between runs
+	candidates := g.sortedVertexKeys()
+
+	assigned := make(map[string]string)
+	used := make(map[string]bool)
+
+	var search func(i int)
+	search = func(i int) {
+		if i == len(p.order) {
+			match := make(map[string]string, len(assigned))
+			for k, v := range assigned {
+				match[k] = v
+			}
+			matches = append(matches, match)
+			return
+		}
+
+		pkey := p.order[i]
+		for _, candidate := range candidates {
+			if used[candidate] || !p.predicates[pkey](g.vertex(candidate)) {
+				continue
+			}
+			assigned[pkey] = candidate
+			if g.patternEdgesHold(p, assigned, pkey) {
+				used[candidate] = true
+				search(i + 1)
+				used[candidate] = false
+			}
+			delete(assigned, pkey)
+		}
+	}
+	search(0)
+
+	return matches
+}
+
+// patternEdgesHold checks that every pattern edge between pkey and an already-assigned pattern vertex exists in the graph
+func (g *Graph[T]) patternEdgesHold(p *Pattern[T], assigned map[string]string, pkey string) bool {
+	for source, dests := range p.edges {
+		for _, dest := range dests {
+			if source != pkey && dest != pkey {
+				continue
+			}
+			gSource, sourceOk := assigned[source]
+			gDest, destOk := assigned[dest]
+			if !sourceOk || !destOk {
+				// checked once the other end gets assigned
+				continue
+			}
+			if !g.hasEdge(gSource, gDest) {
+				return false
+			}
+		}
+	}
+	return true
+}
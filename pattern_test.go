@@ -0,0 +1,86 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGraph_FindPattern(t *testing.T) {
+	graph := graphWithVerticesDUMMYDATA(map[string][]string{
+		"web-cache":   {"web-service", "db"},
+		"web-service": {"db"},
+		"api-cache":   {"api-service"},
+		"api-service": {"db"},
+		"db":          {},
+	}, "")
+
+	isCache := func(n *GraphNode[string]) bool { return strings.HasSuffix(n.Key, "-cache") }
+	isDB := func(n *GraphNode[string]) bool { return n.Key == "db" }
+
+	tests := []struct {
+		name  string
+		build func(p *Pattern[string])
+		want  []map[string]string
+	}{
+		{
+			name:  "An empty pattern has no matches",
+			build: func(p *Pattern[string]) {},
+			want:  []map[string]string{},
+		},
+		{
+			name: "Cache depending directly on the DB is found",
+			build: func(p *Pattern[string]) {
+				p.AddVertex("cache", isCache)
+				p.AddVertex("db", isDB)
+				p.AddEdge("cache", "db")
+			},
+			want: []map[string]string{{"cache": "web-cache", "db": "db"}},
+		},
+		{
+			name: "A nil predicate matches any vertex",
+			build: func(p *Pattern[string]) {
+				p.AddVertex("cache", isCache)
+				p.AddVertex("dep", nil)
+				p.AddEdge("cache", "dep")
+			},
+			want: []map[string]string{
+				{"cache": "api-cache", "dep": "api-service"},
+				{"cache": "web-cache", "dep": "db"},
+				{"cache": "web-cache", "dep": "web-service"},
+			},
+		},
+		{
+			name: "Multi-edge patterns require every edge",
+			build: func(p *Pattern[string]) {
+				p.AddVertex("a", nil)
+				p.AddVertex("b", nil)
+				p.AddVertex("c", nil)
+				p.AddEdge("a", "b")
+				p.AddEdge("b", "c")
+				p.AddEdge("a", "c")
+			},
+			want: []map[string]string{{"a": "web-cache", "b": "web-service", "c": "db"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPattern[string]()
+			tt.build(p)
+			if got := graph.FindPattern(p); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graph.FindPattern() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPattern_AddEdge(t *testing.T) {
+	p := NewPattern[string]()
+	p.AddVertex("a", nil)
+	if err := p.AddVertex("a", nil); err == nil {
+		t.Errorf("Pattern.AddVertex: expected an error for a duplicate vertex")
+	}
+	if err := p.AddEdge("a", "missing"); err == nil {
+		t.Errorf("Pattern.AddEdge: expected an error for an unregistered vertex")
+	}
+}
@@ -0,0 +1,96 @@
+package topologicalsort
+
+import "fmt"
+
+// SetVertexPhase tags a registered vertex with a phase label (e.g. "provision" or "configure"), for use with [Graph.TopologicalSortByPhase]
+func (g *Graph[T]) SetVertexPhase(key, phase string) error {
+	if !g.HasVertex(key) {
+		return vertexError(key, "attempted to set phase on unregistered vertex %s", key)
+	}
+	g.phases[g.canonicalKey(key)] = phase
+	return nil
+}
+
+// VertexPhase returns the phase label of a vertex, and whether it has one
+func (g *Graph[T]) VertexPhase(key string) (string, bool) {
+	phase, ok := g.phases[g.canonicalKey(key)]
+	return phase, ok
+}
+
+// TopologicalSortByPhase performs a topological sort that also respects phase boundaries:
+// every vertex of phases[0] comes before every vertex of phases[1], and so on.
+// Every vertex needs a phase from the given list, and it returns an error if a vertex depends on a vertex in a later phase.
+func (g *Graph[T]) TopologicalSortByPhase(phases ...string) ([]string, error) {
+	end := g.startSort("topologicalsort.sort_by_phase")
+	sorted, err := g.topologicalSortByPhase(phases...)
+	end(err)
+	return sorted, err
+}
+
+func (g *Graph[T]) topologicalSortByPhase(phases ...string) ([]string, error) {
+	phaseIndex := make(map[string]int, len(phases))
+	for i, phase := range phases {
+		if _, ok := phaseIndex[phase]; ok {
+			return []string{}, fmt.Errorf("phase %s listed more than once", phase)
+		}
+		phaseIndex[phase] = i
+	}
+
+	// bucket vertices by phase, in sorted key order (or insertion order) so the result is stable
+	keys := g.orderedVertexKeys()
+	buckets := make([][]*GraphNode[T], len(phases))
+	for _, k := range keys {
+		phase, ok := g.phases[k]
+		if !ok {
+			return []string{}, fmt.Errorf("vertex %s has no phase", k)
+		}
+		i, ok := phaseIndex[phase]
+		if !ok {
+			return []string{}, fmt.Errorf("vertex %s has unknown phase %s", k, phase)
+		}
+		buckets[i] = append(buckets[i], g.vertex(k))
+	}
+
+	// edges may only point at the same phase or an earlier one
+	for id, dests := range g.adjacency {
+		source := g.nodes[id].Key
+		for _, dest := range dests {
+			destKey := g.nodes[dest].Key
+			if phaseIndex[g.phases[destKey]] > phaseIndex[g.phases[source]] {
+				return []string{}, fmt.Errorf("vertex %s (phase %s) cannot depend on vertex %s in later phase %s", source, g.phases[source], destKey, g.phases[destKey])
+			}
+		}
+	}
+
+	visited := make(map[*GraphNode[T]]bool)
+	finished := make(map[*GraphNode[T]]bool)
+	g.topoSortedOrder = make([]*GraphNode[T], 0, len(g.nodes))
+
+	// earlier phases are finished by the time a later phase is searched, so a DFS never leaves its own phase
+	for _, bucket := range buckets {
+		for _, n := range bucket {
+			if finished[n] {
+				continue
+			}
+			var err error
+			visited, finished, err = g.DepthFirstSearch(n, visited, finished)
+			if err != nil {
+				return []string{}, err
+			}
+		}
+	}
+
+	if err := g.applyPins(); err != nil {
+		return []string{}, err
+	}
+	for i := 1; i < len(g.topoSortedOrder); i++ {
+		prev, node := g.topoSortedOrder[i-1].Key, g.topoSortedOrder[i].Key
+		if phaseIndex[g.phases[node]] < phaseIndex[g.phases[prev]] {
+			return []string{}, fmt.Errorf("pins put vertex %s (phase %s) after vertex %s in later phase %s", node, g.phases[node], prev, g.phases[prev])
+		}
+	}
+	if err := g.selfCheck(true); err != nil {
+		return []string{}, err
+	}
+	return g.SortedKeys(), nil
+}
@@ -0,0 +1,87 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph_TopologicalSortByPhase(t *testing.T) {
+	adjacencyList := map[string][]string{
+		"vpc":        {},
+		"subnet":     {"vpc"},
+		"instance":   {"subnet"},
+		"users":      {},
+		"nginx":      {"instance"},
+		"nginx-conf": {"nginx", "users"},
+	}
+	tests := []struct {
+		name    string
+		phaseOf map[string]string
+		phases  []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Phases are kept in order",
+			phaseOf: map[string]string{
+				"vpc": "provision", "subnet": "provision", "instance": "provision",
+				"users": "configure", "nginx": "configure", "nginx-conf": "configure",
+			},
+			phases: []string{"provision", "configure"},
+			want:   []string{"vpc", "subnet", "instance", "nginx", "users", "nginx-conf"},
+		},
+		{
+			name: "Unconnected vertices are still held back by their phase",
+			phaseOf: map[string]string{
+				"vpc": "provision", "subnet": "provision", "instance": "provision",
+				"users": "users", "nginx": "configure", "nginx-conf": "configure",
+			},
+			phases: []string{"users", "provision", "configure"},
+			want:   []string{"users", "vpc", "subnet", "instance", "nginx", "nginx-conf"},
+		},
+		{
+			name: "Edges contradicting phases trigger an error",
+			phaseOf: map[string]string{
+				"vpc": "provision", "subnet": "provision", "instance": "provision",
+				"users": "configure", "nginx": "configure", "nginx-conf": "configure",
+			},
+			phases:  []string{"configure", "provision"},
+			wantErr: true,
+		},
+		{
+			name: "Vertices without a phase trigger an error",
+			phaseOf: map[string]string{
+				"vpc": "provision",
+			},
+			phases:  []string{"provision"},
+			wantErr: true,
+		},
+		{
+			name: "Vertices with an unknown phase trigger an error",
+			phaseOf: map[string]string{
+				"vpc": "provision", "subnet": "provision", "instance": "provision",
+				"users": "configure", "nginx": "configure", "nginx-conf": "teardown",
+			},
+			phases:  []string{"provision", "configure"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphWithVerticesDUMMYDATA(adjacencyList, "")
+			for k, phase := range tt.phaseOf {
+				if err := g.SetVertexPhase(k, phase); err != nil {
+					t.Fatalf("SetVertexPhase: unexpected error %v", err)
+				}
+			}
+			got, err := g.TopologicalSortByPhase(tt.phases...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Graph.TopologicalSortByPhase() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graph.TopologicalSortByPhase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package topologicalsort
+
+// PinFirst makes TopologicalSort put the given vertices at the very start of the sorted order, in the given order (e.g. "monitoring first").
+// Sorting returns an error if a pin contradicts an edge, e.g. when a vertex pinned first depends on a vertex that isn't. It can be used more than once.
+// Pins are honoured by TopologicalSort, [Sorter], [Graph.TopologicalSortFor] (for the pinned vertices it sorts), [Graph.TopologicalSortWithPreferences],
+// [Graph.TopologicalSortByPhase] and [Graph.TopologicalSortByBlock] (which return an error if a pin breaks up a phase or block).
+// Other orders, like [Graph.Levels], [Graph.TopologicalSortBestEffort] and [Graph.SampleTopologicalOrder], ignore them.
+func PinFirst(keys ...string) Option {
+	return func(c *config) {
+		c.pinFirst = append(c.pinFirst, keys...)
+	}
+}
+
+// PinLast makes TopologicalSort put the given vertices at the very end of the sorted order, in the given order (e.g. "database last").
+// Sorting returns an error if a pin contradicts an edge, e.g. when a vertex depends on a vertex pinned last. It can be used more than once.
+// See [PinFirst] for the sorts which honour pins.
+func PinLast(keys ...string) Option {
+	return func(c *config) {
+		c.pinLast = append(c.pinLast, keys...)
+	}
+}
+
+// applyPins moves the vertices pinned by [PinFirst] and [PinLast] to the ends of g.topoSortedOrder, keeping everything else in its sorted order,
+// and checks that no edge is broken by doing so. Pinned vertices which aren't part of the order (e.g. in [Graph.TopologicalSortFor]) are left out.
+func (g *Graph[T]) applyPins() error {
+	if len(g.config.pinFirst) == 0 && len(g.config.pinLast) == 0 {
+		return nil
+	}
+
+	pinned := make(map[string]string, len(g.config.pinFirst)+len(g.config.pinLast))
+	for _, pins := range []struct {
+		keys     []string
+		position string
+	}{{g.config.pinFirst, "first"}, {g.config.pinLast, "last"}} {
+		for _, k := range pins.keys {
+			if !g.HasVertex(k) {
+				return vertexError(k, "attempted to pin unregistered vertex %s", k)
+			}
+			k = g.canonicalKey(k)
+			if _, ok := pinned[k]; ok {
+				return vertexError(k, "vertex %s is pinned more than once", k)
+			}
+			pinned[k] = pins.position
+		}
+	}
+
+	sorted := make(map[string]bool, len(g.topoSortedOrder))
+	for _, node := range g.topoSortedOrder {
+		sorted[node.Key] = true
+	}
+	order := make([]*GraphNode[T], 0, len(g.topoSortedOrder))
+	for _, k := range g.config.pinFirst {
+		if node := g.vertex(k); sorted[node.Key] {
+			order = append(order, node)
+		}
+	}
+	for _, node := range g.topoSortedOrder {
+		if _, ok := pinned[node.Key]; !ok {
+			order = append(order, node)
+		}
+	}
+	for _, k := range g.config.pinLast {
+		if node := g.vertex(k); sorted[node.Key] {
+			order = append(order, node)
+		}
+	}
+
+	position := make(map[string]int, len(order))
+	for i, node := range order {
+		position[node.Key] = i
+	}
+	for _, e := range g.Edges() {
+		sourcePosition, sourceSorted := position[e.Source]
+		destPosition, destSorted := position[e.Dest]
+		if !sourceSorted || !destSorted || destPosition < sourcePosition {
+			continue
+		}
+		if where, ok := pinned[e.Source]; ok && where == "first" {
+			return edgeError(e.Source, e.Dest, "", "vertex %s is pinned first but depends on %s", e.Source, e.Dest)
+		}
+		if where, ok := pinned[e.Dest]; ok && where == "last" {
+			return edgeError(e.Source, e.Dest, "", "vertex %s is pinned last but %s depends on it", e.Dest, e.Source)
+		}
+		return edgeError(e.Source, e.Dest, "", "pinned order contradicts the dependency of %s on %s", e.Source, e.Dest)
+	}
+
+	g.topoSortedOrder = order
+	return nil
+}
@@ -0,0 +1,123 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPinFirstAndPinLast(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		edges   []Edge
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "No pins",
+			edges: []Edge{{Source: "app", Dest: "database"}},
+			want:  []string{"database", "monitoring", "app", "cache"},
+		},
+		{
+			name:  "Monitoring first, cache last",
+			opts:  []Option{PinFirst("monitoring"), PinLast("cache")},
+			edges: []Edge{{Source: "app", Dest: "database"}},
+			want:  []string{"monitoring", "database", "app", "cache"},
+		},
+		{
+			name: "Several pins keep their given order",
+			opts: []Option{PinFirst("cache", "monitoring")},
+			want: []string{"cache", "monitoring", "database", "app"},
+		},
+		{
+			name:    "Pinned first vertex depends on another vertex",
+			opts:    []Option{PinFirst("app")},
+			edges:   []Edge{{Source: "app", Dest: "database"}},
+			wantErr: true,
+		},
+		{
+			name:    "Vertex depends on a pinned last vertex",
+			opts:    []Option{PinLast("database")},
+			edges:   []Edge{{Source: "app", Dest: "database"}},
+			wantErr: true,
+		},
+		{
+			name:    "Unregistered vertex",
+			opts:    []Option{PinLast("queue")},
+			wantErr: true,
+		},
+		{
+			name:    "Vertex pinned twice",
+			opts:    []Option{PinFirst("monitoring"), PinLast("monitoring")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := NewGraphWithOptions[string](tt.opts...)
+			for _, k := range []string{"database", "monitoring", "app", "cache"} {
+				graph.RegisterVertex(k, "")
+			}
+			if err := graph.AddEdges(tt.edges...); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := graph.TopologicalSort()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Graph.TopologicalSort() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graph.TopologicalSort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPins_OtherSorts(t *testing.T) {
+	newGraph := func() *Graph[string] {
+		graph := NewGraphWithOptions[string](PinFirst("monitoring"), PinLast("cache"))
+		for _, k := range []string{"database", "monitoring", "app", "cache"} {
+			graph.RegisterVertex(k, "")
+		}
+		graph.AddEdge("app", "database")
+		return graph
+	}
+	want := []string{"monitoring", "database", "app", "cache"}
+
+	if got, err := NewSorter[string]().Sort(newGraph()); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Sorter.Sort() = %v, %v, want %v", got, err, want)
+	}
+	if got, err := newGraph().TopologicalSortFor("app", "cache"); err != nil || !reflect.DeepEqual(got, []string{"database", "app", "cache"}) {
+		t.Errorf("Graph.TopologicalSortFor() = %v, %v, want the pinned vertex it sorts last", got, err)
+	}
+
+	pref := Preference{Before: "cache", After: "database"}
+	got, skipped, err := newGraph().TopologicalSortWithPreferences(pref)
+	if err != nil || !reflect.DeepEqual(got, want) || !reflect.DeepEqual(skipped, []Preference{pref}) {
+		t.Errorf("Graph.TopologicalSortWithPreferences() = %v, %v, %v, want %v with the preference the pin breaks skipped", got, skipped, err, want)
+	}
+
+	graph := newGraph()
+	for _, k := range []string{"database", "monitoring", "app", "cache"} {
+		graph.SetVertexPhase(k, "deploy")
+	}
+	if got, err := graph.TopologicalSortByPhase("deploy"); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.TopologicalSortByPhase() = %v, %v, want %v", got, err, want)
+	}
+	graph.SetVertexPhase("monitoring", "observe")
+	if _, err := graph.TopologicalSortByPhase("deploy", "observe"); err == nil {
+		t.Errorf("Graph.TopologicalSortByPhase() expected an error for a pin which moves a vertex out of its phase")
+	}
+
+	graph = newGraph()
+	graph.SetVertexBlock("database", "backend")
+	graph.SetVertexBlock("app", "backend")
+	if got, err := graph.TopologicalSortByBlock(); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.TopologicalSortByBlock() = %v, %v, want %v", got, err, want)
+	}
+	graph.SetVertexBlock("monitoring", "ops")
+	graph.SetVertexBlock("cache", "ops")
+	if _, err := graph.TopologicalSortByBlock(); err == nil {
+		t.Errorf("Graph.TopologicalSortByBlock() expected an error for pins which split up a block")
+	}
+}
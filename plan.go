@@ -0,0 +1,188 @@
+package topologicalsort
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Plan describes how [Graph.Execute] would process a graph, without running anything
+type Plan struct {
+	// Batches are the graph's levels: every vertex in a batch can run once the earlier batches are done
+	Batches [][]string
+	// Dependencies maps every vertex to the sorted keys of its direct dependencies
+	Dependencies map[string][]string
+	// CriticalPath is the heaviest dependency chain, dependencies first; it's only set if the plan was made with weights
+	CriticalPath       []string
+	CriticalPathWeight int
+}
+
+// Plan returns the execution plan for the graph. If weight is not nil, it estimates the cost of every vertex, and the plan includes the critical path.
+// It returns an error if the graph contains a cycle.
+func (g *Graph[T]) Plan(weight func(*GraphNode[T]) int) (Plan, error) {
+	batches, err := g.levels()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	plan := Plan{
+		Batches:      batches,
+		Dependencies: make(map[string][]string, len(g.nodes)),
+	}
+	for _, batch := range batches {
+		for _, k := range batch {
+			plan.Dependencies[k] = sortedUnique(g.dependencyKeys(k))
+		}
+	}
+
+	if weight != nil {
+		plan.CriticalPathWeight, plan.CriticalPath, err = g.CriticalPath(weight)
+		if err != nil {
+			return Plan{}, err
+		}
+	}
+	return plan, nil
+}
+
+// String renders the plan as a table, one vertex per row, followed by the critical path (if known)
+func (p Plan) String() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "BATCH\tVERTEX\tDEPENDS ON")
+	for i, batch := range p.Batches {
+		for _, k := range batch {
+			deps := "-"
+			if len(p.Dependencies[k]) > 0 {
+				deps = strings.Join(p.Dependencies[k], ", ")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", i+1, k, deps)
+		}
+	}
+	w.Flush()
+
+	if p.CriticalPath != nil {
+		fmt.Fprintf(&b, "critical path (weight %d): %s\n", p.CriticalPathWeight, strings.Join(p.CriticalPath, " -> "))
+	}
+	return b.String()
+}
+
+// CriticalPath returns the heaviest dependency chain in the graph, where weight gives the cost of every vertex, along with the chain's total weight.
+// The chain is listed dependencies first, and ties are broken by key. It returns an error if the graph contains a cycle, or a weight is negative.
+func (g *Graph[T]) CriticalPath(weight func(*GraphNode[T]) int) (int, []string, error) {
+	levels, err := g.levels()
+	if err != nil {
+		return 0, []string{}, err
+	}
+
+	// total[k] is the weight of the heaviest chain ending at k, and next[k] is k's dependency along that chain
+	total := make(map[string]int, len(g.nodes))
+	next := make(map[string]string, len(g.nodes))
+	heaviest := ""
+	for _, level := range levels {
+		for _, k := range level {
+			best, bestDep := 0, ""
+			for _, dest := range g.dependencyNodes(k) {
+				if bestDep == "" || total[dest.Key] > best || (total[dest.Key] == best && dest.Key < bestDep) {
+					best, bestDep = total[dest.Key], dest.Key
+				}
+			}
+			w := weight(g.vertex(k))
+			if w < 0 {
+				return 0, []string{}, fmt.Errorf("vertex %s has negative weight %d", k, w)
+			}
+			total[k] = best + w
+			if bestDep != "" {
+				next[k] = bestDep
+			}
+			if heaviest == "" || total[k] > total[heaviest] || (total[k] == total[heaviest] && k < heaviest) {
+				heaviest = k
+			}
+		}
+	}
+	if heaviest == "" {
+		return 0, []string{}, nil
+	}
+
+	path := make([]string, 0)
+	for k, ok := heaviest, true; ok; k, ok = next[k] {
+		path = append(path, k)
+	}
+	// the chain was collected top-down
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return total[heaviest], path, nil
+}
+
+// Timing is the schedule of a vertex worked out by [Graph.Timings] (the critical path method), in the units of the weights
+type Timing struct {
+	EarliestStart  int
+	EarliestFinish int
+	LatestStart    int
+	LatestFinish   int
+	// Slack is how long the vertex can be delayed without delaying the whole graph; vertices on the critical path have none
+	Slack int
+}
+
+// Timings works out when every vertex can run with the critical path method, where weight gives the duration of every vertex:
+// a vertex can start once all of its dependencies have finished, and the graph is done when the last vertex finishes.
+// It returns an error if the graph contains a cycle, or a weight is negative.
+func (g *Graph[T]) Timings(weight func(*GraphNode[T]) int) (map[string]Timing, error) {
+	levels, err := g.levels()
+	if err != nil {
+		return map[string]Timing{}, err
+	}
+
+	timings := make(map[string]Timing, len(g.nodes))
+	end := 0
+	for _, level := range levels {
+		for _, k := range level {
+			w := weight(g.vertex(k))
+			if w < 0 {
+				return map[string]Timing{}, fmt.Errorf("vertex %s has negative weight %d", k, w)
+			}
+			t := Timing{}
+			for _, dest := range g.dependencyNodes(k) {
+				if finish := timings[dest.Key].EarliestFinish; finish > t.EarliestStart {
+					t.EarliestStart = finish
+				}
+			}
+			t.EarliestFinish = t.EarliestStart + w
+			if t.EarliestFinish > end {
+				end = t.EarliestFinish
+			}
+			timings[k] = t
+		}
+	}
+
+	// latest times are worked out backwards from the end, dependents first
+	dependents := g.dependentsOf()
+	for i := len(levels) - 1; i >= 0; i-- {
+		for _, k := range levels[i] {
+			t := timings[k]
+			t.LatestFinish = end
+			for _, d := range dependents[k] {
+				if start := timings[d].LatestStart; start < t.LatestFinish {
+					t.LatestFinish = start
+				}
+			}
+			t.LatestStart = t.LatestFinish - (t.EarliestFinish - t.EarliestStart)
+			t.Slack = t.LatestStart - t.EarliestStart
+			timings[k] = t
+		}
+	}
+	return timings, nil
+}
+
+// sortedUnique sorts keys and drops duplicates (which parallel edges can produce)
+func sortedUnique(keys []string) []string {
+	sort.Strings(keys)
+	unique := keys[:0]
+	for i, k := range keys {
+		if i == 0 || k != keys[i-1] {
+			unique = append(unique, k)
+		}
+	}
+	return unique
+}
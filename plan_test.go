@@ -0,0 +1,100 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph_Plan(t *testing.T) {
+	g := graphWithVerticesDUMMYDATA(map[string][]string{
+		"build-essential": {"make", "gcc"},
+		"make":            {},
+		"gcc":             {"libc"},
+		"libc":            {},
+	}, 0)
+	for k, w := range map[string]int{"build-essential": 1, "make": 10, "gcc": 3, "libc": 2} {
+		g.SetVertexData(k, w)
+	}
+
+	plan, err := g.Plan(nil)
+	if err != nil {
+		t.Fatalf("Graph.Plan() unexpected error %v", err)
+	}
+	want := Plan{
+		Batches: [][]string{{"libc", "make"}, {"gcc"}, {"build-essential"}},
+		Dependencies: map[string][]string{
+			"build-essential": {"gcc", "make"},
+			"make":            {},
+			"gcc":             {"libc"},
+			"libc":            {},
+		},
+	}
+	if !reflect.DeepEqual(plan, want) {
+		t.Errorf("Graph.Plan() = %+v, want %+v", plan, want)
+	}
+
+	plan, err = g.Plan(func(n *GraphNode[int]) int { return n.Data })
+	if err != nil {
+		t.Fatalf("Graph.Plan() unexpected error %v", err)
+	}
+	if want := []string{"make", "build-essential"}; !reflect.DeepEqual(plan.CriticalPath, want) || plan.CriticalPathWeight != 11 {
+		t.Errorf("Graph.Plan() critical path = %v (weight %d), want %v (weight 11)", plan.CriticalPath, plan.CriticalPathWeight, want)
+	}
+
+	wantTable := `BATCH  VERTEX           DEPENDS ON
+1      libc             -
+1      make             -
+2      gcc              libc
+3      build-essential  gcc, make
+critical path (weight 11): make -> build-essential
+`
+	if got := plan.String(); got != wantTable {
+		t.Errorf("Plan.String() = %q, want %q", got, wantTable)
+	}
+
+	cyclic := graphWithVerticesDUMMYDATA(map[string][]string{"one": {"two"}, "two": {"one"}}, 0)
+	if _, err := cyclic.Plan(nil); err == nil {
+		t.Errorf("Graph.Plan() expected an error for a graph with a cycle")
+	}
+
+	g.SetVertexData("gcc", -20)
+	if _, _, err := g.CriticalPath(func(n *GraphNode[int]) int { return n.Data }); err == nil {
+		t.Errorf("Graph.CriticalPath() expected an error for a negative weight")
+	}
+	if _, err := g.Plan(func(n *GraphNode[int]) int { return n.Data }); err == nil {
+		t.Errorf("Graph.Plan() expected an error for a negative weight")
+	}
+}
+
+func TestGraph_Timings(t *testing.T) {
+	durations := map[string]int{"design": 3, "frontend": 5, "backend": 8, "docs": 2, "release": 1}
+	graph := NewGraphWithOptions[int]()
+	for k, d := range durations {
+		graph.RegisterVertex(k, d)
+	}
+	graph.AddEdge("frontend", "design")
+	graph.AddEdge("backend", "design")
+	graph.AddEdge("docs", "design")
+	graph.AddEdge("release", "frontend")
+	graph.AddEdge("release", "backend")
+	graph.AddEdge("release", "docs")
+
+	got, err := graph.Timings(func(n *GraphNode[int]) int { return n.Data })
+	if err != nil {
+		t.Fatalf("Graph.Timings() unexpected error %v", err)
+	}
+	want := map[string]Timing{
+		"design":   {EarliestStart: 0, EarliestFinish: 3, LatestStart: 0, LatestFinish: 3, Slack: 0},
+		"backend":  {EarliestStart: 3, EarliestFinish: 11, LatestStart: 3, LatestFinish: 11, Slack: 0},
+		"frontend": {EarliestStart: 3, EarliestFinish: 8, LatestStart: 6, LatestFinish: 11, Slack: 3},
+		"docs":     {EarliestStart: 3, EarliestFinish: 5, LatestStart: 9, LatestFinish: 11, Slack: 6},
+		"release":  {EarliestStart: 11, EarliestFinish: 12, LatestStart: 11, LatestFinish: 12, Slack: 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.Timings() = %v, want %v", got, want)
+	}
+
+	if _, err := graph.Timings(func(*GraphNode[int]) int { return -1 }); err == nil {
+		t.Errorf("Graph.Timings() expected an error for a negative weight")
+	}
+}
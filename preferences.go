@@ -0,0 +1,107 @@
+package topologicalsort
+
+// Preference is a soft ordering constraint: Before should come before After, as long as the graph's edges allow it
+type Preference struct {
+	Before string
+	After  string
+}
+
+// TopologicalSortWithPreferences sorts the graph like [Graph.TopologicalSort], additionally honoring as many soft preferences as possible (e.g. a preferred deploy order).
+// Preferences are considered in the given order, and one that conflicts with the graph's edges, an earlier preference or a pin (see [PinFirst]) is skipped instead of failing the sort.
+// It returns the order along with the skipped preferences. Vertices are taken in key order where neither edges nor preferences decide.
+// It returns an error if the graph contains a cycle, or a preference refers to an unregistered vertex.
+func (g *Graph[T]) TopologicalSortWithPreferences(prefs ...Preference) ([]string, []Preference, error) {
+	end := g.startSort("topologicalsort.sort_with_preferences")
+	sorted, skipped, err := g.topologicalSortWithPreferences(prefs...)
+	end(err)
+	return sorted, skipped, err
+}
+
+func (g *Graph[T]) topologicalSortWithPreferences(prefs ...Preference) ([]string, []Preference, error) {
+	for _, p := range prefs {
+		for _, k := range []string{p.Before, p.After} {
+			if !g.HasVertex(k) {
+				return []string{}, []Preference{}, vertexError(k, "preference refers to unregistered vertex %s", k)
+			}
+		}
+	}
+	if _, err := g.topologicalSort(); err != nil {
+		return []string{}, []Preference{}, err
+	}
+
+	// dependencies holds the graph's edges plus the accepted preferences, as "After depends on Before"
+	dependencies := g.AdjacencyMap()
+	skip := make([]bool, len(prefs))
+	for i, p := range prefs {
+		before, after := g.canonicalKey(p.Before), g.canonicalKey(p.After)
+		if before == after || dependsTransitively(dependencies, before, after) {
+			skip[i] = true
+			continue
+		}
+		dependencies[after] = append(dependencies[after], before)
+	}
+
+	// Kahn's algorithm, taking ready vertices in key order
+	remaining := make(map[string]int, len(g.nodes))
+	dependents := make(map[string][]string)
+	for source, dests := range dependencies {
+		remaining[source] = len(dests)
+		for _, dest := range dests {
+			dependents[dest] = append(dependents[dest], source)
+		}
+	}
+	ready := newReadyQueue(g.lessKey)
+	for _, k := range g.orderedVertexKeys() {
+		if remaining[k] == 0 {
+			ready.push(k)
+		}
+	}
+
+	g.topoSortedOrder = make([]*GraphNode[T], 0, len(g.nodes))
+	for ready.Len() > 0 {
+		k := ready.pop()
+		g.topoSortedOrder = append(g.topoSortedOrder, g.vertex(k))
+		for _, d := range dependents[k] {
+			remaining[d]--
+			if remaining[d] == 0 {
+				ready.push(d)
+			}
+		}
+	}
+	if err := g.applyPins(); err != nil {
+		return []string{}, []Preference{}, err
+	}
+
+	// pins win over preferences, so a preference the pins broke counts as skipped
+	position := make(map[string]int, len(g.topoSortedOrder))
+	for i, node := range g.topoSortedOrder {
+		position[node.Key] = i
+	}
+	skipped := make([]Preference, 0)
+	for i, p := range prefs {
+		if skip[i] || position[g.canonicalKey(p.After)] < position[g.canonicalKey(p.Before)] {
+			skipped = append(skipped, p)
+		}
+	}
+	return g.SortedKeys(), skipped, nil
+}
+
+// dependsTransitively reports whether source reaches dest in an adjacency map
+func dependsTransitively(dependencies map[string][]string, source, dest string) bool {
+	seen := map[string]bool{source: true}
+	stack := []string{source}
+	for len(stack) > 0 {
+		k := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, next := range dependencies[k] {
+			if next == dest {
+				return true
+			}
+			if !seen[next] {
+				seen[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+	return false
+}
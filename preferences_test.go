@@ -0,0 +1,76 @@
+package topologicalsort
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGraph_TopologicalSortWithPreferences(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        map[string][]string
+		prefs       []Preference
+		wantOrder   []string
+		wantSkipped []Preference
+	}{
+		{
+			name:        "No preferences",
+			data:        map[string][]string{"api": {"db"}, "db": {}, "cache": {}},
+			wantOrder:   []string{"cache", "db", "api"},
+			wantSkipped: []Preference{},
+		},
+		{
+			name:        "Honored preference",
+			data:        map[string][]string{"api": {"db"}, "db": {}, "cache": {}},
+			prefs:       []Preference{{Before: "api", After: "cache"}},
+			wantOrder:   []string{"db", "api", "cache"},
+			wantSkipped: []Preference{},
+		},
+		{
+			name:        "Preference conflicting with an edge",
+			data:        map[string][]string{"api": {"db"}, "db": {}, "cache": {}},
+			prefs:       []Preference{{Before: "api", After: "db"}},
+			wantOrder:   []string{"cache", "db", "api"},
+			wantSkipped: []Preference{{Before: "api", After: "db"}},
+		},
+		{
+			name: "Preference conflicting with an earlier preference",
+			data: map[string][]string{"api": {}, "db": {}, "cache": {}},
+			prefs: []Preference{
+				{Before: "db", After: "cache"},
+				{Before: "cache", After: "api"},
+				{Before: "api", After: "db"},
+			},
+			wantOrder:   []string{"db", "cache", "api"},
+			wantSkipped: []Preference{{Before: "api", After: "db"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := graphWithVerticesDUMMYDATA(tt.data, "")
+			order, skipped, err := graph.TopologicalSortWithPreferences(tt.prefs...)
+			if err != nil {
+				t.Fatalf("Graph.TopologicalSortWithPreferences() unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(order, tt.wantOrder) {
+				t.Errorf("Graph.TopologicalSortWithPreferences() order = %v, want %v", order, tt.wantOrder)
+			}
+			if !reflect.DeepEqual(skipped, tt.wantSkipped) {
+				t.Errorf("Graph.TopologicalSortWithPreferences() skipped = %v, want %v", skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestGraph_TopologicalSortWithPreferencesErrors(t *testing.T) {
+	graph := graphWithVerticesDUMMYDATA(map[string][]string{"api": {}, "db": {}}, "")
+	if _, _, err := graph.TopologicalSortWithPreferences(Preference{Before: "api", After: "cache"}); err == nil {
+		t.Errorf("Graph.TopologicalSortWithPreferences() expected an error for an unregistered vertex")
+	}
+
+	cyclic := graphWithVerticesDUMMYDATA(map[string][]string{"api": {"db"}, "db": {"api"}}, "")
+	if _, _, err := cyclic.TopologicalSortWithPreferences(); !errors.Is(err, ErrCycle) {
+		t.Errorf("Graph.TopologicalSortWithPreferences() error = %v, want ErrCycle", err)
+	}
+}
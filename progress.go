@@ -0,0 +1,141 @@
+package topologicalsort
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Progress tracks which vertices of a graph have been processed, so that processing can be interrupted and resumed later (see [Progress.Snapshot]).
+// It captures the graph's structure when it's created; edges added to the graph afterwards aren't taken into account.
+type Progress[T any] struct {
+	graph      *Graph[T]
+	done       map[string]bool
+	ready      map[string]bool
+	remaining  map[string]int
+	dependents map[string][]string
+}
+
+// ProgressSnapshot is the serializable state of a [Progress]: the keys of the vertices which are done
+type ProgressSnapshot struct {
+	Done []string `json:"done"`
+}
+
+// NewProgress starts tracking the processing of the graph, with nothing done yet. It returns an error if the graph contains a cycle, since it could never be finished.
+func (g *Graph[T]) NewProgress() (*Progress[T], error) {
+	if _, err := g.levels(); err != nil {
+		return nil, err
+	}
+	remaining, dependents := g.dependencyCounts()
+	ready := make(map[string]bool)
+	for k, count := range remaining {
+		if count == 0 {
+			ready[k] = true
+		}
+	}
+	return &Progress[T]{
+		graph:      g,
+		done:       make(map[string]bool),
+		ready:      ready,
+		remaining:  remaining,
+		dependents: dependents,
+	}, nil
+}
+
+// ResumeProgress restores a [Progress] from a snapshot taken earlier, marking the snapshot's vertices as done
+func (g *Graph[T]) ResumeProgress(snapshot ProgressSnapshot) (*Progress[T], error) {
+	p, err := g.NewProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	// the snapshot isn't necessarily in dependency order, so mark vertices as they become ready
+	pending := make(map[string]bool, len(snapshot.Done))
+	for _, k := range snapshot.Done {
+		if !g.HasVertex(k) {
+			return nil, fmt.Errorf("snapshot contains unregistered vertex %s", k)
+		}
+		pending[g.canonicalKey(k)] = true
+	}
+	queue := p.Ready()
+	for len(queue) > 0 {
+		k := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if pending[k] {
+			delete(pending, k)
+			newlyReady, _ := p.markDone(k)
+			queue = append(queue, newlyReady...)
+		}
+	}
+	if len(pending) > 0 {
+		stuck := make([]string, 0, len(pending))
+		for k := range pending {
+			stuck = append(stuck, k)
+		}
+		sort.Strings(stuck)
+		return nil, fmt.Errorf("snapshot marks vertices %v as done without all of their dependencies", stuck)
+	}
+	return p, nil
+}
+
+// MarkDone marks a vertex as processed. It returns an error if the vertex is unregistered, already done, or has dependencies which aren't done yet.
+func (p *Progress[T]) MarkDone(key string) error {
+	_, err := p.markDone(p.graph.canonicalKey(key))
+	return err
+}
+
+// markDone marks the vertex registered under key as processed, and returns the dependents which became ready because of it
+func (p *Progress[T]) markDone(key string) ([]string, error) {
+	count, ok := p.remaining[key]
+	if !ok {
+		return nil, vertexError(key, "attempted to mark unregistered vertex %s as done", key)
+	}
+	if p.done[key] {
+		return nil, fmt.Errorf("vertex %s is already done", key)
+	}
+	if count > 0 {
+		return nil, fmt.Errorf("vertex %s still has %d dependencies which aren't done", key, count)
+	}
+
+	p.done[key] = true
+	delete(p.ready, key)
+	newlyReady := make([]string, 0)
+	for _, d := range p.dependents[key] {
+		p.remaining[d]--
+		if p.remaining[d] == 0 {
+			p.ready[d] = true
+			newlyReady = append(newlyReady, d)
+		}
+	}
+	return newlyReady, nil
+}
+
+// IsDone reports whether a vertex has been marked as done
+func (p *Progress[T]) IsDone(key string) bool {
+	return p.done[p.graph.canonicalKey(key)]
+}
+
+// Ready returns the sorted keys of the vertices which aren't done yet, but whose dependencies all are.
+// The ready set is kept up to date by [Progress.MarkDone], so this only costs sorting it.
+func (p *Progress[T]) Ready() []string {
+	ready := make([]string, 0, len(p.ready))
+	for k := range p.ready {
+		ready = append(ready, k)
+	}
+	sort.Strings(ready)
+	return ready
+}
+
+// Finished reports whether every vertex is done
+func (p *Progress[T]) Finished() bool {
+	return len(p.done) == len(p.remaining)
+}
+
+// Snapshot returns the current state, for storing and passing to [Graph.ResumeProgress] later. Keys are sorted.
+func (p *Progress[T]) Snapshot() ProgressSnapshot {
+	done := make([]string, 0, len(p.done))
+	for k := range p.done {
+		done = append(done, k)
+	}
+	sort.Strings(done)
+	return ProgressSnapshot{Done: done}
+}
@@ -0,0 +1,128 @@
+package topologicalsort
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func progressTestGraph() *Graph[string] {
+	return graphWithVerticesDUMMYDATA(map[string][]string{
+		"build-essential": {"make", "gcc"},
+		"make":            {},
+		"gcc":             {"libc"},
+		"libc":            {},
+	}, "")
+}
+
+func TestProgress(t *testing.T) {
+	p, err := progressTestGraph().NewProgress()
+	if err != nil {
+		t.Fatalf("Graph.NewProgress() unexpected error %v", err)
+	}
+
+	if got, want := p.Ready(), []string{"libc", "make"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Progress.Ready() = %v, want %v", got, want)
+	}
+	if err := p.MarkDone("gcc"); err == nil {
+		t.Errorf("Progress.MarkDone() expected an error for a vertex whose dependencies aren't done")
+	}
+	if err := p.MarkDone("libc"); err != nil {
+		t.Fatalf("Progress.MarkDone() unexpected error %v", err)
+	}
+	if err := p.MarkDone("libc"); err == nil {
+		t.Errorf("Progress.MarkDone() expected an error for a vertex which is already done")
+	}
+	if err := p.MarkDone("emacs"); err == nil {
+		t.Errorf("Progress.MarkDone() expected an error for an unregistered vertex")
+	}
+	if got, want := p.Ready(), []string{"gcc", "make"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Progress.Ready() = %v, want %v", got, want)
+	}
+
+	for _, k := range []string{"gcc", "make", "build-essential"} {
+		if p.Finished() {
+			t.Errorf("Progress.Finished() = true before %s is done", k)
+		}
+		p.MarkDone(k)
+	}
+	if !p.Finished() || !p.IsDone("build-essential") {
+		t.Errorf("Progress.Finished() = false after everything is done")
+	}
+
+	cyclic := graphWithVerticesDUMMYDATA(map[string][]string{"one": {"two"}, "two": {"one"}}, "")
+	if _, err := cyclic.NewProgress(); err == nil {
+		t.Errorf("Graph.NewProgress() expected an error for a graph with a cycle")
+	}
+}
+
+func TestGraph_ResumeProgress(t *testing.T) {
+	g := progressTestGraph()
+	p, _ := g.NewProgress()
+	p.MarkDone("make")
+	p.MarkDone("libc")
+	p.MarkDone("gcc")
+
+	// round-trip through JSON, the way a tool would store it between runs
+	encoded, err := json.Marshal(p.Snapshot())
+	if err != nil {
+		t.Fatalf("json.Marshal() unexpected error %v", err)
+	}
+	if want := `{"done":["gcc","libc","make"]}`; string(encoded) != want {
+		t.Errorf("Progress.Snapshot() encoded = %s, want %s", encoded, want)
+	}
+	var snapshot ProgressSnapshot
+	json.Unmarshal(encoded, &snapshot)
+
+	resumed, err := g.ResumeProgress(snapshot)
+	if err != nil {
+		t.Fatalf("Graph.ResumeProgress() unexpected error %v", err)
+	}
+	if got, want := resumed.Ready(), []string{"build-essential"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Progress.Ready() after resuming = %v, want %v", got, want)
+	}
+
+	if _, err := g.ResumeProgress(ProgressSnapshot{Done: []string{"gcc"}}); err == nil {
+		t.Errorf("Graph.ResumeProgress() expected an error for a vertex done before its dependencies")
+	}
+	if _, err := g.ResumeProgress(ProgressSnapshot{Done: []string{"emacs"}}); err == nil {
+		t.Errorf("Graph.ResumeProgress() expected an error for an unregistered vertex")
+	}
+}
+
+func TestProgress_KeySpellings(t *testing.T) {
+	g := NewGraph("", WithKeyNormalizer(strings.ToLower))
+	g.RegisterVertex("glibc", "")
+	g.RegisterVertex("gcc", "")
+	g.AddEdge("gcc", "glibc")
+	if err := g.AddAlias("libc6", "glibc"); err != nil {
+		t.Fatalf("Graph.AddAlias() unexpected error %v", err)
+	}
+
+	p, _ := g.NewProgress()
+	if err := p.MarkDone("GLIBC"); err != nil {
+		t.Fatalf("Progress.MarkDone() with a normalized key unexpected error %v", err)
+	}
+	if !p.IsDone("libc6") || !p.IsDone("glibc") {
+		t.Errorf("Progress.IsDone() = false for a vertex marked done under another spelling")
+	}
+	if err := p.MarkDone("libc6"); err == nil {
+		t.Errorf("Progress.MarkDone() expected an error for an alias of a vertex which is already done")
+	}
+
+	s, _ := g.NewScheduler()
+	s.Next()
+	if err := s.Done("libc6"); err != nil {
+		t.Fatalf("Scheduler.Done() with an alias unexpected error %v", err)
+	}
+	if got, want := nodeKeys(s.Next()), []string{"gcc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Scheduler.Next() = %v, want %v", got, want)
+	}
+	if err := s.Done("GCC"); err != nil {
+		t.Fatalf("Scheduler.Done() with a normalized key unexpected error %v", err)
+	}
+	if !s.Finished() {
+		t.Errorf("Scheduler.Finished() = false after everything is done")
+	}
+}
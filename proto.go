@@ -0,0 +1,161 @@
+package topologicalsort
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// protobuf wire types used by proto/graph.proto
+const (
+	protoVarint = 0
+	protoBytes  = 2
+)
+
+// ToProto encodes the graph as a topologicalsort.v1.Graph protobuf message (see proto/graph.proto), for sending it to other services in a stable wire format.
+// encode turns every vertex's Data into opaque bytes; a nil encode leaves Data out. Vertices are written in registration order, so [FromProto] restores it.
+func (g *Graph[T]) ToProto(encode func(data T) ([]byte, error)) ([]byte, error) {
+	buf := make([]byte, 0)
+	for _, node := range g.nodes {
+		var data []byte
+		if encode != nil {
+			var err error
+			data, err = encode(node.Data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode data of vertex %s: %w", node.Key, err)
+			}
+		}
+		vertex := appendProtoBytes(nil, 1, []byte(node.Key))
+		vertex = appendProtoBytes(vertex, 2, data)
+		buf = appendProtoMessage(buf, 1, vertex)
+	}
+	for _, e := range g.Edges() {
+		edge := appendProtoBytes(nil, 1, []byte(e.Source))
+		edge = appendProtoBytes(edge, 2, []byte(e.Dest))
+		edge = appendProtoBytes(edge, 3, []byte(e.Label))
+		buf = appendProtoMessage(buf, 2, edge)
+	}
+	return buf, nil
+}
+
+// FromProto decodes a graph written by [Graph.ToProto] (or any other topologicalsort.v1.Graph message), configured by opts.
+// decode turns the opaque bytes back into Data; a nil decode leaves Data at its zero value. Edges always mean "source depends on dest", whatever the [EdgeSemantics].
+// Fields may arrive in any order: edges are added once all vertices are registered.
+func FromProto[T any](buf []byte, decode func(data []byte) (T, error), opts ...Option) (*Graph[T], error) {
+	graph := NewGraphWithOptions[T](opts...)
+	edges := make([]Edge, 0)
+	err := readProtoFields(buf, func(field int, value []byte) error {
+		switch field {
+		case 1:
+			var key string
+			var data []byte
+			err := readProtoFields(value, func(field int, value []byte) error {
+				switch field {
+				case 1:
+					key = string(value)
+				case 2:
+					data = value
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			var d T
+			if decode != nil {
+				d, err = decode(data)
+				if err != nil {
+					return fmt.Errorf("failed to decode data of vertex %s: %w", key, err)
+				}
+			}
+			return graph.RegisterVertex(key, d)
+		case 2:
+			var e Edge
+			err := readProtoFields(value, func(field int, value []byte) error {
+				switch field {
+				case 1:
+					e.Source = string(value)
+				case 2:
+					e.Dest = string(value)
+				case 3:
+					e.Label = string(value)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			edges = append(edges, e)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range edges {
+		if err := graph.addEdge(e); err != nil {
+			return nil, err
+		}
+	}
+	return graph, nil
+}
+
+func appendProtoMessage(buf []byte, field int, message []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field)<<3|protoBytes)
+	buf = binary.AppendUvarint(buf, uint64(len(message)))
+	return append(buf, message...)
+}
+
+// appendProtoBytes appends a string or bytes field, leaving it out if it's empty (like proto3 does)
+func appendProtoBytes(buf []byte, field int, value []byte) []byte {
+	if len(value) == 0 {
+		return buf
+	}
+	return appendProtoMessage(buf, field, value)
+}
+
+// readProtoFields calls fn for every length-delimited field of a message, skipping (varint and fixed-size) fields of other types
+func readProtoFields(buf []byte, fn func(field int, value []byte) error) error {
+	for len(buf) > 0 {
+		tag, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return errInvalidProto
+		}
+		buf = buf[n:]
+		field, wireType := int(tag>>3), tag&7
+
+		switch wireType {
+		case protoVarint:
+			_, n = binary.Uvarint(buf)
+			if n <= 0 {
+				return errInvalidProto
+			}
+			buf = buf[n:]
+		case 1, 5:
+			// fixed64 and fixed32
+			size := 8
+			if wireType == 5 {
+				size = 4
+			}
+			if len(buf) < size {
+				return errInvalidProto
+			}
+			buf = buf[size:]
+		case protoBytes:
+			length, n := binary.Uvarint(buf)
+			if n <= 0 || uint64(len(buf)-n) < length {
+				return errInvalidProto
+			}
+			value := buf[n : n+int(length)]
+			buf = buf[n+int(length):]
+			if err := fn(field, value); err != nil {
+				return err
+			}
+		default:
+			return errInvalidProto
+		}
+	}
+	return nil
+}
+
+var errInvalidProto = errors.New("invalid protobuf message")
@@ -0,0 +1,91 @@
+package topologicalsort
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGraph_ToProto(t *testing.T) {
+	graph := NewGraphWithOptions[string]()
+	graph.RegisterVertex("a", "x")
+	graph.RegisterVertex("b", "")
+	graph.AddEdges(Edge{Source: "b", Dest: "a", Label: "l"})
+
+	got, err := graph.ToProto(func(data string) ([]byte, error) { return []byte(data), nil })
+	if err != nil {
+		t.Fatalf("Graph.ToProto() unexpected error %v", err)
+	}
+	// the wire format must stay stable, so compare against the exact bytes
+	want := "0a060a0161120178" + "0a030a0162" + "12090a0162120161" + "1a016c"
+	if hex.EncodeToString(got) != want {
+		t.Errorf("Graph.ToProto() = %x, want %s", got, want)
+	}
+}
+
+func TestFromProto(t *testing.T) {
+	graph := NewGraphWithOptions[string]()
+	for _, k := range []string{"libc", "gcc", "make"} {
+		graph.RegisterVertex(k, k+"-data")
+	}
+	graph.AddEdges(Edge{Source: "gcc", Dest: "libc", Label: "links"}, Edge{Source: "make", Dest: "libc"})
+
+	encode := func(data string) ([]byte, error) { return []byte(data), nil }
+	decode := func(data []byte) (string, error) { return string(data), nil }
+	buf, err := graph.ToProto(encode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// edges keep their meaning whatever the semantics of the new graph
+	decoded, err := FromProto(buf, decode, WithEdgeSemantics(Precedes))
+	if err != nil {
+		t.Fatalf("FromProto() unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Edges(), graph.Edges()) {
+		t.Errorf("FromProto() edges = %v, want %v", decoded.Edges(), graph.Edges())
+	}
+	if !reflect.DeepEqual(decoded.AdjacencyMap(), graph.AdjacencyMap()) {
+		t.Errorf("FromProto() adjacency = %v, want %v", decoded.AdjacencyMap(), graph.AdjacencyMap())
+	}
+	if node, _ := decoded.Vertex("gcc"); node.Data != "gcc-data" {
+		t.Errorf("FromProto() vertex gcc has Data %q, want gcc-data", node.Data)
+	}
+	// proto3 lets fields arrive in any order, so an edge may come before its vertices
+	edgeFirst, _ := hex.DecodeString("12090a0162120161" + "1a016c" + "0a060a0161120178" + "0a030a0162")
+	reordered, err := FromProto(edgeFirst, decode)
+	if err != nil {
+		t.Fatalf("FromProto() of an edge before its vertices unexpected error %v", err)
+	}
+	if want := []Edge{{Source: "b", Dest: "a", Label: "l"}}; !reflect.DeepEqual(reordered.Edges(), want) {
+		t.Errorf("FromProto() of an edge before its vertices edges = %v, want %v", reordered.Edges(), want)
+	}
+
+	wantOrder, _ := graph.TopologicalSort()
+	if got, _ := decoded.TopologicalSort(); !reflect.DeepEqual(got, wantOrder) {
+		t.Errorf("FromProto() sorts to %v, want %v", got, wantOrder)
+	}
+}
+
+func TestFromProtoErrors(t *testing.T) {
+	decodeErr := errors.New("bad data")
+	tests := []struct {
+		name   string
+		buf    string
+		decode func([]byte) (string, error)
+	}{
+		{name: "Truncated message", buf: "0a060a01"},
+		{name: "Edge to unregistered vertex", buf: "12060a0162120161"},
+		{name: "Duplicate vertex", buf: "0a030a01610a030a0161"},
+		{name: "Failing decode", buf: "0a030a0161", decode: func([]byte) (string, error) { return "", decodeErr }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf, _ := hex.DecodeString(tt.buf)
+			if _, err := FromProto(buf, tt.decode); err == nil {
+				t.Errorf("FromProto() expected an error")
+			}
+		})
+	}
+}
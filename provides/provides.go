@@ -0,0 +1,306 @@
+// Package provides builds dependency graphs the way package managers express them: instead of naming each other directly,
+// packages declare the capabilities they provide (e.g. "mail-transport-agent") and the capabilities they require,
+// and every requirement is resolved to the one package providing it.
+//
+// Requirements may carry a version constraint, e.g. "libc >=2.31, <3" or "openssl ^3.0". When several versions of a capability are available,
+// the resolver picks the newest one which satisfies every constraint placed on it.
+package provides
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// ErrUnsatisfied is returned (wrapped) when nothing provides a requirement
+var ErrUnsatisfied = errors.New("unsatisfied requirement")
+
+// ErrAmbiguous is returned (wrapped) when more than one package provides a requirement
+var ErrAmbiguous = errors.New("ambiguous requirement")
+
+// ErrConflict is returned (wrapped) when every constraint on a capability can be satisfied on its own, but no single version satisfies all of them
+var ErrConflict = errors.New("conflicting requirements")
+
+// Package is a vertex which provides and requires capabilities. Every package implicitly provides its own Key as well.
+// Version is optional; it applies to everything the package provides, and is needed to satisfy requirements with a version constraint.
+type Package[T any] struct {
+	Key      string
+	Version  string
+	Data     T
+	Provides []string
+	// Requires lists capabilities, each optionally followed by a version constraint: "libc", "libc >=2.31, <3", "openssl ^3.0"
+	Requires []string
+}
+
+// Resolver collects packages and resolves their requirements into a graph
+type Resolver[T any] struct {
+	packages []Package[T]
+	index    map[string]int
+	opts     []topologicalsort.Option
+}
+
+// requirement is a parsed entry of [Package.Requires]
+type requirement struct {
+	from       string
+	text       string
+	capability string
+	constraint constraint
+}
+
+// NewResolver returns an empty resolver; opts are passed on to the graphs it builds
+func NewResolver[T any](opts ...topologicalsort.Option) *Resolver[T] {
+	return &Resolver[T]{index: make(map[string]int), opts: opts}
+}
+
+// Add adds a package. Its requirements don't have to be provided yet, but they have to be by the time the resolver resolves them.
+func (r *Resolver[T]) Add(p Package[T]) error {
+	if _, ok := r.index[p.Key]; ok {
+		return fmt.Errorf("attempted to add duplicate package %s", p.Key)
+	}
+	if p.Version != "" {
+		if _, err := parseVersion(p.Version); err != nil {
+			return fmt.Errorf("package %s: %w", p.Key, err)
+		}
+	}
+	for _, text := range p.Requires {
+		if _, err := parseRequirement(p.Key, text); err != nil {
+			return err
+		}
+	}
+	r.index[p.Key] = len(r.packages)
+	r.packages = append(r.packages, p)
+	return nil
+}
+
+// Providers returns the sorted keys of the packages which provide capability
+func (r *Resolver[T]) Providers(capability string) []string {
+	return r.providers()[capability]
+}
+
+// Resolve resolves the requirements of every package and returns the resulting graph, in which every package depends on the providers of its requirements.
+// A requirement a package provides itself is ignored. It returns every unsatisfied, ambiguous or conflicting requirement, joined with [errors.Join] in package order.
+func (r *Resolver[T]) Resolve() (*topologicalsort.Graph[T], error) {
+	keys := make([]string, len(r.packages))
+	for i, p := range r.packages {
+		keys[i] = p.Key
+	}
+	return r.ResolveFor(keys...)
+}
+
+// ResolveFor works like [Resolver.Resolve], but only includes the given packages and the providers their requirements (transitively) resolve to,
+// like a package manager installing them. Of several versions of a capability, only the chosen one is included.
+func (r *Resolver[T]) ResolveFor(keys ...string) (*topologicalsort.Graph[T], error) {
+	for _, k := range keys {
+		if _, ok := r.index[k]; !ok {
+			return nil, fmt.Errorf("attempted to resolve unknown package %s", k)
+		}
+	}
+	providers := r.providers()
+
+	// choosing a provider can pull in packages with more constraints, which can change earlier choices,
+	// so keep going until a pass doesn't turn up any new constraint. There's no backtracking: once seen, a constraint stays in effect,
+	// even if the package it came from is no longer chosen.
+	constraints := make(map[string][]requirement)
+	seen := make(map[string]bool)
+	var included []string
+	var edges []topologicalsort.Edge
+	var errs []error
+	for grew := true; grew; {
+		grew = false
+		included, edges, errs = nil, nil, nil
+		chosen := make(map[string]string)
+		conflicts := make(map[string]bool)
+		queued := make(map[string]bool)
+
+		queue := append([]string{}, keys...)
+		for _, k := range keys {
+			queued[k] = true
+		}
+		for len(queue) > 0 {
+			k := queue[0]
+			queue = queue[1:]
+			included = append(included, k)
+
+			for _, text := range r.packages[r.index[k]].Requires {
+				req, _ := parseRequirement(k, text)
+				if containsString(providers[req.capability], k) {
+					continue
+				}
+				key := req.from + "\x00" + req.text
+				if !seen[key] {
+					seen[key] = true
+					constraints[req.capability] = append(constraints[req.capability], req)
+					grew = true
+				}
+
+				provider, ok := chosen[req.capability]
+				if !ok {
+					var err error
+					provider, err = r.choose(req, providers[req.capability], constraints[req.capability])
+					if err != nil {
+						if !errors.Is(err, ErrConflict) || !conflicts[req.capability] {
+							errs = append(errs, err)
+						}
+						conflicts[req.capability] = conflicts[req.capability] || errors.Is(err, ErrConflict)
+						continue
+					}
+					chosen[req.capability] = provider
+				}
+				edges = append(edges, topologicalsort.Edge{Source: k, Dest: provider})
+				if !queued[provider] {
+					queued[provider] = true
+					queue = append(queue, provider)
+				}
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	// register in the order packages were added, so the graph doesn't depend on the order of the search
+	sort.Slice(included, func(i, j int) bool {
+		return r.index[included[i]] < r.index[included[j]]
+	})
+	opts := append([]topologicalsort.Option{topologicalsort.WithDuplicateEdges(topologicalsort.IgnoreDuplicates)}, r.opts...)
+	graph := topologicalsort.NewGraphWithOptions[T](opts...)
+	for _, k := range included {
+		p := r.packages[r.index[k]]
+		if err := graph.RegisterVertex(p.Key, p.Data); err != nil {
+			return nil, err
+		}
+	}
+	for _, e := range edges {
+		if err := graph.AddDependency(e.Source, e.Dest); err != nil {
+			return nil, err
+		}
+	}
+	return graph, nil
+}
+
+// Sort resolves the requirements of every package and returns the package keys in installation order (providers first)
+func (r *Resolver[T]) Sort() ([]string, error) {
+	graph, err := r.Resolve()
+	if err != nil {
+		return []string{}, err
+	}
+	return graph.TopologicalSort()
+}
+
+// choose picks the provider for req out of candidates: the newest version satisfying every constraint on the capability,
+// or the only candidate if there are no constraints
+func (r *Resolver[T]) choose(req requirement, candidates []string, constraints []requirement) (string, error) {
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("%w: package %s requires %s, which nothing provides", ErrUnsatisfied, req.from, req.capability)
+	}
+	constrained := false
+	for _, c := range constraints {
+		constrained = constrained || len(c.constraint) > 0
+	}
+	if !constrained && len(candidates) == 1 {
+		return candidates[0], nil
+	}
+
+	var best string
+	var bestVersion version
+	ambiguous := false
+	for _, k := range candidates {
+		if r.packages[r.index[k]].Version == "" {
+			if !constrained {
+				// without versions, there's nothing to choose by
+				return "", fmt.Errorf("%w: package %s requires %s, which is provided by %v", ErrAmbiguous, req.from, req.capability, candidates)
+			}
+			continue
+		}
+		v, _ := parseVersion(r.packages[r.index[k]].Version)
+		if !allowedByAll(v, constraints) {
+			continue
+		}
+		switch n := v.compare(bestVersion); {
+		case best == "" || n > 0:
+			best, bestVersion, ambiguous = k, v, false
+		case n == 0:
+			ambiguous = true
+		}
+	}
+
+	switch {
+	case ambiguous:
+		return "", fmt.Errorf("%w: package %s requires %s, which is provided by several packages at version %s", ErrAmbiguous, req.from, req.capability, r.packages[r.index[best]].Version)
+	case best != "":
+		return best, nil
+	}
+	if !r.satisfiable(req, candidates) {
+		return "", fmt.Errorf("%w: package %s requires %s, which no version of %v satisfies", ErrUnsatisfied, req.from, req.text, candidates)
+	}
+	texts := make([]string, len(constraints))
+	for i, c := range constraints {
+		texts[i] = fmt.Sprintf("%s requires %s", c.from, c.text)
+	}
+	return "", fmt.Errorf("%w: no version of %s satisfies all of: %s", ErrConflict, req.capability, strings.Join(texts, "; "))
+}
+
+// satisfiable reports whether one of the candidates satisfies req on its own
+func (r *Resolver[T]) satisfiable(req requirement, candidates []string) bool {
+	for _, k := range candidates {
+		if r.packages[r.index[k]].Version == "" {
+			continue
+		}
+		v, _ := parseVersion(r.packages[r.index[k]].Version)
+		if req.constraint.allows(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func allowedByAll(v version, constraints []requirement) bool {
+	for _, c := range constraints {
+		if !c.constraint.allows(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseRequirement splits an entry of [Package.Requires] into the capability and its (optional) version constraint
+func parseRequirement(from, text string) (requirement, error) {
+	name, rest := text, ""
+	if i := strings.IndexAny(text, " <>=!^~"); i >= 0 {
+		name, rest = text[:i], text[i:]
+	}
+	c, err := parseConstraint(rest)
+	if err != nil {
+		return requirement{}, fmt.Errorf("package %s: %w", from, err)
+	}
+	return requirement{from: from, text: text, capability: name, constraint: c}, nil
+}
+
+// providers maps every capability to the sorted keys of the packages providing it
+func (r *Resolver[T]) providers() map[string][]string {
+	providers := make(map[string][]string)
+	for _, p := range r.packages {
+		providers[p.Key] = append(providers[p.Key], p.Key)
+		for _, capability := range p.Provides {
+			if capability != p.Key && !containsString(providers[capability], p.Key) {
+				providers[capability] = append(providers[capability], p.Key)
+			}
+		}
+	}
+	for _, keys := range providers {
+		sort.Strings(keys)
+	}
+	return providers
+}
+
+func containsString(keys []string, match string) bool {
+	for _, k := range keys {
+		if k == match {
+			return true
+		}
+	}
+	return false
+}
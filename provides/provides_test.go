@@ -0,0 +1,138 @@
+package provides
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestResolver_Sort(t *testing.T) {
+	r := NewResolver[string]()
+	for _, p := range []Package[string]{
+		{Key: "mutt", Requires: []string{"mail-transport-agent", "libc"}},
+		{Key: "postfix", Provides: []string{"mail-transport-agent"}, Requires: []string{"libc"}},
+		{Key: "libc", Provides: []string{"libc.so.6"}},
+		{Key: "busybox", Provides: []string{"sh"}, Requires: []string{"sh", "libc.so.6"}},
+	} {
+		if err := r.Add(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := r.Sort()
+	if err != nil {
+		t.Fatalf("Resolver.Sort() unexpected error %v", err)
+	}
+	want := []string{"libc", "postfix", "mutt", "busybox"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Resolver.Sort() = %v, want %v", got, want)
+	}
+	if got := r.Providers("mail-transport-agent"); !reflect.DeepEqual(got, []string{"postfix"}) {
+		t.Errorf("Resolver.Providers() = %v, want [postfix]", got)
+	}
+}
+
+func TestResolver_ResolveErrors(t *testing.T) {
+	r := NewResolver[string]()
+	for _, p := range []Package[string]{
+		{Key: "mutt", Requires: []string{"mail-transport-agent"}},
+		{Key: "postfix", Provides: []string{"mail-transport-agent"}},
+		{Key: "exim", Provides: []string{"mail-transport-agent"}},
+		{Key: "git", Requires: []string{"perl"}},
+	} {
+		if err := r.Add(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Add(Package[string]{Key: "git"}); err == nil {
+		t.Errorf("Resolver.Add() expected an error for a duplicate package")
+	}
+
+	_, err := r.Resolve()
+	if !errors.Is(err, ErrAmbiguous) || !errors.Is(err, ErrUnsatisfied) {
+		t.Errorf("Resolver.Resolve() error = %v, want both ErrAmbiguous and ErrUnsatisfied", err)
+	}
+}
+
+func TestResolver_ResolveForVersions(t *testing.T) {
+	packages := []Package[string]{
+		{Key: "mutt", Version: "2.2.0", Requires: []string{"openssl ^3.0", "libc >=2.31"}},
+		{Key: "curl", Version: "8.5.0", Requires: []string{"openssl >=3.1", "libc"}},
+		{Key: "openssl-1.1.1", Version: "1.1.1", Provides: []string{"openssl"}, Requires: []string{"libc"}},
+		{Key: "openssl-3.0.13", Version: "3.0.13", Provides: []string{"openssl"}, Requires: []string{"libc"}},
+		{Key: "openssl-3.2.1", Version: "3.2.1", Provides: []string{"openssl"}, Requires: []string{"libc >=2.35"}},
+		{Key: "libc-2.31", Version: "2.31", Provides: []string{"libc"}},
+		{Key: "libc-2.35", Version: "2.35", Provides: []string{"libc"}},
+	}
+	tests := []struct {
+		name    string
+		keys    []string
+		extra   []Package[string]
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "Newest satisfying versions are chosen",
+			keys: []string{"mutt"},
+			want: []string{"libc-2.35", "openssl-3.2.1", "mutt"},
+		},
+		{
+			name: "Constraints from several packages are combined",
+			keys: []string{"mutt", "curl"},
+			want: []string{"libc-2.35", "openssl-3.2.1", "mutt", "curl"},
+		},
+		{
+			name:    "Conflicting constraints",
+			keys:    []string{"mutt", "legacy"},
+			extra:   []Package[string]{{Key: "legacy", Requires: []string{"openssl <3"}}},
+			wantErr: ErrConflict,
+		},
+		{
+			name:    "Constraint no version satisfies",
+			keys:    []string{"future"},
+			extra:   []Package[string]{{Key: "future", Requires: []string{"openssl >=4"}}},
+			wantErr: ErrUnsatisfied,
+		},
+		{
+			name:    "Several packages with the same version",
+			keys:    []string{"mutt"},
+			extra:   []Package[string]{{Key: "libressl", Version: "3.2.1", Provides: []string{"openssl"}}},
+			wantErr: ErrAmbiguous,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewResolver[string]()
+			for _, p := range append(append([]Package[string]{}, packages...), tt.extra...) {
+				if err := r.Add(p); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			graph, err := r.ResolveFor(tt.keys...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Resolver.ResolveFor() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got, err := graph.TopologicalSort()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolver.ResolveFor() sorted = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolver_AddInvalidVersions(t *testing.T) {
+	r := NewResolver[string]()
+	if err := r.Add(Package[string]{Key: "mutt", Version: "two"}); err == nil {
+		t.Errorf("Resolver.Add() expected an error for an invalid version")
+	}
+	if err := r.Add(Package[string]{Key: "mutt", Requires: []string{"libc >=two"}}); err == nil {
+		t.Errorf("Resolver.Add() expected an error for an invalid constraint")
+	}
+}
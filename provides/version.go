@@ -0,0 +1,129 @@
+package provides
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// version is a parsed semantic version; missing minor and patch numbers count as 0
+type version struct {
+	numbers    [3]int
+	prerelease string
+}
+
+func parseVersion(s string) (version, error) {
+	var v version
+	core := strings.TrimPrefix(s, "v")
+	// build metadata doesn't take part in comparisons
+	core, _, _ = strings.Cut(core, "+")
+	core, v.prerelease, _ = strings.Cut(core, "-")
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return version{}, fmt.Errorf("invalid version %q", s)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version{}, fmt.Errorf("invalid version %q", s)
+		}
+		v.numbers[i] = n
+	}
+	return v, nil
+}
+
+// compare returns -1, 0 or 1 depending on whether v is older than, the same as or newer than other.
+// A prerelease is older than the release itself; prereleases are compared as strings.
+func (v version) compare(other version) int {
+	for i := range v.numbers {
+		if v.numbers[i] != other.numbers[i] {
+			if v.numbers[i] < other.numbers[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case v.prerelease == other.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	case v.prerelease < other.prerelease:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// comparison is one part of a constraint, e.g. ">=1.2.0"
+type comparison struct {
+	op string
+	v  version
+}
+
+// constraint is a list of comparisons which all have to hold; an empty constraint allows any version
+type constraint []comparison
+
+// parseConstraint parses comma-separated comparisons (=, !=, >, >=, <, <=), along with ^1.2.3 (same major version) and ~1.2.3 (same minor version)
+func parseConstraint(s string) (constraint, error) {
+	c := make(constraint, 0)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		op := "="
+		for _, candidate := range []string{">=", "<=", "!=", ">", "<", "=", "^", "~"} {
+			if strings.HasPrefix(part, candidate) {
+				op = candidate
+				break
+			}
+		}
+		v, err := parseVersion(strings.TrimSpace(strings.TrimPrefix(part, op)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid constraint %q: %w", s, err)
+		}
+
+		switch op {
+		case "^":
+			upper := version{numbers: [3]int{v.numbers[0] + 1, 0, 0}}
+			if v.numbers[0] == 0 {
+				upper = version{numbers: [3]int{0, v.numbers[1] + 1, 0}}
+			}
+			c = append(c, comparison{op: ">=", v: v}, comparison{op: "<", v: upper})
+		case "~":
+			c = append(c, comparison{op: ">=", v: v}, comparison{op: "<", v: version{numbers: [3]int{v.numbers[0], v.numbers[1] + 1, 0}}})
+		default:
+			c = append(c, comparison{op: op, v: v})
+		}
+	}
+	return c, nil
+}
+
+// allows reports whether v satisfies every comparison of the constraint
+func (c constraint) allows(v version) bool {
+	for _, cmp := range c {
+		n := v.compare(cmp.v)
+		var ok bool
+		switch cmp.op {
+		case "=":
+			ok = n == 0
+		case "!=":
+			ok = n != 0
+		case ">":
+			ok = n > 0
+		case ">=":
+			ok = n >= 0
+		case "<":
+			ok = n < 0
+		case "<=":
+			ok = n <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
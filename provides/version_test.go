@@ -0,0 +1,41 @@
+package provides
+
+import "testing"
+
+func TestConstraintAllows(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{constraint: "", version: "1.0.0", want: true},
+		{constraint: "1.2", version: "1.2.0", want: true},
+		{constraint: "=1.2.0", version: "1.2.1", want: false},
+		{constraint: "!=1.2.0", version: "1.2.1", want: true},
+		{constraint: ">=1.2, <2", version: "1.9.9", want: true},
+		{constraint: ">=1.2, <2", version: "2.0.0", want: false},
+		{constraint: "<2", version: "2.0.0-rc1", want: true},
+		{constraint: ">1.0.0-alpha", version: "1.0.0-beta", want: true},
+		{constraint: "^1.2.3", version: "1.9.0", want: true},
+		{constraint: "^1.2.3", version: "2.0.0", want: false},
+		{constraint: "^0.2.3", version: "0.3.0", want: false},
+		{constraint: "~1.2.3", version: "1.2.9", want: true},
+		{constraint: "~1.2.3", version: "1.3.0", want: false},
+		{constraint: ">= v1.2.0", version: "v1.10.0+build.5", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint+" "+tt.version, func(t *testing.T) {
+			c, err := parseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("parseConstraint() unexpected error %v", err)
+			}
+			v, err := parseVersion(tt.version)
+			if err != nil {
+				t.Fatalf("parseVersion() unexpected error %v", err)
+			}
+			if got := c.allows(v); got != tt.want {
+				t.Errorf("constraint.allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
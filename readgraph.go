@@ -0,0 +1,44 @@
+package topologicalsort
+
+// ReadGraph is the read-only side of a graph, implemented by [*Graph] and [*View]. Accept it instead of *Graph[T]
+// in code which only looks at a graph, so it can't change the graph and is easy to test against a fake.
+type ReadGraph[T any] interface {
+	// HasVertex reports whether a vertex is registered under key
+	HasVertex(key string) bool
+	// Vertex returns the vertex registered under key, and whether there is one
+	Vertex(key string) (*GraphNode[T], bool)
+	// Keys returns the sorted keys of all vertices
+	Keys() []string
+	// Dependencies returns the vertices key directly depends on, or an error if key isn't a vertex
+	Dependencies(key string) ([]*GraphNode[T], error)
+	// Edges returns every edge, sorted by source, dest and label (or in insertion order, see [Graph.Edges])
+	Edges() []Edge
+	// TopologicalSort returns the keys of all vertices, every vertex after its dependencies, or an error if there's a cycle
+	TopologicalSort() ([]string, error)
+}
+
+var (
+	_ ReadGraph[any] = (*Graph[any])(nil)
+	_ ReadGraph[any] = (*View[any])(nil)
+)
+
+// Keys returns the sorted keys of all vertices (in registration order with [WithInsertionOrder])
+func (g *Graph[T]) Keys() []string {
+	return g.orderedVertexKeys()
+}
+
+// Dependencies returns the vertices key directly depends on, in the order the edges were added
+func (g *Graph[T]) Dependencies(key string) ([]*GraphNode[T], error) {
+	if !g.HasVertex(key) {
+		return []*GraphNode[T]{}, vertexError(key, "attempted to read dependencies of unregistered vertex %s", key)
+	}
+	return g.dependencyNodes(key), nil
+}
+
+// Vertex returns the vertex registered under key, and whether it's part of the view
+func (v *View[T]) Vertex(key string) (*GraphNode[T], bool) {
+	if !v.HasVertex(key) {
+		return nil, false
+	}
+	return v.graph.Vertex(key)
+}
@@ -0,0 +1,92 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// describe only needs to read a graph, so it takes a ReadGraph
+func describe[T any](g ReadGraph[T]) (string, error) {
+	order, err := g.TopologicalSort()
+	if err != nil {
+		return "", err
+	}
+	lines := make([]string, 0, len(order))
+	for _, k := range order {
+		deps, err := g.Dependencies(k)
+		if err != nil {
+			return "", err
+		}
+		keys := make([]string, len(deps))
+		for i, d := range deps {
+			keys[i] = d.Key
+		}
+		lines = append(lines, k+": "+strings.Join(keys, ","))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// fakeGraph is a ReadGraph of two vertices, b depending on a
+type fakeGraph struct{}
+
+func (fakeGraph) HasVertex(key string) bool { return key == "a" || key == "b" }
+func (f fakeGraph) Vertex(key string) (*GraphNode[int], bool) {
+	if !f.HasVertex(key) {
+		return nil, false
+	}
+	return NewGraphNode(key, 0), true
+}
+func (fakeGraph) Keys() []string { return []string{"a", "b"} }
+func (fakeGraph) Dependencies(key string) ([]*GraphNode[int], error) {
+	if key == "b" {
+		return []*GraphNode[int]{NewGraphNode("a", 0)}, nil
+	}
+	return []*GraphNode[int]{}, nil
+}
+func (fakeGraph) Edges() []Edge                      { return []Edge{{Source: "b", Dest: "a"}} }
+func (fakeGraph) TopologicalSort() ([]string, error) { return []string{"a", "b"}, nil }
+
+func TestReadGraph(t *testing.T) {
+	graph := NewGraphWithOptions[int]()
+	for _, k := range []string{"c", "b", "a"} {
+		if err := graph.RegisterVertex(k, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := graph.AddEdges(Edge{Source: "b", Dest: "a"}, Edge{Source: "c", Dest: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	view := graph.FilterView(func(n *GraphNode[int]) bool { return n.Key != "c" }, nil)
+
+	tests := []struct {
+		name  string
+		graph ReadGraph[int]
+		want  string
+	}{
+		{name: "Graph", graph: graph, want: "a: \nb: a\nc: b"},
+		{name: "View", graph: view, want: "a: \nb: a"},
+		{name: "Fake", graph: fakeGraph{}, want: "a: \nb: a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := describe(tt.graph)
+			if err != nil {
+				t.Fatalf("describe() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("describe() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := graph.Keys(); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("Graph.Keys() = %v, want [a b c]", got)
+	}
+	if _, err := graph.Dependencies("missing"); err == nil {
+		t.Errorf("Graph.Dependencies() of an unregistered vertex should have failed")
+	}
+	if _, ok := view.Vertex("c"); ok {
+		t.Errorf("View.Vertex() returned a vertex outside of the view")
+	}
+}
@@ -0,0 +1,45 @@
+package topologicalsort
+
+import "container/heap"
+
+// readyQueue holds the vertices (or blocks) which are ready in a Kahn's algorithm sort, and hands them out smallest first according to less.
+// It's a binary heap, so a sort taking ready items in order costs O(n log n) rather than re-sorting the ready items after every pop.
+type readyQueue[K any] struct {
+	items []K
+	less  func(a, b K) bool
+}
+
+// newReadyQueue returns a queue holding items, ordered by less
+func newReadyQueue[K any](less func(a, b K) bool, items ...K) *readyQueue[K] {
+	q := &readyQueue[K]{items: items, less: less}
+	heap.Init(q)
+	return q
+}
+
+// push adds an item which became ready
+func (q *readyQueue[K]) push(item K) {
+	heap.Push(q, item)
+}
+
+// pop removes and returns the smallest ready item
+func (q *readyQueue[K]) pop() K {
+	return heap.Pop(q).(K)
+}
+
+// Len, Less, Swap, Push and Pop implement [heap.Interface]; use push and pop instead
+
+func (q *readyQueue[K]) Len() int           { return len(q.items) }
+func (q *readyQueue[K]) Less(i, j int) bool { return q.less(q.items[i], q.items[j]) }
+func (q *readyQueue[K]) Swap(i, j int)      { q.items[i], q.items[j] = q.items[j], q.items[i] }
+func (q *readyQueue[K]) Push(x any)         { q.items = append(q.items, x.(K)) }
+
+func (q *readyQueue[K]) Pop() any {
+	last := q.items[len(q.items)-1]
+	q.items = q.items[:len(q.items)-1]
+	return last
+}
+
+// keyLess reports whether a comes before b in key order
+func keyLess(a, b string) bool {
+	return a < b
+}
@@ -0,0 +1,28 @@
+package topologicalsort
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestReadyQueue(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	q := newReadyQueue(func(a, b int) bool { return a < b }, 5, 3, 9)
+	want := []int{3, 5, 9}
+	for i := 0; i < 50; i++ {
+		n := rng.Intn(100)
+		q.push(n)
+		want = append(want, n)
+	}
+	sort.Ints(want)
+
+	got := make([]int, 0, len(want))
+	for q.Len() > 0 {
+		got = append(got, q.pop())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readyQueue popped %v, want %v", got, want)
+	}
+}
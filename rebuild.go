@@ -0,0 +1,86 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"slices"
+)
+
+// RebuildReason says why [PlanRebuild] schedules a vertex
+type RebuildReason string
+
+const (
+	// RebuildNew is a vertex which isn't part of the previous graph
+	RebuildNew RebuildReason = "new"
+	// RebuildChanged is a vertex whose Data changed
+	RebuildChanged RebuildReason = "changed"
+	// RebuildDependenciesChanged is a vertex which gained or lost dependencies
+	RebuildDependenciesChanged RebuildReason = "dependencies changed"
+	// RebuildNotDone is a vertex which didn't complete in the previous run
+	RebuildNotDone RebuildReason = "not done"
+	// RebuildDependency is a vertex which depends, directly or transitively, on another vertex that has to run
+	RebuildDependency RebuildReason = "dependency rebuilt"
+)
+
+// RebuildPlan is the result of [PlanRebuild]
+type RebuildPlan struct {
+	// Order lists the vertices which have to run, in topological order
+	Order []string
+	// Reasons says why every vertex in Order has to run
+	Reasons map[string]RebuildReason
+}
+
+// PlanRebuild works out the least work needed to bring the results of a previous run of previous up to date with current, like an incremental build system:
+// the vertices which are new, changed, gained or lost dependencies, or weren't done in the previous run (according to state, see [Progress.Snapshot]),
+// plus every vertex depending on one of them. changed compares the Data of a vertex in both graphs; if it's nil, Data is compared by its content hash
+// if it implements [ContentHasher], and with [reflect.DeepEqual] otherwise.
+// Vertices removed from the graph are ignored. It returns an error if current contains a cycle.
+func PlanRebuild[T any](previous, current *Graph[T], state ProgressSnapshot, changed func(old, new T) bool) (RebuildPlan, error) {
+	if changed == nil {
+		changed = func(old, new T) bool {
+			oldHasher, oldOk := any(old).(ContentHasher)
+			newHasher, newOk := any(new).(ContentHasher)
+			if oldOk && newOk {
+				return oldHasher.ContentHash() != newHasher.ContentHash()
+			}
+			return !reflect.DeepEqual(old, new)
+		}
+	}
+	order, err := current.TopologicalSort()
+	if err != nil {
+		return RebuildPlan{}, err
+	}
+
+	done := make(map[string]bool, len(state.Done))
+	for _, k := range state.Done {
+		done[k] = true
+	}
+	plan := RebuildPlan{Order: make([]string, 0), Reasons: make(map[string]RebuildReason)}
+	// dependencies come first in the order, so every vertex knows whether one of its dependencies runs by the time it's checked
+	for _, k := range order {
+		node := current.vertex(k)
+		old := previous.vertex(k)
+		var reason RebuildReason
+		switch {
+		case old == nil:
+			reason = RebuildNew
+		case changed(old.Data, node.Data):
+			reason = RebuildChanged
+		case !slices.Equal(sortedUnique(previous.dependencyKeys(k)), sortedUnique(current.dependencyKeys(k))):
+			reason = RebuildDependenciesChanged
+		case !done[k]:
+			reason = RebuildNotDone
+		default:
+			for _, dep := range current.dependencyKeys(k) {
+				if _, ok := plan.Reasons[dep]; ok {
+					reason = RebuildDependency
+					break
+				}
+			}
+		}
+		if reason != "" {
+			plan.Order = append(plan.Order, k)
+			plan.Reasons[k] = reason
+		}
+	}
+	return plan, nil
+}
@@ -0,0 +1,72 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanRebuild(t *testing.T) {
+	build := func(data map[string]string, edges []Edge) *Graph[string] {
+		graph := NewGraphWithOptions[string]()
+		for _, k := range []string{"proto", "lib", "cli", "server", "docs", "lint"} {
+			if d, ok := data[k]; ok {
+				graph.RegisterVertex(k, d)
+			}
+		}
+		graph.AddEdges(edges...)
+		return graph
+	}
+	previous := build(
+		map[string]string{"proto": "v1", "lib": "v1", "cli": "v1", "server": "v1", "docs": "v1"},
+		[]Edge{{Source: "lib", Dest: "proto"}, {Source: "cli", Dest: "lib"}, {Source: "server", Dest: "lib"}},
+	)
+
+	tests := []struct {
+		name    string
+		current *Graph[string]
+		done    []string
+		want    RebuildPlan
+	}{
+		{
+			name:    "Nothing changed",
+			current: previous,
+			done:    []string{"cli", "docs", "lib", "proto", "server"},
+			want:    RebuildPlan{Order: []string{}, Reasons: map[string]RebuildReason{}},
+		},
+		{
+			name: "Changed vertex and its dependents",
+			current: build(
+				map[string]string{"proto": "v2", "lib": "v1", "cli": "v1", "server": "v1", "docs": "v1"},
+				[]Edge{{Source: "lib", Dest: "proto"}, {Source: "cli", Dest: "lib"}, {Source: "server", Dest: "lib"}},
+			),
+			done: []string{"cli", "docs", "lib", "proto", "server"},
+			want: RebuildPlan{
+				Order:   []string{"proto", "lib", "cli", "server"},
+				Reasons: map[string]RebuildReason{"proto": RebuildChanged, "lib": RebuildDependency, "cli": RebuildDependency, "server": RebuildDependency},
+			},
+		},
+		{
+			name: "New vertex, new dependency and unfinished work",
+			current: build(
+				map[string]string{"proto": "v1", "lib": "v1", "cli": "v1", "server": "v1", "docs": "v1", "lint": "v1"},
+				[]Edge{{Source: "lib", Dest: "proto"}, {Source: "cli", Dest: "lib"}, {Source: "server", Dest: "lib"}, {Source: "docs", Dest: "cli"}},
+			),
+			done: []string{"cli", "lib", "proto"},
+			want: RebuildPlan{
+				Order:   []string{"server", "docs", "lint"},
+				Reasons: map[string]RebuildReason{"server": RebuildNotDone, "docs": RebuildDependenciesChanged, "lint": RebuildNew},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PlanRebuild(previous, tt.current, ProgressSnapshot{Done: tt.done}, nil)
+			if err != nil {
+				t.Fatalf("PlanRebuild() unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlanRebuild() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package topologicalsort
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// RunRecord is a record of a [Run]: the vertices it started, in the order it started them, with their outcomes and timings, and the vertices it skipped.
+// It's plain data, so it can be stored as JSON (e.g. by a CI job which failed) and replayed with [Graph.Replay].
+type RunRecord struct {
+	Events  []TimelineEvent `json:"events"`
+	Skipped []string        `json:"skipped"`
+}
+
+// Record returns a record of the run so far; call it after [Run.Wait] to record the whole run
+func (r *Run[T]) Record() RunRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record := RunRecord{
+		Events:  make([]TimelineEvent, len(r.timeline)),
+		Skipped: make([]string, len(r.skipped)),
+	}
+	copy(record.Events, r.timeline)
+	copy(record.Skipped, r.skipped)
+	return record
+}
+
+// Replay runs fn for the vertices of a recorded run one at a time, in the order the run started them. With stubs standing in for the real work,
+// a test can reproduce a run whose outcome depended on the order things happened in, the same way every time.
+// Every vertex gets up to as many attempts as it had in the record, without timeouts or backoff, and the vertices the record skipped are skipped again.
+// Only config's Inputs and Faults are used; inputs, outputs and faults work like in [Graph.Execute], so a seeded fault happens again.
+// It returns the results and error like Execute does, for comparing to the record. It returns an error without running anything if the record doesn't fit the graph:
+// if it contains an unregistered vertex, starts a vertex twice, or starts one before its dependencies.
+func (g *Graph[T]) Replay(ctx context.Context, record RunRecord, fn NodeFunc[T], config ExecuteConfig[T]) (map[string]NodeResult, error) {
+	ctx, span := g.startSpan(ctx, "topologicalsort.replay", Attribute{Key: "vertices", Value: len(g.nodes)})
+	results, err := g.replay(ctx, record, fn, config)
+	span.End(err)
+	return results, err
+}
+
+func (g *Graph[T]) replay(ctx context.Context, record RunRecord, fn NodeFunc[T], config ExecuteConfig[T]) (map[string]NodeResult, error) {
+	if err := g.checkRecord(record); err != nil {
+		return map[string]NodeResult{}, err
+	}
+	r, err := g.newRun(fn, config)
+	if err != nil {
+		return map[string]NodeResult{}, err
+	}
+	for _, k := range record.Skipped {
+		r.skip(g.canonicalKey(k))
+	}
+
+	failures := make([]error, 0)
+	for _, e := range record.Events {
+		if ctx.Err() != nil {
+			return r.Results(), ctx.Err()
+		}
+		node := g.vertex(e.Key)
+		event := r.startEvent(node.Key, e.Worker)
+		var output any
+		attempt := r.nodeFunc(node, &output)
+		result := NodeResult{Status: NodeFailed}
+		for result.Attempts < max(e.Attempts, 1) {
+			result.Attempts++
+			if result.Err = attempt(ctx, node); result.Err == nil {
+				result.Status = NodeSucceeded
+				result.Output = output
+				break
+			}
+		}
+		r.finish(event, node.Key, result)
+		if result.Status == NodeFailed {
+			failures = append(failures, nodeError(node.Key, result))
+		}
+	}
+	return r.Results(), errors.Join(failures...)
+}
+
+// checkRecord returns an error if a record can't be of a run of the graph
+func (g *Graph[T]) checkRecord(record RunRecord) error {
+	started := make(map[string]bool, len(record.Events))
+	for _, e := range record.Events {
+		if !g.HasVertex(e.Key) {
+			return vertexError(e.Key, "record contains unregistered vertex %s", e.Key)
+		}
+		key := g.canonicalKey(e.Key)
+		if started[key] {
+			return fmt.Errorf("record starts vertex %s twice", key)
+		}
+		for _, dep := range g.dependencyKeys(key) {
+			if !started[dep] {
+				return fmt.Errorf("record starts vertex %s before its dependency %s", key, dep)
+			}
+		}
+		started[key] = true
+	}
+	for _, k := range record.Skipped {
+		if !g.HasVertex(k) {
+			return vertexError(k, "record contains unregistered vertex %s", k)
+		}
+		if started[g.canonicalKey(k)] {
+			return fmt.Errorf("record both starts and skips vertex %s", k)
+		}
+	}
+	return nil
+}
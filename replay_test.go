@@ -0,0 +1,110 @@
+package topologicalsort
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGraph_Replay(t *testing.T) {
+	g := progressTestGraph()
+	for _, k := range []string{"zlib", "curl"} {
+		g.RegisterVertex(k, "")
+	}
+	g.AddEdge("curl", "zlib")
+	broken := errors.New("broken")
+	config := ExecuteConfig[string]{
+		Workers: 3,
+		Policy:  func(*GraphNode[string]) NodePolicy { return NodePolicy{Retries: 1, ContinueOnError: true} },
+	}
+
+	// zlib fails for good, gcc only on its first attempt
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+	work := func(ctx context.Context, node *GraphNode[string]) error {
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[node.Key]++
+		if node.Key == "zlib" || (node.Key == "gcc" && attempts[node.Key] == 1) {
+			return broken
+		}
+		return nil
+	}
+	run, err := g.Start(context.Background(), work, config)
+	if err != nil {
+		t.Fatalf("Graph.Start() unexpected error %v", err)
+	}
+	recorded, recordedErr := run.Wait()
+
+	// the record survives being stored
+	data, err := json.Marshal(run.Record())
+	if err != nil {
+		t.Fatalf("json.Marshal(Run.Record()) unexpected error %v", err)
+	}
+	var record RunRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatalf("json.Unmarshal(%s) unexpected error %v", data, err)
+	}
+	if !reflect.DeepEqual(record.Skipped, []string{"curl"}) || record.Events[0].Status == NodeNotRun {
+		t.Errorf("Run.Record() = %s, want curl skipped and every started vertex finished", data)
+	}
+
+	for i := 0; i < 3; i++ {
+		order := make([]string, 0)
+		attempts = make(map[string]int)
+		results, err := g.Replay(context.Background(), record, func(ctx context.Context, node *GraphNode[string]) error {
+			order = append(order, node.Key)
+			return work(ctx, node)
+		}, config)
+		if (err != nil) != (recordedErr != nil) || !errors.Is(err, broken) {
+			t.Errorf("Graph.Replay() error = %v, want %v", err, recordedErr)
+		}
+
+		// every attempt runs in the recorded order
+		want := make([]string, 0)
+		for _, e := range record.Events {
+			for a := 0; a < e.Attempts; a++ {
+				want = append(want, e.Key)
+			}
+		}
+		if !reflect.DeepEqual(order, want) {
+			t.Errorf("Graph.Replay() ran %v, want %v", order, want)
+		}
+		for k, result := range recorded {
+			if results[k].Status != result.Status || results[k].Attempts != result.Attempts {
+				t.Errorf("Graph.Replay() result of %s = %+v, recorded %+v", k, results[k], result)
+			}
+		}
+	}
+}
+
+func TestGraph_Replay_RecordMismatch(t *testing.T) {
+	g := progressTestGraph()
+	noop := func(context.Context, *GraphNode[string]) error { return nil }
+	tests := []struct {
+		name   string
+		record RunRecord
+	}{
+		{name: "Unregistered vertex", record: RunRecord{Events: []TimelineEvent{{Key: "clang"}}}},
+		{name: "Started twice", record: RunRecord{Events: []TimelineEvent{{Key: "make"}, {Key: "make"}}}},
+		{name: "Started before a dependency", record: RunRecord{Events: []TimelineEvent{{Key: "gcc"}, {Key: "libc"}}}},
+		{name: "Started and skipped", record: RunRecord{Events: []TimelineEvent{{Key: "make"}}, Skipped: []string{"make"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ran := false
+			_, err := g.Replay(context.Background(), tt.record, func(ctx context.Context, node *GraphNode[string]) error {
+				ran = true
+				return noop(ctx, node)
+			}, ExecuteConfig[string]{})
+			if err == nil || ran {
+				t.Errorf("Graph.Replay() error = %v, ran anything %v; want an error before running anything", err, ran)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Package resources builds dependency graphs from JSON descriptions of infrastructure resources with "depends_on" lists,
+// the shape used by most infrastructure-as-code tools. Sorting the graph gives a valid provisioning order.
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// Resource is the Data of every vertex: the resource's name, its dependencies, and the full JSON object describing it
+type Resource struct {
+	Name      string
+	DependsOn []string
+	Object    map[string]any
+}
+
+// Parse reads resources from r and returns a graph with one vertex per resource, depending on the resources in its depends_on list. It accepts:
+//
+//   - a JSON array of resource objects, named by their "type" and "name" fields ("aws_vpc.main", like Terraform addresses),
+//     or by their "name" or "id" field if they have no type
+//   - a JSON object mapping resource names to resource objects, optionally wrapped in a "Resources" object (like a CloudFormation template)
+//
+// Dependencies are read from "depends_on" or "DependsOn", which may be a list of names or a single name.
+// It returns an error if a resource depends on a resource which isn't described.
+func Parse(r io.Reader) (*topologicalsort.Graph[Resource], error) {
+	var document any
+	if err := json.NewDecoder(r).Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to decode resources: %w", err)
+	}
+
+	resources := make([]Resource, 0)
+	switch doc := document.(type) {
+	case []any:
+		for i, item := range doc {
+			object, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("resource %d is not an object", i)
+			}
+			name, err := resourceName(object)
+			if err != nil {
+				return nil, fmt.Errorf("resource %d: %w", i, err)
+			}
+			resources = append(resources, Resource{Name: name, Object: object})
+		}
+	case map[string]any:
+		if wrapped, ok := doc["Resources"].(map[string]any); ok {
+			doc = wrapped
+		}
+		names := make([]string, 0, len(doc))
+		for name := range doc {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			object, ok := doc[name].(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("resource %s is not an object", name)
+			}
+			resources = append(resources, Resource{Name: name, Object: object})
+		}
+	default:
+		return nil, fmt.Errorf("expected a JSON array or object of resources")
+	}
+
+	graph := topologicalsort.NewGraphWithOptions[Resource]()
+	for i := range resources {
+		deps, err := dependsOn(resources[i].Object)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s: %w", resources[i].Name, err)
+		}
+		resources[i].DependsOn = deps
+		if err := graph.RegisterVertex(resources[i].Name, resources[i]); err != nil {
+			return nil, fmt.Errorf("resource %s: %w", resources[i].Name, err)
+		}
+	}
+	for _, resource := range resources {
+		for _, dep := range resource.DependsOn {
+			if !graph.HasVertex(dep) {
+				return nil, fmt.Errorf("resource %s depends on undescribed resource %s", resource.Name, dep)
+			}
+			if err := graph.AddEdge(resource.Name, dep); err != nil {
+				return nil, fmt.Errorf("resource %s: %w", resource.Name, err)
+			}
+		}
+	}
+	return graph, nil
+}
+
+// ProvisioningOrder parses resources like [Parse] and returns their names in an order which creates every resource after its dependencies
+func ProvisioningOrder(r io.Reader) ([]string, error) {
+	graph, err := Parse(r)
+	if err != nil {
+		return []string{}, err
+	}
+	return graph.TopologicalSort()
+}
+
+func resourceName(object map[string]any) (string, error) {
+	name, _ := object["name"].(string)
+	if resourceType, ok := object["type"].(string); ok && name != "" {
+		return resourceType + "." + name, nil
+	}
+	if name != "" {
+		return name, nil
+	}
+	if id, ok := object["id"].(string); ok && id != "" {
+		return id, nil
+	}
+	return "", fmt.Errorf("resource has no name or id")
+}
+
+func dependsOn(object map[string]any) ([]string, error) {
+	raw, ok := object["depends_on"]
+	if !ok {
+		raw, ok = object["DependsOn"]
+	}
+	if !ok || raw == nil {
+		return []string{}, nil
+	}
+
+	switch deps := raw.(type) {
+	case string:
+		return []string{deps}, nil
+	case []any:
+		names := make([]string, len(deps))
+		for i, dep := range deps {
+			name, ok := dep.(string)
+			if !ok {
+				return nil, fmt.Errorf("depends_on entry %d is not a string", i)
+			}
+			names[i] = name
+		}
+		return names, nil
+	}
+	return nil, fmt.Errorf("depends_on must be a string or a list of strings")
+}
@@ -0,0 +1,75 @@
+package resources
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse_Array(t *testing.T) {
+	const input = `[
+		{"type": "aws_instance", "name": "web", "depends_on": ["aws_subnet.main"], "ami": "ami-123"},
+		{"type": "aws_subnet", "name": "main", "depends_on": ["aws_vpc.main"]},
+		{"type": "aws_vpc", "name": "main", "cidr_block": "10.0.0.0/16"}
+	]`
+	graph, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() unexpected error %v", err)
+	}
+	web, ok := graph.Vertex("aws_instance.web")
+	if !ok || web.Data.Object["ami"] != "ami-123" || !reflect.DeepEqual(web.Data.DependsOn, []string{"aws_subnet.main"}) {
+		t.Errorf("Parse() vertex = %+v, want the web instance", web)
+	}
+
+	order, err := graph.TopologicalSort()
+	if err != nil {
+		t.Fatalf("Graph.TopologicalSort() unexpected error %v", err)
+	}
+	if want := []string{"aws_vpc.main", "aws_subnet.main", "aws_instance.web"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("Graph.TopologicalSort() = %v, want %v", order, want)
+	}
+}
+
+func TestProvisioningOrder_Template(t *testing.T) {
+	const input = `{
+		"AWSTemplateFormatVersion": "2010-09-09",
+		"Resources": {
+			"Instance": {"Type": "AWS::EC2::Instance", "DependsOn": ["Subnet", "Role"]},
+			"Subnet": {"Type": "AWS::EC2::Subnet", "DependsOn": "VPC"},
+			"VPC": {"Type": "AWS::EC2::VPC"},
+			"Role": {"Type": "AWS::IAM::Role"}
+		}
+	}`
+	order, err := ProvisioningOrder(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ProvisioningOrder() unexpected error %v", err)
+	}
+	position := make(map[string]int)
+	for i, name := range order {
+		position[name] = i
+	}
+	if len(order) != 4 || position["VPC"] > position["Subnet"] || position["Subnet"] > position["Instance"] || position["Role"] > position["Instance"] {
+		t.Errorf("ProvisioningOrder() = %v, which doesn't respect DependsOn", order)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "Malformed JSON", input: `[{"name": "vpc"`},
+		{name: "Neither an array nor an object", input: `"vpc"`},
+		{name: "Unnamed resources", input: `[{"type": "aws_vpc"}]`},
+		{name: "Unknown dependencies", input: `{"subnet": {"depends_on": ["vpc"]}}`},
+		{name: "Malformed dependencies", input: `{"subnet": {"depends_on": [1]}}`},
+		{name: "Duplicate resources", input: `[{"name": "vpc"}, {"id": "vpc"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.input)); err == nil {
+				t.Errorf("Parse() expected an error")
+			}
+		})
+	}
+}
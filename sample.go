@@ -0,0 +1,84 @@
+package topologicalsort
+
+import "math/rand"
+
+// SampleTopologicalOrder returns a random valid topological order, e.g. for chaos-testing an executor against orders it doesn't usually see.
+// Every valid order can come up, but they aren't exactly equally likely: it picks uniformly among the vertices whose dependencies
+// are all placed, which is cheap but favours orders that place short chains early. The same rng state gives the same order.
+// It returns an error if the graph contains a cycle.
+func (g *Graph[T]) SampleTopologicalOrder(rng *rand.Rand) ([]string, error) {
+	end := g.startSort("topologicalsort.sample")
+	order, err := g.sampleTopologicalOrder(rng, func(*GraphNode[T]) float64 { return 1 })
+	end(err)
+	return order, err
+}
+
+// SampleTopologicalOrderWeighted is like [Graph.SampleTopologicalOrder], but picks among the vertices that are ready with a probability
+// proportional to their weight, so heavier vertices tend to come earlier. Weights have to be positive.
+func (g *Graph[T]) SampleTopologicalOrderWeighted(rng *rand.Rand, weight func(*GraphNode[T]) float64) ([]string, error) {
+	end := g.startSort("topologicalsort.sample")
+	order, err := g.sampleTopologicalOrder(rng, weight)
+	end(err)
+	return order, err
+}
+
+func (g *Graph[T]) sampleTopologicalOrder(rng *rand.Rand, weight func(*GraphNode[T]) float64) ([]string, error) {
+	remaining := make([]int, len(g.nodes))
+	dependents := make([][]int32, len(g.nodes))
+	ready := make([]int32, 0)
+	for id, dests := range g.adjacency {
+		remaining[id] = len(dests)
+		for _, dest := range dests {
+			dependents[dest] = append(dependents[dest], int32(id))
+		}
+		if len(dests) == 0 {
+			ready = append(ready, int32(id))
+		}
+	}
+	weights := make([]float64, len(g.nodes))
+	for id, node := range g.nodes {
+		weights[id] = weight(node)
+	}
+
+	g.topoSortedOrder = make([]*GraphNode[T], 0, len(g.nodes))
+	for len(ready) > 0 {
+		i := pickWeighted(rng, ready, weights)
+		id := ready[i]
+		ready[i] = ready[len(ready)-1]
+		ready = ready[:len(ready)-1]
+
+		g.topoSortedOrder = append(g.topoSortedOrder, g.nodes[id])
+		for _, d := range dependents[id] {
+			remaining[d]--
+			if remaining[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+
+	if len(g.topoSortedOrder) < len(g.nodes) {
+		placed := make(map[string]bool, len(g.topoSortedOrder))
+		for _, node := range g.topoSortedOrder {
+			placed[node.Key] = true
+		}
+		return []string{}, g.levelCycleError(placed)
+	}
+	return g.SortedKeys(), nil
+}
+
+// pickWeighted returns the index of a random entry of ids, chosen with a probability proportional to its weight
+func pickWeighted(rng *rand.Rand, ids []int32, weights []float64) int {
+	total := 0.0
+	for _, id := range ids {
+		total += weights[id]
+	}
+	r := rng.Float64() * total
+	for i, id := range ids {
+		r -= weights[id]
+		if r < 0 {
+			return i
+		}
+	}
+	// rounding can leave r a hair above zero
+	return len(ids) - 1
+}
@@ -0,0 +1,87 @@
+package topologicalsort
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSampleTopologicalOrder(t *testing.T) {
+	graph := NewGraphWithOptions[string]()
+	for _, k := range []string{"a", "b", "c", "d"} {
+		if err := graph.RegisterVertex(k, k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// d depends on b and c, which both depend on a: the only valid orders are a b c d and a c b d
+	edges := []Edge{{Source: "b", Dest: "a"}, {Source: "c", Dest: "a"}, {Source: "d", Dest: "b"}, {Source: "d", Dest: "c"}}
+	if err := graph.AddEdges(edges...); err != nil {
+		t.Fatal(err)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	seen := make(map[string]int)
+	for i := 0; i < 200; i++ {
+		order, err := graph.SampleTopologicalOrder(rng)
+		if err != nil {
+			t.Fatalf("SampleTopologicalOrder returned unexpected error: %v", err)
+		}
+		if comparison, err := graph.CompareOrders(order, order); err != nil || !comparison.AValid() {
+			t.Fatalf("SampleTopologicalOrder returned invalid order %v", order)
+		}
+		seen[strings.Join(order, " ")]++
+	}
+	if len(seen) != 2 {
+		t.Errorf("SampleTopologicalOrder produced orders %v, want both valid orders", seen)
+	}
+
+	// the same seed gives the same order
+	first, _ := graph.SampleTopologicalOrder(rand.New(rand.NewSource(42)))
+	second, _ := graph.SampleTopologicalOrder(rand.New(rand.NewSource(42)))
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("SampleTopologicalOrder gave %v and %v for the same seed", first, second)
+	}
+}
+
+func TestSampleTopologicalOrderWeighted(t *testing.T) {
+	graph := NewGraphWithOptions[int]()
+	for i, k := range []string{"light", "heavy"} {
+		if err := graph.RegisterVertex(k, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	weight := func(n *GraphNode[int]) float64 { return float64(1 + 99*n.Data) }
+
+	rng := rand.New(rand.NewSource(1))
+	heavyFirst := 0
+	for i := 0; i < 200; i++ {
+		order, err := graph.SampleTopologicalOrderWeighted(rng, weight)
+		if err != nil {
+			t.Fatalf("SampleTopologicalOrderWeighted returned unexpected error: %v", err)
+		}
+		if order[0] == "heavy" {
+			heavyFirst++
+		}
+	}
+	// heavy should come first about 99% of the time
+	if heavyFirst < 180 {
+		t.Errorf("heavy came first %d times out of 200, want nearly always", heavyFirst)
+	}
+}
+
+func TestSampleTopologicalOrderCycle(t *testing.T) {
+	graph := NewGraphWithOptions[string]()
+	for _, k := range []string{"a", "b", "c"} {
+		if err := graph.RegisterVertex(k, k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := graph.AddEdges(Edge{Source: "a", Dest: "b"}, Edge{Source: "b", Dest: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := graph.SampleTopologicalOrder(rand.New(rand.NewSource(1))); !errors.Is(err, ErrCycle) {
+		t.Errorf("SampleTopologicalOrder returned %v, want an error wrapping ErrCycle", err)
+	}
+}
@@ -0,0 +1,194 @@
+// Package sbom builds dependency graphs of components from software bills of materials, in CycloneDX or SPDX JSON format.
+package sbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// Component is a component (CycloneDX) or package (SPDX) of an SBOM, used as vertex Data
+type Component struct {
+	// Ref is the component's bom-ref (CycloneDX) or SPDXID (SPDX), which is also its vertex key
+	Ref     string
+	Name    string
+	Version string
+	PURL    string
+}
+
+type cycloneDXComponent struct {
+	BOMRef     string               `json:"bom-ref"`
+	Name       string               `json:"name"`
+	Version    string               `json:"version"`
+	PURL       string               `json:"purl"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDX struct {
+	BOMFormat string `json:"bomFormat"`
+	Metadata  struct {
+		Component *cycloneDXComponent `json:"component"`
+	} `json:"metadata"`
+	Components   []cycloneDXComponent `json:"components"`
+	Dependencies []struct {
+		Ref       string   `json:"ref"`
+		DependsOn []string `json:"dependsOn"`
+	} `json:"dependencies"`
+}
+
+type spdx struct {
+	SPDXVersion string `json:"spdxVersion"`
+	Packages    []struct {
+		SPDXID       string `json:"SPDXID"`
+		Name         string `json:"name"`
+		VersionInfo  string `json:"versionInfo"`
+		ExternalRefs []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+	Relationships []struct {
+		SPDXElementID      string `json:"spdxElementId"`
+		RelationshipType   string `json:"relationshipType"`
+		RelatedSPDXElement string `json:"relatedSpdxElement"`
+	} `json:"relationships"`
+}
+
+// Read builds a graph from a CycloneDX or SPDX JSON document, telling them apart by their "bomFormat" and "spdxVersion" fields
+func Read(r io.Reader) (*topologicalsort.Graph[Component], error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SBOM: %w", err)
+	}
+	var format struct {
+		BOMFormat   string `json:"bomFormat"`
+		SPDXVersion string `json:"spdxVersion"`
+	}
+	if err := json.Unmarshal(raw, &format); err != nil {
+		return nil, fmt.Errorf("failed to decode SBOM: %w", err)
+	}
+	switch {
+	case format.BOMFormat == "CycloneDX":
+		return ReadCycloneDX(bytes.NewReader(raw))
+	case format.SPDXVersion != "":
+		return ReadSPDX(bytes.NewReader(raw))
+	}
+	return nil, fmt.Errorf("unrecognized SBOM format: expected CycloneDX or SPDX JSON")
+}
+
+// ReadCycloneDX builds a graph from a CycloneDX JSON document, with one vertex per component (including the metadata component and nested components)
+// keyed by its bom-ref, depending on the components listed in its "dependencies" entry
+func ReadCycloneDX(r io.Reader) (*topologicalsort.Graph[Component], error) {
+	var bom cycloneDX
+	if err := json.NewDecoder(r).Decode(&bom); err != nil {
+		return nil, fmt.Errorf("failed to decode CycloneDX document: %w", err)
+	}
+
+	graph := newGraph()
+	var register func(components []cycloneDXComponent) error
+	register = func(components []cycloneDXComponent) error {
+		for _, c := range components {
+			if c.BOMRef != "" {
+				err := graph.RegisterVertex(c.BOMRef, Component{Ref: c.BOMRef, Name: c.Name, Version: c.Version, PURL: c.PURL})
+				if err != nil {
+					return fmt.Errorf("component %s: %w", c.BOMRef, err)
+				}
+			}
+			if err := register(c.Components); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if bom.Metadata.Component != nil {
+		if err := register([]cycloneDXComponent{*bom.Metadata.Component}); err != nil {
+			return nil, err
+		}
+	}
+	if err := register(bom.Components); err != nil {
+		return nil, err
+	}
+
+	for _, d := range bom.Dependencies {
+		for _, dep := range d.DependsOn {
+			if err := addDependency(graph, d.Ref, dep); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return graph, nil
+}
+
+// ReadSPDX builds a graph from an SPDX 2.x JSON document, with one vertex per package keyed by its SPDXID.
+// DEPENDS_ON relationships and the *DEPENDENCY_OF relationships (DEV_DEPENDENCY_OF, BUILD_DEPENDENCY_OF, ...) become edges;
+// other relationships, and relationships with elements which aren't packages, are ignored.
+func ReadSPDX(r io.Reader) (*topologicalsort.Graph[Component], error) {
+	var doc spdx
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode SPDX document: %w", err)
+	}
+
+	graph := newGraph()
+	for _, p := range doc.Packages {
+		c := Component{Ref: p.SPDXID, Name: p.Name, Version: p.VersionInfo}
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				c.PURL = ref.ReferenceLocator
+			}
+		}
+		if err := graph.RegisterVertex(p.SPDXID, c); err != nil {
+			return nil, fmt.Errorf("package %s: %w", p.SPDXID, err)
+		}
+	}
+
+	for _, rel := range doc.Relationships {
+		source, dest := rel.SPDXElementID, rel.RelatedSPDXElement
+		switch {
+		case rel.RelationshipType == "DEPENDS_ON":
+		case strings.HasSuffix(rel.RelationshipType, "DEPENDENCY_OF"):
+			source, dest = dest, source
+		default:
+			continue
+		}
+		if !graph.HasVertex(source) || !graph.HasVertex(dest) {
+			continue
+		}
+		if err := addDependency(graph, source, dest); err != nil {
+			return nil, err
+		}
+	}
+	return graph, nil
+}
+
+// AffectedBy returns the sorted refs of every component which depends on ref, directly or transitively: the blast radius of a vulnerability in ref
+func AffectedBy(graph *topologicalsort.Graph[Component], ref string) ([]string, error) {
+	groups, err := graph.Ancestors(ref, 0)
+	if err != nil {
+		return []string{}, err
+	}
+	affected := make([]string, 0)
+	for _, group := range groups {
+		affected = append(affected, group...)
+	}
+	sort.Strings(affected)
+	return affected, nil
+}
+
+func newGraph() *topologicalsort.Graph[Component] {
+	return topologicalsort.NewGraphWithOptions[Component](
+		topologicalsort.WithSelfLoops(topologicalsort.IgnoreSelfLoops),
+		topologicalsort.WithDuplicateEdges(topologicalsort.IgnoreDuplicates),
+	)
+}
+
+func addDependency(graph *topologicalsort.Graph[Component], source, dest string) error {
+	if err := graph.AddEdge(source, dest); err != nil {
+		return fmt.Errorf("dependency %s -> %s: %w", source, dest, err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package sbom
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+const cycloneDXDocument = `{
+	"bomFormat": "CycloneDX",
+	"specVersion": "1.5",
+	"metadata": {"component": {"bom-ref": "app", "name": "app", "version": "1.0.0"}},
+	"components": [
+		{"bom-ref": "pkg:golang/example.com/web@v1.2.0", "name": "web", "version": "v1.2.0", "purl": "pkg:golang/example.com/web@v1.2.0",
+			"components": [{"bom-ref": "web-router", "name": "router"}]},
+		{"bom-ref": "pkg:golang/example.com/log@v0.3.0", "name": "log", "version": "v0.3.0"}
+	],
+	"dependencies": [
+		{"ref": "app", "dependsOn": ["pkg:golang/example.com/web@v1.2.0"]},
+		{"ref": "pkg:golang/example.com/web@v1.2.0", "dependsOn": ["pkg:golang/example.com/log@v0.3.0", "web-router"]},
+		{"ref": "pkg:golang/example.com/log@v0.3.0"}
+	]
+}`
+
+const spdxDocument = `{
+	"spdxVersion": "SPDX-2.3",
+	"packages": [
+		{"SPDXID": "SPDXRef-app", "name": "app", "versionInfo": "1.0.0"},
+		{"SPDXID": "SPDXRef-web", "name": "web", "versionInfo": "v1.2.0",
+			"externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:golang/example.com/web@v1.2.0"}]},
+		{"SPDXID": "SPDXRef-log", "name": "log", "versionInfo": "v0.3.0"}
+	],
+	"relationships": [
+		{"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-app"},
+		{"spdxElementId": "SPDXRef-app", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-web"},
+		{"spdxElementId": "SPDXRef-log", "relationshipType": "DEV_DEPENDENCY_OF", "relatedSpdxElement": "SPDXRef-web"},
+		{"spdxElementId": "SPDXRef-web", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "NOASSERTION"}
+	]
+}`
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantEdges []topologicalsort.Edge
+	}{
+		{
+			name:  "CycloneDX",
+			input: cycloneDXDocument,
+			wantEdges: []topologicalsort.Edge{
+				{Source: "app", Dest: "pkg:golang/example.com/web@v1.2.0"},
+				{Source: "pkg:golang/example.com/web@v1.2.0", Dest: "pkg:golang/example.com/log@v0.3.0"},
+				{Source: "pkg:golang/example.com/web@v1.2.0", Dest: "web-router"},
+			},
+		},
+		{
+			name:  "SPDX",
+			input: spdxDocument,
+			wantEdges: []topologicalsort.Edge{
+				{Source: "SPDXRef-app", Dest: "SPDXRef-web"},
+				{Source: "SPDXRef-web", Dest: "SPDXRef-log"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph, err := Read(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Read() unexpected error %v", err)
+			}
+			if got := graph.Edges(); !reflect.DeepEqual(got, tt.wantEdges) {
+				t.Errorf("Read() edges = %v, want %v", got, tt.wantEdges)
+			}
+		})
+	}
+}
+
+func TestRead_Components(t *testing.T) {
+	graph, err := Read(strings.NewReader(spdxDocument))
+	if err != nil {
+		t.Fatalf("Read() unexpected error %v", err)
+	}
+	web, _ := graph.Vertex("SPDXRef-web")
+	want := Component{Ref: "SPDXRef-web", Name: "web", Version: "v1.2.0", PURL: "pkg:golang/example.com/web@v1.2.0"}
+	if web.Data != want {
+		t.Errorf("Read() component = %+v, want %+v", web.Data, want)
+	}
+
+	if _, err := Read(strings.NewReader(`{"name": "not an sbom"}`)); err == nil {
+		t.Errorf("Read() expected an error for an unrecognized format")
+	}
+}
+
+func TestAffectedBy(t *testing.T) {
+	graph, err := ReadCycloneDX(strings.NewReader(cycloneDXDocument))
+	if err != nil {
+		t.Fatalf("ReadCycloneDX() unexpected error %v", err)
+	}
+	got, err := AffectedBy(graph, "pkg:golang/example.com/log@v0.3.0")
+	if err != nil {
+		t.Fatalf("AffectedBy() unexpected error %v", err)
+	}
+	if want := []string{"app", "pkg:golang/example.com/web@v1.2.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AffectedBy() = %v, want %v", got, want)
+	}
+	if _, err := AffectedBy(graph, "missing"); err == nil {
+		t.Errorf("AffectedBy() expected an error for an unknown component")
+	}
+}
@@ -0,0 +1,76 @@
+package topologicalsort
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Scheduler hands out vertices as soon as all of their dependencies are done, for external executors which pull work as it becomes available.
+// It's safe for concurrent use. Like [Progress], it captures the graph's structure when it's created.
+type Scheduler[T any] struct {
+	mu         sync.Mutex
+	graph      *Graph[T]
+	progress   *Progress[T]
+	dispatched map[string]bool
+	// pending holds the vertices which became ready but haven't been handed out yet
+	pending []string
+}
+
+// NewScheduler returns a scheduler for the graph, with nothing done yet. It returns an error if the graph contains a cycle.
+func (g *Graph[T]) NewScheduler() (*Scheduler[T], error) {
+	progress, err := g.NewProgress()
+	if err != nil {
+		return nil, err
+	}
+	return &Scheduler[T]{
+		graph:      g,
+		progress:   progress,
+		dispatched: make(map[string]bool),
+		pending:    progress.Ready(),
+	}, nil
+}
+
+// Next returns the vertices whose dependencies are all done and which haven't been handed out yet, sorted by key.
+// An empty result means that everything is either finished or waiting for work in flight.
+func (s *Scheduler[T]) Next() []*GraphNode[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sort.Strings(s.pending)
+	next := make([]*GraphNode[T], len(s.pending))
+	for i, k := range s.pending {
+		s.dispatched[k] = true
+		next[i] = s.graph.vertex(k)
+	}
+	s.pending = s.pending[:0]
+	return next
+}
+
+// Done marks a vertex handed out by [Scheduler.Next] as completed, which may make its dependents available
+func (s *Scheduler[T]) Done(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key = s.graph.canonicalKey(key)
+	if !s.dispatched[key] {
+		return fmt.Errorf("vertex %s was never handed out by the scheduler", key)
+	}
+	newlyReady, err := s.progress.markDone(key)
+	s.pending = append(s.pending, newlyReady...)
+	return err
+}
+
+// InFlight returns the number of vertices which have been handed out but aren't done yet
+func (s *Scheduler[T]) InFlight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.dispatched) - len(s.progress.done)
+}
+
+// Finished reports whether every vertex is done
+func (s *Scheduler[T]) Finished() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.progress.Finished()
+}
@@ -0,0 +1,79 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func nodeKeys[T any](nodes []*GraphNode[T]) []string {
+	keys := make([]string, len(nodes))
+	for i, n := range nodes {
+		keys[i] = n.Key
+	}
+	return keys
+}
+
+func TestScheduler(t *testing.T) {
+	s, err := progressTestGraph().NewScheduler()
+	if err != nil {
+		t.Fatalf("Graph.NewScheduler() unexpected error %v", err)
+	}
+
+	if got, want := nodeKeys(s.Next()), []string{"libc", "make"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Scheduler.Next() = %v, want %v", got, want)
+	}
+	if got := s.Next(); len(got) != 0 {
+		t.Errorf("Scheduler.Next() = %v, want nothing until work is done", nodeKeys(got))
+	}
+	if s.InFlight() != 2 {
+		t.Errorf("Scheduler.InFlight() = %d, want 2", s.InFlight())
+	}
+	if err := s.Done("gcc"); err == nil {
+		t.Errorf("Scheduler.Done() expected an error for a vertex which wasn't handed out")
+	}
+
+	s.Done("libc")
+	if got, want := nodeKeys(s.Next()), []string{"gcc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Scheduler.Next() = %v, want %v", got, want)
+	}
+	s.Done("make")
+	s.Done("gcc")
+	if got, want := nodeKeys(s.Next()), []string{"build-essential"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Scheduler.Next() = %v, want %v", got, want)
+	}
+	s.Done("build-essential")
+	if !s.Finished() || s.InFlight() != 0 {
+		t.Errorf("Scheduler.Finished() = false after everything is done")
+	}
+}
+
+func TestScheduler_Concurrent(t *testing.T) {
+	g := progressTestGraph()
+	s, _ := g.NewScheduler()
+
+	var mu sync.Mutex
+	finished := make(map[string]bool)
+	for !s.Finished() {
+		var wg sync.WaitGroup
+		for _, n := range s.Next() {
+			wg.Add(1)
+			go func(n *GraphNode[string]) {
+				defer wg.Done()
+				mu.Lock()
+				for _, d := range g.dependencyNodes(n.Key) {
+					if !finished[d.Key] {
+						t.Errorf("%s started before its dependency %s finished", n.Key, d.Key)
+					}
+				}
+				finished[n.Key] = true
+				mu.Unlock()
+				s.Done(n.Key)
+			}(n)
+		}
+		wg.Wait()
+	}
+	if len(finished) != 4 {
+		t.Errorf("ran %d vertices, want 4", len(finished))
+	}
+}
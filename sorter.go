@@ -0,0 +1,52 @@
+package topologicalsort
+
+import "time"
+
+// Sorter sorts graphs while reusing its memory between calls, for hot paths which sort the same graph (or graphs of a similar size) over and over.
+// Once it has sorted a graph of a given size, sorting a graph of that size again doesn't allocate (unless the graph contains a cycle, or uses [WithSelfCheck], [PinFirst] or [PinLast]).
+// A Sorter must not be used by several goroutines at once; use one per goroutine, or a [sync.Pool] of them.
+type Sorter[T any] struct {
+	scratch dfsScratch
+	keys    []string
+}
+
+// NewSorter returns an empty Sorter; it grows to fit the graphs it sorts
+func NewSorter[T any]() *Sorter[T] {
+	return &Sorter[T]{}
+}
+
+// Sort sorts g like [Graph.TopologicalSort], except that it doesn't emit spans, and that the returned slice belongs to the Sorter:
+// it is only valid until the next call, so copy it if you need to keep it. [Graph.SortedKeys] and [Graph.SortedValues] work as usual afterwards.
+func (s *Sorter[T]) Sort(g *Graph[T]) ([]string, error) {
+	start := time.Now()
+	keys, err := s.sort(g)
+	g.observeSort(start, err)
+	return keys, err
+}
+
+func (s *Sorter[T]) sort(g *Graph[T]) ([]string, error) {
+	s.scratch.reset(len(g.nodes))
+	g.topoSortedOrder = g.topoSortedOrder[:0]
+	s.keys = s.keys[:0]
+	g.prepareTraversal()
+	for i := range g.nodes {
+		var err error
+		g.topoSortedOrder, err = g.depthFirstOrder(g.dfsRoot(i), &s.scratch, g.topoSortedOrder)
+		if err != nil {
+			g.topoSortedOrder = g.topoSortedOrder[:0]
+			return s.keys, err
+		}
+	}
+	if err := g.applyPins(); err != nil {
+		g.topoSortedOrder = g.topoSortedOrder[:0]
+		return s.keys, err
+	}
+	if err := g.selfCheck(true); err != nil {
+		return s.keys, err
+	}
+
+	for _, node := range g.topoSortedOrder {
+		s.keys = append(s.keys, node.Key)
+	}
+	return s.keys, nil
+}
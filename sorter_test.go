@@ -0,0 +1,56 @@
+package topologicalsort
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSorter_Sort(t *testing.T) {
+	s := NewSorter[string]()
+	graph := graphWithVerticesDUMMYDATA(map[string][]string{
+		"build-essential": {"make", "gcc"},
+		"gcc":             {"libc"},
+		"make":            {},
+		"libc":            {},
+	}, "")
+
+	// sorting several times reuses the Sorter's memory, and always gives a valid order
+	for i := 0; i < 3; i++ {
+		got, err := s.Sort(graph)
+		if err != nil {
+			t.Fatalf("Sorter.Sort() unexpected error %v", err)
+		}
+		position := make(map[string]int)
+		for i, k := range got {
+			position[k] = i
+		}
+		if len(got) != 4 || position["libc"] > position["gcc"] || position["gcc"] > position["build-essential"] || position["make"] > position["build-essential"] {
+			t.Errorf("Sorter.Sort() = %v, which isn't a valid order", got)
+		}
+		if keys := graph.SortedKeys(); len(keys) != 4 || keys[3] != got[3] {
+			t.Errorf("Graph.SortedKeys() = %v after Sorter.Sort() = %v", keys, got)
+		}
+	}
+
+	graph.AddEdge("libc", "build-essential")
+	if _, err := s.Sort(graph); !errors.Is(err, ErrCycle) {
+		t.Errorf("Sorter.Sort() error = %v, want ErrCycle", err)
+	}
+}
+
+func TestSorter_SortAllocations(t *testing.T) {
+	graph := NewGraphWithOptions[int]()
+	for i := 0; i < 100; i++ {
+		graph.RegisterVertex(string(rune('a'+i%26))+string(rune('a'+i/26)), i)
+	}
+	keys := graph.sortedVertexKeys()
+	for i := 1; i < len(keys); i++ {
+		graph.AddEdge(keys[i], keys[i-1])
+	}
+
+	s := NewSorter[int]()
+	s.Sort(graph)
+	if allocs := testing.AllocsPerRun(10, func() { s.Sort(graph) }); allocs != 0 {
+		t.Errorf("Sorter.Sort() allocated %v times per run, want 0", allocs)
+	}
+}
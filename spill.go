@@ -0,0 +1,465 @@
+package topologicalsort
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// SortBackendExternal sorts the graph in b like [SortBackend], but for graphs whose per-vertex state doesn't fit into memory either:
+// it holds at most memoryBudget records (vertex keys or edges) in memory at a time, and spills everything else to temporary files in dir
+// (the default temporary directory if dir is empty), which are removed before it returns.
+//
+// It runs Kahn's algorithm one level at a time over sorted files, so it's much slower than SortBackend, but it finishes on graphs SortBackend can't.
+// Vertices are passed to emit level by level, like [Graph.Levels], and in key order within a level.
+// It returns an error wrapping [ErrCycle] if some vertices are part of, or depend on, a cycle; the vertices before the cycle have been emitted by then.
+func SortBackendExternal(b Backend, memoryBudget int, dir string, emit func(key string) error) error {
+	if memoryBudget < 1 {
+		return fmt.Errorf("memory budget must be positive, got %d", memoryBudget)
+	}
+	dir, err := os.MkdirTemp(dir, "topologicalsort-spill-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	s := &spill{dir: dir, budget: memoryBudget}
+
+	// counts holds (key, number of dependencies left) and reverse holds (dependency, dependent), both sorted by their first field
+	counts, reverse := s.sorter(), s.sorter()
+	err = b.Vertices(func(key string) error {
+		deps, err := b.Dependencies(key)
+		if err != nil {
+			return err
+		}
+		if err := counts.add(spillRecord{key, strconv.Itoa(len(deps))}); err != nil {
+			return err
+		}
+		for _, dep := range deps {
+			if err := reverse.add(spillRecord{dep, key}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	countsFile, err := counts.finish()
+	if err != nil {
+		return err
+	}
+	reverseFile, err := reverse.finish()
+	if err != nil {
+		return err
+	}
+	if err := s.checkDependencies(countsFile, reverseFile); err != nil {
+		return err
+	}
+
+	// split off the vertices without dependencies
+	readyFile, countsFile, err := s.updateCounts(countsFile, "")
+	if err != nil {
+		return err
+	}
+	for {
+		emitted, err := s.emit(readyFile, emit)
+		if err != nil {
+			return err
+		}
+		if emitted == 0 {
+			break
+		}
+		decrements, err := s.dependentsOf(readyFile, reverseFile)
+		if err != nil {
+			return err
+		}
+		// the previous level's files aren't needed anymore, so don't let them pile up
+		previous := []string{readyFile, countsFile, decrements}
+		readyFile, countsFile, err = s.updateCounts(countsFile, decrements)
+		if err != nil {
+			return err
+		}
+		for _, name := range previous {
+			os.Remove(name)
+		}
+	}
+
+	stuck, err := s.count(countsFile)
+	if err != nil {
+		return err
+	}
+	if stuck > 0 {
+		return fmt.Errorf("%w: %d vertices are part of, or depend on, a cycle", ErrCycle, stuck)
+	}
+	return nil
+}
+
+// spillRecord is a pair of fields, sorted by the first field and then the second
+type spillRecord [2]string
+
+func (r spillRecord) less(other spillRecord) bool {
+	if r[0] != other[0] {
+		return r[0] < other[0]
+	}
+	return r[1] < other[1]
+}
+
+// spill manages the temporary files of one external sort
+type spill struct {
+	dir    string
+	budget int
+	files  int
+}
+
+func (s *spill) newFile() (*os.File, error) {
+	s.files++
+	return os.Create(filepath.Join(s.dir, strconv.Itoa(s.files)))
+}
+
+// write writes records to a new file, calling fn with a function adding one record
+func (s *spill) write(fn func(add func(spillRecord) error) error) (string, error) {
+	f, err := s.newFile()
+	if err != nil {
+		return "", err
+	}
+	w := bufio.NewWriter(f)
+	err = fn(func(r spillRecord) error {
+		return writeSpillRecord(w, r)
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return f.Name(), err
+}
+
+// read calls fn for every record of a file (an empty name is an empty file)
+func (s *spill) read(name string, fn func(spillRecord) error) error {
+	r, err := openSpillReader(name)
+	if err != nil {
+		return err
+	}
+	defer r.close()
+	for {
+		record, ok, err := r.next()
+		if err != nil || !ok {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+// checkDependencies returns an error for the first dependency (in reverse) which isn't a vertex (in counts)
+func (s *spill) checkDependencies(countsFile, reverseFile string) error {
+	counts, err := openSpillReader(countsFile)
+	if err != nil {
+		return err
+	}
+	defer counts.close()
+	vertex, hasVertex, err := counts.next()
+	if err != nil {
+		return err
+	}
+	return s.read(reverseFile, func(edge spillRecord) error {
+		for hasVertex && vertex[0] < edge[0] {
+			if vertex, hasVertex, err = counts.next(); err != nil {
+				return err
+			}
+		}
+		if !hasVertex || vertex[0] != edge[0] {
+			return edgeError(edge[1], edge[0], edge[0], "vertex %s depends on unknown vertex %s", edge[1], edge[0])
+		}
+		return nil
+	})
+}
+
+// emit passes the keys of the ready file to fn, returning how many there were
+func (s *spill) emit(readyFile string, fn func(string) error) (int, error) {
+	n := 0
+	err := s.read(readyFile, func(r spillRecord) error {
+		n++
+		return fn(r[0])
+	})
+	return n, err
+}
+
+// dependentsOf returns a file of the dependents of the ready vertices (one record per edge, so a dependent can appear more than once), sorted by key
+func (s *spill) dependentsOf(readyFile, reverseFile string) (string, error) {
+	ready, err := openSpillReader(readyFile)
+	if err != nil {
+		return "", err
+	}
+	defer ready.close()
+	next, hasNext, err := ready.next()
+	if err != nil {
+		return "", err
+	}
+
+	dependents := s.sorter()
+	err = s.read(reverseFile, func(edge spillRecord) error {
+		for hasNext && next[0] < edge[0] {
+			if next, hasNext, err = ready.next(); err != nil {
+				return err
+			}
+		}
+		if hasNext && next[0] == edge[0] {
+			return dependents.add(spillRecord{edge[1], ""})
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return dependents.finish()
+}
+
+// updateCounts subtracts the decrements (a sorted file of keys, one record per decrement) from the counts, returning a file of the vertices
+// which are now ready and a file of the counts of the remaining vertices
+func (s *spill) updateCounts(countsFile, decrementsFile string) (string, string, error) {
+	decrements, err := openSpillReader(decrementsFile)
+	if err != nil {
+		return "", "", err
+	}
+	defer decrements.close()
+	next, hasNext, err := decrements.next()
+	if err != nil {
+		return "", "", err
+	}
+
+	var remainingFile string
+	readyFile, err := s.write(func(addReady func(spillRecord) error) error {
+		remainingFile, err = s.write(func(addRemaining func(spillRecord) error) error {
+			return s.read(countsFile, func(r spillRecord) error {
+				count, err := strconv.Atoi(r[1])
+				if err != nil {
+					return err
+				}
+				for hasNext && next[0] <= r[0] {
+					if next[0] == r[0] {
+						count--
+					}
+					if next, hasNext, err = decrements.next(); err != nil {
+						return err
+					}
+				}
+				if count == 0 {
+					return addReady(spillRecord{r[0], ""})
+				}
+				return addRemaining(spillRecord{r[0], strconv.Itoa(count)})
+			})
+		})
+		return err
+	})
+	return readyFile, remainingFile, err
+}
+
+func (s *spill) count(name string) (int, error) {
+	n := 0
+	err := s.read(name, func(spillRecord) error {
+		n++
+		return nil
+	})
+	return n, err
+}
+
+// spillSorter sorts any number of records, keeping at most the spill's budget in memory and writing the rest to sorted runs
+type spillSorter struct {
+	spill   *spill
+	records []spillRecord
+	runs    []string
+}
+
+func (s *spill) sorter() *spillSorter {
+	return &spillSorter{spill: s}
+}
+
+func (s *spillSorter) add(r spillRecord) error {
+	s.records = append(s.records, r)
+	if len(s.records) >= s.spill.budget {
+		return s.flush()
+	}
+	return nil
+}
+
+func (s *spillSorter) flush() error {
+	if len(s.records) == 0 {
+		return nil
+	}
+	sort.Slice(s.records, func(i, j int) bool { return s.records[i].less(s.records[j]) })
+	run, err := s.spill.write(func(add func(spillRecord) error) error {
+		for _, r := range s.records {
+			if err := add(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	s.records = s.records[:0]
+	s.runs = append(s.runs, run)
+	return err
+}
+
+// spillFanIn is the most runs merged at once, which bounds the number of files open at a time during a merge
+var spillFanIn = 64
+
+// finish merges the sorted runs into a single sorted file, and returns its name.
+// With more runs than [spillFanIn], it merges them in several passes, spillFanIn runs at a time.
+func (s *spillSorter) finish() (string, error) {
+	if err := s.flush(); err != nil {
+		return "", err
+	}
+	runs := s.runs
+	s.runs = nil
+	for len(runs) > spillFanIn {
+		merged := make([]string, 0, (len(runs)+spillFanIn-1)/spillFanIn)
+		for start := 0; start < len(runs); start += spillFanIn {
+			run, err := s.spill.merge(runs[start:min(start+spillFanIn, len(runs))])
+			if err != nil {
+				removeSpillFiles(runs[start:])
+				removeSpillFiles(merged)
+				return "", err
+			}
+			merged = append(merged, run)
+		}
+		runs = merged
+	}
+	return s.spill.merge(runs)
+}
+
+// merge merges sorted runs into a single sorted file, and returns its name. The runs are removed, whether or not merging succeeds.
+func (s *spill) merge(runs []string) (string, error) {
+	defer removeSpillFiles(runs)
+	readers := make(spillMerge, 0, len(runs))
+	defer func() {
+		for _, r := range readers {
+			r.close()
+		}
+	}()
+	for _, run := range runs {
+		r, err := openSpillReader(run)
+		if err != nil {
+			return "", err
+		}
+		if r.head, r.ok, err = r.next(); err != nil {
+			r.close()
+			return "", err
+		}
+		if r.ok {
+			readers = append(readers, r)
+		} else {
+			r.close()
+		}
+	}
+	heap.Init(&readers)
+
+	return s.write(func(add func(spillRecord) error) error {
+		for readers.Len() > 0 {
+			r := readers[0]
+			if err := add(r.head); err != nil {
+				return err
+			}
+			var err error
+			if r.head, r.ok, err = r.next(); err != nil {
+				return err
+			}
+			if r.ok {
+				heap.Fix(&readers, 0)
+			} else {
+				heap.Pop(&readers).(*spillReader).close()
+			}
+		}
+		return nil
+	})
+}
+
+// removeSpillFiles removes temporary files which aren't needed anymore
+func removeSpillFiles(names []string) {
+	for _, name := range names {
+		os.Remove(name)
+	}
+}
+
+// spillMerge is a heap of run readers, ordered by their next record
+type spillMerge []*spillReader
+
+func (m spillMerge) Len() int           { return len(m) }
+func (m spillMerge) Less(i, j int) bool { return m[i].head.less(m[j].head) }
+func (m spillMerge) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+func (m *spillMerge) Push(x any)        { *m = append(*m, x.(*spillReader)) }
+func (m *spillMerge) Pop() any {
+	old := *m
+	r := old[len(old)-1]
+	*m = old[:len(old)-1]
+	return r
+}
+
+type spillReader struct {
+	f    *os.File
+	r    *bufio.Reader
+	head spillRecord
+	ok   bool
+}
+
+func openSpillReader(name string) (*spillReader, error) {
+	if name == "" {
+		return &spillReader{}, nil
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &spillReader{f: f, r: bufio.NewReader(f)}, nil
+}
+
+func (r *spillReader) next() (spillRecord, bool, error) {
+	var record spillRecord
+	if r.f == nil {
+		return record, false, nil
+	}
+	for i := range record {
+		n, err := binary.ReadUvarint(r.r)
+		if err == io.EOF && i == 0 {
+			return record, false, nil
+		}
+		if err != nil {
+			return record, false, err
+		}
+		field := make([]byte, n)
+		if _, err := io.ReadFull(r.r, field); err != nil {
+			return record, false, err
+		}
+		record[i] = string(field)
+	}
+	return record, true, nil
+}
+
+func (r *spillReader) close() {
+	if r.f != nil {
+		r.f.Close()
+	}
+}
+
+// writeSpillRecord writes both fields of a record, length-prefixed so keys can contain any bytes
+func writeSpillRecord(w *bufio.Writer, r spillRecord) error {
+	var buf [binary.MaxVarintLen64]byte
+	for _, field := range r {
+		n := binary.PutUvarint(buf[:], uint64(len(field)))
+		if _, err := w.Write(buf[:n]); err != nil {
+			return err
+		}
+		if _, err := w.WriteString(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
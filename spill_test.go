@@ -0,0 +1,157 @@
+package topologicalsort
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestSortBackendExternal(t *testing.T) {
+	// a random DAG, sorted with a budget small enough to spill almost everything
+	rng := rand.New(rand.NewSource(1))
+	graph := NewGraphWithOptions[string]()
+	for i := 0; i < 200; i++ {
+		graph.RegisterVertex(fmt.Sprintf("v%03d", i), "")
+		for j := 0; j < 3 && i > 0; j++ {
+			graph.AddDependency(fmt.Sprintf("v%03d", i), fmt.Sprintf("v%03d", rng.Intn(i)))
+		}
+	}
+	levels, err := graph.Levels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := make([]string, 0)
+	for _, level := range levels {
+		want = append(want, level...)
+	}
+
+	dir := t.TempDir()
+	for _, budget := range []int{1, 7, 10000} {
+		got := make([]string, 0)
+		err := SortBackendExternal(BackendOf(graph), budget, dir, func(key string) error {
+			got = append(got, key)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("SortBackendExternal() with budget %d unexpected error %v", budget, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("SortBackendExternal() with budget %d = %v, want %v", budget, got, want)
+		}
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("SortBackendExternal() left %d files behind", len(entries))
+	}
+}
+
+func TestSortBackendExternalErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		backend   *mapBackend
+		budget    int
+		wantCycle bool
+		wantOrder []string
+	}{
+		{
+			name: "Cycle",
+			backend: &mapBackend{
+				keys: []string{"a", "b", "c", "d"},
+				deps: map[string][]string{"b": {"a", "c"}, "c": {"b"}, "d": {"c"}},
+			},
+			budget:    2,
+			wantCycle: true,
+			wantOrder: []string{"a"},
+		},
+		{
+			name: "Unknown dependency",
+			backend: &mapBackend{
+				keys: []string{"a"},
+				deps: map[string][]string{"a": {"b"}},
+			},
+			budget:    2,
+			wantOrder: []string{},
+		},
+		{
+			name:      "Invalid budget",
+			backend:   &mapBackend{},
+			wantOrder: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			err := SortBackendExternal(tt.backend, tt.budget, t.TempDir(), func(key string) error {
+				got = append(got, key)
+				return nil
+			})
+			if err == nil || errors.Is(err, ErrCycle) != tt.wantCycle {
+				t.Errorf("SortBackendExternal() error = %v, wantCycle %v", err, tt.wantCycle)
+			}
+			if !reflect.DeepEqual(got, tt.wantOrder) {
+				t.Errorf("SortBackendExternal() emitted %v before failing, want %v", got, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestSpillSorter_MultiPassMerge(t *testing.T) {
+	// merging at most 3 runs at a time, with a run per record, takes several passes
+	defer func(fanIn int) { spillFanIn = fanIn }(spillFanIn)
+	spillFanIn = 3
+
+	dir := t.TempDir()
+	s := &spill{dir: dir, budget: 1}
+	sorter := s.sorter()
+	rng := rand.New(rand.NewSource(1))
+	want := make([]spillRecord, 0)
+	for i := 0; i < 50; i++ {
+		r := spillRecord{fmt.Sprintf("k%02d", rng.Intn(30)), fmt.Sprintf("v%02d", i)}
+		want = append(want, r)
+		if err := sorter.add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(sorter.runs) <= spillFanIn*spillFanIn {
+		t.Fatalf("test made %d runs, want more than two passes' worth", len(sorter.runs))
+	}
+	sort.Slice(want, func(i, j int) bool { return want[i].less(want[j]) })
+
+	merged, err := sorter.finish()
+	if err != nil {
+		t.Fatalf("spillSorter.finish() unexpected error %v", err)
+	}
+	got := make([]spillRecord, 0)
+	if err := s.read(merged, func(r spillRecord) error { got = append(got, r); return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("spillSorter.finish() = %v, want %v", got, want)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("spillSorter.finish() left %d files, want only the merged one", len(entries))
+	}
+
+	// a whole external sort, with more runs per merge than the fan-in
+	graph := NewGraphWithOptions[string]()
+	for i := 0; i < 40; i++ {
+		graph.RegisterVertex(fmt.Sprintf("v%02d", i), "")
+		if i > 0 {
+			graph.AddDependency(fmt.Sprintf("v%02d", i), fmt.Sprintf("v%02d", rng.Intn(i)))
+		}
+	}
+	levels, _ := graph.Levels()
+	wantOrder := slices.Concat(levels...)
+	gotOrder := make([]string, 0)
+	err = SortBackendExternal(BackendOf(graph), 1, t.TempDir(), func(key string) error {
+		gotOrder = append(gotOrder, key)
+		return nil
+	})
+	if err != nil || !reflect.DeepEqual(gotOrder, wantOrder) {
+		t.Errorf("SortBackendExternal() = %v, %v, want %v", gotOrder, err, wantOrder)
+	}
+}
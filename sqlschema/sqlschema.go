@@ -0,0 +1,97 @@
+// Package sqlschema orders database tables by their foreign keys, so rows can be inserted into
+// (or deleted from) every table without violating a constraint.
+package sqlschema
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// ForeignKey is a foreign key constraint from Table to ReferencedTable
+type ForeignKey struct {
+	Table           string
+	ReferencedTable string
+}
+
+// NewGraph returns a graph with one vertex per table (its Data is the table name), where every table depends on the tables it references.
+// Tables only mentioned in keys are added too. Self-referencing keys are ignored, since they don't constrain the order of tables.
+func NewGraph(tables []string, keys []ForeignKey) (*topologicalsort.Graph[string], error) {
+	graph := topologicalsort.NewGraphWithOptions[string](
+		topologicalsort.WithSelfLoops(topologicalsort.IgnoreSelfLoops),
+		topologicalsort.WithDuplicateVertices(topologicalsort.IgnoreDuplicates),
+		topologicalsort.WithDuplicateEdges(topologicalsort.IgnoreDuplicates),
+	)
+	for _, table := range tables {
+		graph.RegisterVertex(table, table)
+	}
+	for i, key := range keys {
+		if key.Table == "" || key.ReferencedTable == "" {
+			return nil, fmt.Errorf("foreign key %d has an empty table name", i)
+		}
+		graph.RegisterVertex(key.Table, key.Table)
+		graph.RegisterVertex(key.ReferencedTable, key.ReferencedTable)
+		if err := graph.AddEdge(key.Table, key.ReferencedTable); err != nil {
+			return nil, fmt.Errorf("foreign key %s -> %s: %w", key.Table, key.ReferencedTable, err)
+		}
+	}
+	return graph, nil
+}
+
+// InsertOrder returns the tables in an order which inserts into every table after the tables it references
+func InsertOrder(tables []string, keys []ForeignKey) ([]string, error) {
+	graph, err := NewGraph(tables, keys)
+	if err != nil {
+		return []string{}, err
+	}
+	return graph.TopologicalSort()
+}
+
+// DeleteOrder returns the tables in an order which deletes from (or truncates) every table before the tables it references; it's the reverse of InsertOrder
+func DeleteOrder(tables []string, keys []ForeignKey) ([]string, error) {
+	order, err := InsertOrder(tables, keys)
+	if err != nil {
+		return order, err
+	}
+	slices.Reverse(order)
+	return order, nil
+}
+
+// ReadForeignKeys reads foreign keys from CSV rows, like the output of a query against information_schema.
+// If the first row is a header with "table_name" and "referenced_table_name" columns, those columns are used
+// (and other columns are ignored); otherwise every row must start with the table and the referenced table.
+func ReadForeignKeys(r io.Reader) ([]ForeignKey, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	keys := []ForeignKey{}
+	tableColumn, referencedColumn := 0, 1
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return keys, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read foreign keys: %w", err)
+		}
+
+		if row == 1 {
+			table := slices.IndexFunc(record, func(column string) bool { return strings.EqualFold(column, "table_name") })
+			referenced := slices.IndexFunc(record, func(column string) bool { return strings.EqualFold(column, "referenced_table_name") })
+			if table >= 0 && referenced >= 0 {
+				tableColumn, referencedColumn = table, referenced
+				continue
+			}
+		}
+		if len(record) <= max(tableColumn, referencedColumn) {
+			return nil, fmt.Errorf("row %d: expected a table and a referenced table, got %d columns", row, len(record))
+		}
+		keys = append(keys, ForeignKey{Table: record[tableColumn], ReferencedTable: record[referencedColumn]})
+	}
+}
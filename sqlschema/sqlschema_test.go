@@ -0,0 +1,101 @@
+package sqlschema
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+var shopKeys = []ForeignKey{
+	{Table: "orders", ReferencedTable: "customers"},
+	{Table: "order_items", ReferencedTable: "orders"},
+	{Table: "order_items", ReferencedTable: "products"},
+	{Table: "employees", ReferencedTable: "employees"},
+}
+
+func TestInsertAndDeleteOrder(t *testing.T) {
+	tables := []string{"customers", "orders", "order_items", "products", "employees"}
+	insert, err := InsertOrder(tables, shopKeys)
+	if err != nil {
+		t.Fatalf("InsertOrder() unexpected error %v", err)
+	}
+	position := make(map[string]int)
+	for i, table := range insert {
+		position[table] = i
+	}
+	if len(insert) != len(tables) {
+		t.Fatalf("InsertOrder() = %v, want all %d tables", insert, len(tables))
+	}
+	for _, key := range shopKeys {
+		if key.Table != key.ReferencedTable && position[key.ReferencedTable] > position[key.Table] {
+			t.Errorf("InsertOrder() = %v, inserts into %s before %s", insert, key.Table, key.ReferencedTable)
+		}
+	}
+
+	remove, err := DeleteOrder(tables, shopKeys)
+	if err != nil {
+		t.Fatalf("DeleteOrder() unexpected error %v", err)
+	}
+	for i, table := range remove {
+		position[table] = i
+	}
+	for _, key := range shopKeys {
+		if key.Table != key.ReferencedTable && position[key.Table] > position[key.ReferencedTable] {
+			t.Errorf("DeleteOrder() = %v, deletes from %s before %s", remove, key.ReferencedTable, key.Table)
+		}
+	}
+}
+
+func TestInsertOrder_Cycle(t *testing.T) {
+	keys := []ForeignKey{{Table: "a", ReferencedTable: "b"}, {Table: "b", ReferencedTable: "a"}}
+	if _, err := InsertOrder(nil, keys); !errors.Is(err, topologicalsort.ErrCycle) {
+		t.Errorf("InsertOrder() error = %v, want ErrCycle", err)
+	}
+	if _, err := NewGraph(nil, []ForeignKey{{Table: "a"}}); err == nil {
+		t.Errorf("NewGraph() expected an error for an empty table name")
+	}
+}
+
+func TestReadForeignKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []ForeignKey
+		wantErr bool
+	}{
+		{
+			name:  "Header",
+			input: "constraint_name,table_name,referenced_table_name\nfk_orders,orders,customers\nfk_items,order_items,orders\n",
+			want:  []ForeignKey{{Table: "orders", ReferencedTable: "customers"}, {Table: "order_items", ReferencedTable: "orders"}},
+		},
+		{
+			name:  "No header",
+			input: "orders, customers\n",
+			want:  []ForeignKey{{Table: "orders", ReferencedTable: "customers"}},
+		},
+		{
+			name:  "Empty",
+			input: "",
+			want:  []ForeignKey{},
+		},
+		{
+			name:    "Missing columns",
+			input:   "orders\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadForeignKeys(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadForeignKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadForeignKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
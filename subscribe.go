@@ -0,0 +1,41 @@
+package topologicalsort
+
+// Event tells a subscriber about a change to the graph, see [Graph.Subscribe]
+type Event struct {
+	Mutation
+	// OrderInvalidated reports whether the change can change the topological order (a vertex or an edge was added, or vertices were merged),
+	// so orders, levels and other results computed before it are stale. Data updates don't invalidate the order.
+	OrderInvalidated bool
+}
+
+type subscriber struct {
+	id int
+	fn func(Event)
+}
+
+// Subscribe calls fn after every successful change to the graph, in the order subscribers were added, until the returned function is called.
+// fn runs synchronously, on the goroutine changing the graph, so it must not change the graph itself; hand the event to a channel to process it elsewhere.
+func (g *Graph[T]) Subscribe(fn func(Event)) (unsubscribe func()) {
+	g.nextSubscriberID++
+	id := g.nextSubscriberID
+	g.subscribers = append(g.subscribers, subscriber{id: id, fn: fn})
+	return func() {
+		for i, s := range g.subscribers {
+			if s.id == id {
+				g.subscribers = append(g.subscribers[:i:i], g.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// notify passes m on to every subscriber
+func (g *Graph[T]) notify(m Mutation) {
+	if len(g.subscribers) == 0 {
+		return
+	}
+	event := Event{Mutation: m, OrderInvalidated: m.Kind != MutationVertexUpdated}
+	for _, s := range g.subscribers {
+		s.fn(event)
+	}
+}
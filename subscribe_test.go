@@ -0,0 +1,48 @@
+package topologicalsort
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph_Subscribe(t *testing.T) {
+	g := NewGraphWithOptions[string]()
+	events := make([]Event, 0)
+	unsubscribe := g.Subscribe(func(e Event) {
+		if e.Time.IsZero() {
+			t.Errorf("Event %+v has no time", e)
+		}
+		events = append(events, e)
+	})
+	invalidations := 0
+	g.Subscribe(func(e Event) {
+		if e.OrderInvalidated {
+			invalidations++
+		}
+	})
+
+	g.RegisterVertex("gcc", "")
+	g.RegisterVertex("libc", "")
+	g.AddEdge("gcc", "libc")
+	// failed changes aren't events
+	g.AddEdge("gcc", "gcc")
+	g.SetVertexData("libc", "libc-data")
+	unsubscribe()
+	g.RegisterVertex("make", "")
+
+	kinds := make([]MutationKind, len(events))
+	for i, e := range events {
+		kinds[i] = e.Kind
+	}
+	want := []MutationKind{MutationVertexAdded, MutationVertexAdded, MutationEdgeAdded, MutationVertexUpdated}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("events = %v, want %v", kinds, want)
+	}
+	if events[2].Source != "gcc" || events[2].Dest != "libc" || !events[2].OrderInvalidated || events[3].OrderInvalidated {
+		t.Errorf("events = %+v", events)
+	}
+	// the second subscriber is still subscribed, so it saw the last vertex too
+	if invalidations != 4 {
+		t.Errorf("got %d order invalidations, want 4", invalidations)
+	}
+}
@@ -0,0 +1,72 @@
+package topologicalsort
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// TimelineEvent is the run of a single vertex in the timeline of a [Run]
+type TimelineEvent struct {
+	Key string `json:"key"`
+	// Worker is the number of the worker which ran the vertex, counting from 0. A vertex gets the lowest number not in use when it starts,
+	// so a run with [ExecuteConfig.Workers] set never uses more numbers than that.
+	Worker int       `json:"worker"`
+	Start  time.Time `json:"start"`
+	// End is zero while the vertex is still running
+	End      time.Time  `json:"end"`
+	Status   NodeStatus `json:"status"`
+	Attempts int        `json:"attempts"`
+	// Error is the error of the last attempt, if the vertex failed
+	Error string `json:"error,omitempty"`
+}
+
+// Timeline returns an event for every vertex the run has started, in the order they were started, for seeing how much actually ran in parallel.
+// Vertices which were skipped or never started aren't in it.
+func (r *Run[T]) Timeline() []TimelineEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	timeline := make([]TimelineEvent, len(r.timeline))
+	copy(timeline, r.timeline)
+	return timeline
+}
+
+// chromeTraceEvent is a complete ("X") event of the trace event format; times are in microseconds
+type chromeTraceEvent struct {
+	Name  string         `json:"name"`
+	Phase string         `json:"ph"`
+	Time  int64          `json:"ts"`
+	Dur   int64          `json:"dur"`
+	PID   int            `json:"pid"`
+	TID   int            `json:"tid"`
+	Args  map[string]any `json:"args"`
+}
+
+// WriteChromeTrace writes the timeline of the vertices which have finished to w in the trace event format, which chrome://tracing and Perfetto load.
+// Every worker is a thread of its own, and times count from the start of the run.
+func (r *Run[T]) WriteChromeTrace(w io.Writer) error {
+	events := make([]chromeTraceEvent, 0)
+	for _, e := range r.Timeline() {
+		if e.End.IsZero() {
+			continue
+		}
+		events = append(events, chromeTraceEvent{
+			Name:  e.Key,
+			Phase: "X",
+			Time:  e.Start.Sub(r.started).Microseconds(),
+			Dur:   e.End.Sub(e.Start).Microseconds(),
+			PID:   1,
+			TID:   e.Worker,
+			Args:  map[string]any{"status": e.Status.String(), "attempts": e.Attempts},
+		})
+	}
+	return json.NewEncoder(w).Encode(map[string]any{"traceEvents": events, "displayTimeUnit": "ms"})
+}
+
+// startEvent adds a timeline event for a vertex starting on a worker, and returns its index
+func (r *Run[T]) startEvent(key string, worker int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timeline = append(r.timeline, TimelineEvent{Key: key, Worker: worker, Start: time.Now()})
+	return len(r.timeline) - 1
+}
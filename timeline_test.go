@@ -0,0 +1,71 @@
+package topologicalsort
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRun_Timeline(t *testing.T) {
+	g := progressTestGraph()
+	g.RegisterVertex("zlib", "")
+	run, err := g.Start(context.Background(), func(ctx context.Context, node *GraphNode[string]) error {
+		time.Sleep(2 * time.Millisecond)
+		return nil
+	}, ExecuteConfig[string]{Workers: 2})
+	if err != nil {
+		t.Fatalf("Graph.Start() unexpected error %v", err)
+	}
+	run.Wait()
+
+	timeline := run.Timeline()
+	if len(timeline) != 5 {
+		t.Fatalf("Run.Timeline() = %v, want an event for each of the 5 vertices", timeline)
+	}
+	events := make(map[string]TimelineEvent)
+	for _, e := range timeline {
+		events[e.Key] = e
+		if e.Worker < 0 || e.Worker > 1 || e.Status != NodeSucceeded || e.Attempts != 1 || e.End.Before(e.Start) {
+			t.Errorf("Run.Timeline() event %+v, want a successful run on worker 0 or 1", e)
+		}
+	}
+	// a worker runs one vertex at a time, and a vertex starts after its dependencies ended
+	for _, a := range timeline {
+		for _, b := range timeline {
+			if a.Key != b.Key && a.Worker == b.Worker && a.Start.Before(b.End) && b.Start.Before(a.End) {
+				t.Errorf("Run.Timeline() has %s and %s overlapping on worker %d", a.Key, b.Key, a.Worker)
+			}
+		}
+	}
+	if events["gcc"].Start.Before(events["libc"].End) {
+		t.Errorf("Run.Timeline() starts gcc before libc ended")
+	}
+
+	var buf bytes.Buffer
+	if err := run.WriteChromeTrace(&buf); err != nil {
+		t.Fatalf("Run.WriteChromeTrace() unexpected error %v", err)
+	}
+	var trace struct {
+		TraceEvents []struct {
+			Name  string         `json:"name"`
+			Phase string         `json:"ph"`
+			Time  int64          `json:"ts"`
+			Dur   int64          `json:"dur"`
+			TID   int            `json:"tid"`
+			Args  map[string]any `json:"args"`
+		} `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatalf("Run.WriteChromeTrace() wrote invalid JSON %s: %v", buf.String(), err)
+	}
+	if len(trace.TraceEvents) != 5 {
+		t.Fatalf("Run.WriteChromeTrace() wrote %d events, want 5", len(trace.TraceEvents))
+	}
+	for _, e := range trace.TraceEvents {
+		if e.Phase != "X" || e.Time < 0 || e.Dur < 1000 || e.TID != events[e.Name].Worker || e.Args["status"] != "succeeded" {
+			t.Errorf("Run.WriteChromeTrace() event %+v doesn't match the timeline", e)
+		}
+	}
+}
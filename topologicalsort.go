@@ -1,15 +1,61 @@
 package topologicalsort
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sort"
+	"time"
 )
 
+// ErrCycle is returned (wrapped) when a graph can't be sorted because it contains a cycle
+var ErrCycle = errors.New("cycle detected")
+
+// ErrSelfCheckFailed is returned (wrapped) when a graph created with [WithSelfCheck] finds that its own sorted order violates an edge
+var ErrSelfCheckFailed = errors.New("self-check failed")
+
+// ErrSelfLoop is returned (wrapped) when adding an edge from a vertex to itself, unless the graph allows them via [WithSelfLoops]
+var ErrSelfLoop = errors.New("self loop")
+
 type Graph[T any] struct {
-	// currently a map of graphnode IDs to graphnode pointers
-	// could this be map[*GraphNode][]*GraphNode?
-	adjacencyList   map[string][]*GraphNode[T]
-	vertices        map[string]*GraphNode[T]
+	// ids gives every vertex key a dense integer ID (in registration order), which indexes nodes, adjacency and edgeLabels.
+	// Keeping the structure in slices of integers rather than maps of pointers saves a lot of memory and GC work on large graphs.
+	ids   map[string]int32
+	nodes []*GraphNode[T]
+	// adjacency[id] holds the IDs of the vertices that id depends on
+	adjacency       [][]int32
 	topoSortedOrder []*GraphNode[T]
+	// labels of the edges in adjacency, at the same indices; edgeLabels[id] stays nil until one of id's edges has a label
+	edgeLabels [][]string
+	edgeCount  int
+	// mutation journal, see [WithHistory]
+	history []Mutation
+	// see [Graph.Subscribe]
+	subscribers      []subscriber
+	nextSubscriberID int
+	// phase labels of vertices, see [Graph.SetVertexPhase]
+	phases map[string]string
+	// block labels of vertices, see [Graph.SetVertexBlock]
+	blocks map[string]string
+	// namespaces of the vertices registered with [Graph.RegisterVertexNS]
+	namespaces map[string]string
+	// group vertices, see [Graph.RegisterGroup]
+	groups map[string]bool
+	// alternative names of vertices, mapped to the key the vertex was registered under; see [Graph.AddAlias]
+	aliases map[string]string
+	// optional dependencies on vertices which aren't registered yet, by dest; see [Graph.AddOptionalDependency]
+	pendingOptional map[string][]string
+	// rules every edge has to satisfy, see [Graph.RegisterConstraint]
+	constraints []func(source, dest *GraphNode[T]) error
+	// architecture layers of vertices, see [Graph.SetVertexLayer]; layersEnforced is set once [Graph.EnforceLayers] registered its rule
+	layers         map[string]int
+	layersEnforced bool
+	// the graph's structure in key order, see [WithTraversalOrder]; built by the first sort after a structural change
+	keyOrder *keyOrder
+	// memoized key of the graph's structure in the sort cache, see [WithSortCache]; reset by every structural change
+	sortCacheKey string
+	config       config
 }
 
 type GraphNode[T any] struct {
@@ -17,12 +63,40 @@ type GraphNode[T any] struct {
 	Data T
 }
 
-// NewGraph returns an empty graph of the type that's passed in.
-func NewGraph[T any](val T) *Graph[T] {
+// Edge is a directed edge between two vertices: Source depends on Dest.
+// Label is optional metadata describing the edge (e.g. the kind of relationship).
+type Edge struct {
+	Source string
+	Dest   string
+	Label  string
+}
+
+// NewGraph returns an empty graph of the type that's passed in. Options can be passed to enable optional behaviour.
+func NewGraph[T any](val T, opts ...Option) *Graph[T] {
+	return NewGraphWithOptions[T](opts...)
+}
+
+// NewGraphWithOptions returns an empty graph holding Data of type T, configured by opts.
+// Unlike [NewGraph], it doesn't need a throwaway value to infer T: NewGraphWithOptions[string](WithSelfCheck())
+func NewGraphWithOptions[T any](opts ...Option) *Graph[T] {
+	return newGraph[T](newConfig(opts))
+}
+
+func newGraph[T any](config config) *Graph[T] {
 	return &Graph[T]{
-		adjacencyList:   make(map[string][]*GraphNode[T]),
-		vertices:        make(map[string]*GraphNode[T]),
+		ids:             make(map[string]int32, config.vertexCapacity),
+		nodes:           make([]*GraphNode[T], 0, config.vertexCapacity),
+		adjacency:       make([][]int32, 0, config.vertexCapacity),
 		topoSortedOrder: make([]*GraphNode[T], 0),
+		edgeLabels:      make([][]string, 0, config.vertexCapacity),
+		phases:          make(map[string]string),
+		blocks:          make(map[string]string),
+		pendingOptional: make(map[string][]string),
+		groups:          make(map[string]bool),
+		namespaces:      make(map[string]string),
+		aliases:         make(map[string]string),
+		layers:          make(map[string]int),
+		config:          config,
 	}
 }
 
@@ -33,15 +107,37 @@ func NewGraphNode[T any](key string, data T) *GraphNode[T] {
 	}
 }
 
-// RegisterVertex registers a new, unconnected vertex in the graph
+// RegisterVertex registers a new vertex in the graph. It's unconnected, apart from the optional dependencies on it added by [Graph.AddOptionalDependency].
 func (g *Graph[T]) RegisterVertex(key string, data T) error {
-	_, ok := g.vertices[key]
+	if canonical, ok := g.aliases[g.normalizeKey(key)]; ok {
+		return vertexError(key, "attempted to register vertex %s, which is an alias of %s", key, canonical)
+	}
+	node, ok := g.Vertex(key)
 	if ok {
-		return fmt.Errorf("attempted to register duplicate vertex")
+		switch g.config.duplicateVertices {
+		case IgnoreDuplicates:
+			return nil
+		case ReplaceDuplicates:
+			node.Data = data
+			g.record(Mutation{Kind: MutationVertexUpdated, Key: key})
+			return nil
+		}
+		return vertexError(key, "attempted to register duplicate vertex %s", key)
 	}
-	// create a new GraphNode and register a pointer to it
-	g.vertices[key] = NewGraphNode(key, data)
-	return nil
+	if max := g.config.limits.MaxVertices; max > 0 && len(g.nodes) >= max {
+		return &LimitError{Limit: "MaxVertices", Max: max}
+	}
+	// create a new GraphNode and give it the next ID
+	g.ids[g.normalizeKey(key)] = int32(len(g.nodes))
+	g.nodes = append(g.nodes, NewGraphNode(key, data))
+	g.adjacency = append(g.adjacency, nil)
+	g.edgeLabels = append(g.edgeLabels, nil)
+	g.record(Mutation{Kind: MutationVertexAdded, Key: key})
+	g.logDebug("topologicalsort: vertex added", slog.String("key", key))
+	if g.config.metrics != nil {
+		g.config.metrics.VertexAdded()
+	}
+	return g.addPendingOptional(key)
 }
 
 // AddItem is a more user-friendly alias for [RegisterVertex]
@@ -49,31 +145,145 @@ func (g *Graph[T]) AddItem(key string, data T) error {
 	return g.RegisterVertex(key, data)
 }
 
-// AddEdge adds an edge between two vertices (they need to be looked up by strings, though)
+// HasVertex reports whether a vertex is registered under key
+func (g *Graph[T]) HasVertex(key string) bool {
+	_, ok := g.id(key)
+	return ok
+}
+
+// Vertex returns the vertex registered under key, and whether there is one
+func (g *Graph[T]) Vertex(key string) (*GraphNode[T], bool) {
+	id, ok := g.id(key)
+	if !ok {
+		return nil, false
+	}
+	return g.nodes[id], true
+}
+
+// SetVertexData replaces the Data of an already-registered vertex
+func (g *Graph[T]) SetVertexData(key string, data T) error {
+	node, ok := g.Vertex(key)
+	if !ok {
+		return vertexError(key, "attempted to set data on unregistered vertex %s", key)
+	}
+	// edges point at the same GraphNode, so updating it in place is enough
+	node.Data = data
+	g.record(Mutation{Kind: MutationVertexUpdated, Key: key})
+	return nil
+}
+
+// UpdateVertexData replaces the Data of an already-registered vertex with the result of fn, which receives the current Data.
+// This is handy for enriching a vertex when more information about it arrives after registration.
+func (g *Graph[T]) UpdateVertexData(key string, fn func(T) T) error {
+	node, ok := g.Vertex(key)
+	if !ok {
+		return vertexError(key, "attempted to update data on unregistered vertex %s", key)
+	}
+	node.Data = fn(node.Data)
+	g.record(Mutation{Kind: MutationVertexUpdated, Key: key})
+	return nil
+}
+
+// UpsertVertex registers a new vertex, or replaces the Data of the vertex if it is already registered
+func (g *Graph[T]) UpsertVertex(key string, data T) error {
+	if g.HasVertex(key) {
+		return g.SetVertexData(key, data)
+	}
+	return g.RegisterVertex(key, data)
+}
+
+// AddEdge adds an edge between two vertices (they need to be looked up by strings, though).
+// By default source depends on dest; with [WithEdgeSemantics]([Precedes]) source comes before dest instead.
 func (g *Graph[T]) AddEdge(source, dest string) error {
-	_, ok := g.vertices[source]
+	return g.addEdge(g.dependencyEdge(Edge{Source: source, Dest: dest}))
+}
+
+// AddEdges adds several edges, along with their labels, interpreting them like [Graph.AddEdge]. It stops at the first edge which can't be added.
+func (g *Graph[T]) AddEdges(edges ...Edge) error {
+	for i, e := range edges {
+		err := g.addEdge(g.dependencyEdge(e))
+		if err != nil {
+			return withIndex(err, i)
+		}
+	}
+	return nil
+}
+
+func (g *Graph[T]) addEdge(e Edge) error {
+	source, ok := g.id(e.Source)
 	if !ok {
-		return fmt.Errorf("attempted to add edge to unregistered vertex %s", source)
+		return edgeError(e.Source, e.Dest, e.Source, "attempted to add edge to unregistered vertex %s", e.Source)
 	}
 
-	destNode, ok := g.vertices[dest]
+	dest, ok := g.id(e.Dest)
 	if !ok {
-		return fmt.Errorf("attempted to add edge from unregistered vertex %s", dest)
+		return edgeError(e.Source, e.Dest, e.Dest, "attempted to add edge from unregistered vertex %s", e.Dest)
 	}
+	// report the edge with the keys the vertices were registered under, which differ from e's if the graph normalizes keys
+	e.Source, e.Dest = g.nodes[source].Key, g.nodes[dest].Key
 
-	// prevent duplicate additions to adjacencyList
-	if containsNode(g.adjacencyList[source], destNode) {
-		return fmt.Errorf("attempted to add duplicate edge between %s and %s", source, dest)
+	if source == dest {
+		switch g.config.selfLoops {
+		case IgnoreSelfLoops:
+			return nil
+		case RejectSelfLoops:
+			return edgeError(e.Source, e.Dest, e.Source, "%w: attempted to add edge from vertex %s to itself", ErrSelfLoop, e.Source)
+		}
+	}
+
+	// prevent duplicate additions to adjacency
+	if g.config.parallelEdges {
+		if g.containsLabeledEdge(source, dest, e.Label) {
+			if g.config.duplicateEdges != RejectDuplicates {
+				return nil
+			}
+			return edgeError(e.Source, e.Dest, "", "attempted to add duplicate edge between %s and %s with label %q", e.Source, e.Dest, e.Label)
+		}
+	} else if containsID(g.adjacency[source], dest) {
+		if g.config.duplicateEdges != RejectDuplicates {
+			return nil
+		}
+		return edgeError(e.Source, e.Dest, "", "attempted to add duplicate edge between %s and %s", e.Source, e.Dest)
+	}
+	if err := g.checkConstraints(g.nodes[source], g.nodes[dest]); err != nil {
+		return err
+	}
+	if max := g.config.limits.MaxEdges; max > 0 && g.edgeCount >= max {
+		return &LimitError{Limit: "MaxEdges", Max: max}
+	}
+	// add edge to adjacency, keeping its label (if any edge of source has one) at the same index
+	if g.adjacency[source] == nil && g.config.edgeCapacity > 0 {
+		g.adjacency[source] = make([]int32, 0, g.config.edgeCapacity)
+	}
+	if e.Label != "" && g.edgeLabels[source] == nil {
+		g.edgeLabels[source] = make([]string, len(g.adjacency[source]), cap(g.adjacency[source]))
+	}
+	g.adjacency[source] = append(g.adjacency[source], dest)
+	if g.edgeLabels[source] != nil {
+		g.edgeLabels[source] = append(g.edgeLabels[source], e.Label)
+	}
+	g.edgeCount++
+	g.record(Mutation{Kind: MutationEdgeAdded, Source: e.Source, Dest: e.Dest, Label: e.Label})
+	g.logDebug("topologicalsort: edge added", slog.String("source", e.Source), slog.String("dest", e.Dest))
+	if g.config.metrics != nil {
+		g.config.metrics.EdgeAdded()
 	}
-	// add edge to adjacencyList
-	g.adjacencyList[source] = append(g.adjacencyList[source], destNode)
 
 	return nil
 }
 
-// AddDependency is a more user-friendly alias for [AddEdge]
+// AddDependency is a more user-friendly alias for [AddEdge]: source depends on dest.
+// Unlike AddEdge, it always means that, whatever the graph's [EdgeSemantics].
 func (g *Graph[T]) AddDependency(source, dest string) error {
-	return g.AddEdge(source, dest)
+	return g.addEdge(Edge{Source: source, Dest: dest})
+}
+
+// dependencyEdge turns an edge passed to AddEdge into the "source depends on dest" form the graph stores
+func (g *Graph[T]) dependencyEdge(e Edge) Edge {
+	if g.config.edgeSemantics == Precedes {
+		e.Source, e.Dest = e.Dest, e.Source
+	}
+	return e
 }
 
 // DepthFirstSearch performs a depth-first search starting from vertex node. It uses maps of graphnodes to track which have already been explored and which have been finished
@@ -83,10 +293,11 @@ func (g *Graph[T]) DepthFirstSearch(node *GraphNode[T], visited, finished map[*G
 	// Mark this node as explored
 	visited[node] = true
 
-	for _, neighbor := range g.adjacencyList[node.Key] {
+	for _, id := range g.adjacency[g.ids[g.normalizeKey(node.Key)]] {
+		neighbor := g.nodes[id]
 		alreadySeen, ok := visited[neighbor]
 		if ok && alreadySeen {
-			return nil, nil, fmt.Errorf("\ncycle detected: found a back edge from %s to %s", node.Key, neighbor.Key)
+			return nil, nil, edgeError(node.Key, neighbor.Key, "", "\n%w: found a back edge from %s to %s", ErrCycle, node.Key, neighbor.Key)
 		}
 
 		_, alreadyFinished := finished[neighbor]
@@ -105,6 +316,75 @@ func (g *Graph[T]) DepthFirstSearch(node *GraphNode[T], visited, finished map[*G
 	return visited, finished, nil
 }
 
+// dfsScratch is the state of an iterative depth-first search, see [Graph.depthFirstOrder]
+type dfsScratch struct {
+	state []dfsState
+	stack []dfsFrame
+	// skip, if set, hides edges from the search, e.g. the edges leaving a [View]
+	skip func(source, dest int32) bool
+}
+
+type dfsState uint8
+
+const (
+	unvisited dfsState = iota
+	visiting
+	finished
+)
+
+// dfsFrame is a vertex on the depth-first search stack, along with the position of the next dependency to visit
+type dfsFrame struct {
+	vertex int32
+	next   int
+}
+
+// reset prepares the scratch space for a graph with n vertices, reusing its memory where possible
+func (s *dfsScratch) reset(n int) {
+	if cap(s.state) < n {
+		s.state = make([]dfsState, n)
+	}
+	s.state = s.state[:n]
+	clear(s.state)
+	s.stack = s.stack[:0]
+}
+
+// depthFirstOrder appends root and all of its (transitive) dependencies which haven't been visited yet to out, dependencies first, and returns the extended slice.
+// It's the iterative equivalent of [Graph.DepthFirstSearch], working on vertex IDs instead of maps.
+func (g *Graph[T]) depthFirstOrder(root int32, s *dfsScratch, out []*GraphNode[T]) ([]*GraphNode[T], error) {
+	if s.state[root] != unvisited {
+		return out, nil
+	}
+	s.state[root] = visiting
+	s.stack = append(s.stack[:0], dfsFrame{vertex: root})
+	for len(s.stack) > 0 {
+		top := &s.stack[len(s.stack)-1]
+		deps := g.dfsDependencies(top.vertex)
+		if top.next == len(deps) {
+			s.state[top.vertex] = finished
+			out = append(out, g.nodes[top.vertex])
+			s.stack = s.stack[:len(s.stack)-1]
+			continue
+		}
+		dep := deps[top.next]
+		top.next++
+		if s.skip != nil && s.skip(top.vertex, dep) {
+			continue
+		}
+		switch s.state[dep] {
+		case visiting:
+			source, dest := g.nodes[top.vertex].Key, g.nodes[dep].Key
+			return out, edgeError(source, dest, "", "\n%w: found a back edge from %s to %s", ErrCycle, source, dest)
+		case unvisited:
+			if max := g.config.limits.MaxDepth; max > 0 && len(s.stack) >= max {
+				return out, &LimitError{Limit: "MaxDepth", Max: max}
+			}
+			s.state[dep] = visiting
+			s.stack = append(s.stack, dfsFrame{vertex: dep})
+		}
+	}
+	return out, nil
+}
+
 // SortedKeys returns the sorted order of the graph keys
 // IT DOES NOT SORT THE GRAPH! (use [TopologicalSort] to do that)
 func (g *Graph[T]) SortedKeys() []string {
@@ -134,61 +414,305 @@ func (g *Graph[T]) SortedValues() []T {
 // TopologicalSort does some basic graph validation (e.g. cycle detection) and then performs a topological sort.
 // It returns a slice of strings (the node keys which were originally passed in during graph construction), in a valid topologically sorted order
 func (g *Graph[T]) TopologicalSort() ([]string, error) {
-	visited := make(map[*GraphNode[T]]bool)
-	finished := make(map[*GraphNode[T]]bool)
+	end := g.startSort("topologicalsort.sort")
+	sorted, err := g.topologicalSort()
+	end(err)
+	return sorted, err
+}
 
-	for _, n := range g.vertices {
-		_, inVisited := visited[n]
-		_, inFinished := finished[n]
-		var err error
+func (g *Graph[T]) topologicalSort() ([]string, error) {
+	if g.config.sortCache != nil {
+		return g.cachedTopologicalSort()
+	}
+	return g.uncachedTopologicalSort()
+}
 
-		// if not yet visited and finished, recurse
-		if !inVisited && !inFinished {
-			visited, finished, err = g.DepthFirstSearch(n, visited, finished)
-			if err != nil {
-				return []string{}, err
-			}
+func (g *Graph[T]) uncachedTopologicalSort() ([]string, error) {
+	if g.config.parallelism > 1 {
+		return g.parallelTopologicalSort()
+	}
+
+	// start from a clean slate, so that sorting twice doesn't duplicate the sorted order
+	g.topoSortedOrder = make([]*GraphNode[T], 0, len(g.nodes))
+	var scratch dfsScratch
+	scratch.reset(len(g.nodes))
+	g.prepareTraversal()
+
+	for i := range g.nodes {
+		var err error
+		g.topoSortedOrder, err = g.depthFirstOrder(g.dfsRoot(i), &scratch, g.topoSortedOrder)
+		if err != nil {
+			return []string{}, err
 		}
 	}
 
+	if err := g.applyPins(); err != nil {
+		return []string{}, err
+	}
+	if err := g.selfCheck(true); err != nil {
+		return []string{}, err
+	}
+
 	// TODO(dcohen) in a future version, just return the topoSortedOrder (pointers, not string Keys or Data)
 	return g.SortedKeys(), nil
 }
 
+// TopologicalSortFor sorts only the given targets and their transitive dependencies.
+// It returns the keys of that minimal subgraph in a valid topologically sorted order; the rest of the graph is ignored (including any cycles in it).
+func (g *Graph[T]) TopologicalSortFor(targets ...string) ([]string, error) {
+	end := g.startSort("topologicalsort.sort_for")
+	sorted, err := g.topologicalSortFor(targets...)
+	end(err)
+	return sorted, err
+}
+
+func (g *Graph[T]) topologicalSortFor(targets ...string) ([]string, error) {
+	g.topoSortedOrder = make([]*GraphNode[T], 0)
+	var scratch dfsScratch
+	scratch.reset(len(g.nodes))
+	g.prepareTraversal()
+
+	for _, target := range targets {
+		id, ok := g.id(target)
+		if !ok {
+			return []string{}, vertexError(target, "attempted to sort for unregistered vertex %s", target)
+		}
+		// a DFS from a target only ever reaches its dependencies
+		var err error
+		g.topoSortedOrder, err = g.depthFirstOrder(id, &scratch, g.topoSortedOrder)
+		if err != nil {
+			return []string{}, err
+		}
+	}
+
+	if err := g.applyPins(); err != nil {
+		return []string{}, err
+	}
+	if err := g.selfCheck(false); err != nil {
+		return []string{}, err
+	}
+
+	return g.SortedKeys(), nil
+}
+
+// selfCheck validates g.topoSortedOrder against the graph's edges, if the graph was created with [WithSelfCheck].
+// complete says whether the order should contain every vertex, rather than just some vertices along with all of their dependencies.
+func (g *Graph[T]) selfCheck(complete bool) error {
+	if !g.config.selfCheck {
+		return nil
+	}
+	if complete && len(g.topoSortedOrder) != len(g.nodes) {
+		return fmt.Errorf("%w: sorted order has %d vertices, the graph has %d", ErrSelfCheckFailed, len(g.topoSortedOrder), len(g.nodes))
+	}
+
+	position := make(map[*GraphNode[T]]int, len(g.topoSortedOrder))
+	for i, node := range g.topoSortedOrder {
+		if _, ok := position[node]; ok {
+			return fmt.Errorf("%w: vertex %s appears more than once", ErrSelfCheckFailed, node.Key)
+		}
+		position[node] = i
+	}
+	for i, node := range g.topoSortedOrder {
+		for _, id := range g.adjacency[g.ids[g.normalizeKey(node.Key)]] {
+			dep := g.nodes[id]
+			depPosition, ok := position[dep]
+			if !ok || depPosition > i {
+				return fmt.Errorf("%w: vertex %s is not preceded by its dependency %s", ErrSelfCheckFailed, node.Key, dep.Key)
+			}
+		}
+	}
+	return nil
+}
+
 // NewGraphFromData accepts a map of GraphNode:[]string, where the string slice represents adjacent node Keys ("dependencies").
-// It returns a graph pointer, or an error if something went wrong.
-func NewGraphFromData[T any](nodes map[*GraphNode[T]][]string) (*Graph[T], error) {
-	var err error
-	graph := &Graph[T]{
-		adjacencyList:   make(map[string][]*GraphNode[T]),
-		vertices:        make(map[string]*GraphNode[T]),
-		topoSortedOrder: make([]*GraphNode[T], 0),
+// It returns a graph pointer, or an error if something went wrong. The error lists every problem (duplicate vertices, unknown dependencies, ...)
+// rather than just the first one, joined with [errors.Join] and ordered by vertex key. [WithMissingVertices] sets how unknown dependencies are handled.
+func NewGraphFromData[T any](nodes map[*GraphNode[T]][]string, opts ...Option) (*Graph[T], error) {
+	graph := NewGraphWithOptions[T](opts...)
+	start := time.Now()
+	_, span := graph.startSpan(context.Background(), "topologicalsort.build", Attribute{Key: "vertices", Value: len(nodes)})
+	err := graph.addData(nodes)
+	span.End(err)
+	graph.logDebug("topologicalsort: built graph", slog.Int("vertices", len(graph.nodes)), slog.Int("edges", graph.edgeCount),
+		slog.Duration("duration", time.Since(start)), slog.Bool("ok", err == nil))
+	if err != nil {
+		return nil, err
 	}
-	// Iterate through vertices to build up the graph
+	return graph, nil
+}
+
+// addData registers the vertices and edges passed to [NewGraphFromData], collecting every error
+func (g *Graph[T]) addData(nodes map[*GraphNode[T]][]string) error {
+	// go through the nodes in key order, so the errors come out in a stable order
+	sorted := make([]*GraphNode[T], 0, len(nodes))
 	for node := range nodes {
-		err = graph.RegisterVertex(node.Key, node.Data)
+		sorted = append(sorted, node)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Key < sorted[j].Key
+	})
+
+	errs := make([]error, 0)
+	// Iterate through vertices to build up the graph
+	for _, node := range sorted {
+		err := g.RegisterVertex(node.Key, node.Data)
 		if err != nil {
-			return nil, err
+			errs = append(errs, err)
 		}
 	}
 
+	edges := make([]Edge, 0)
+	for _, node := range sorted {
+		for _, a := range nodes[node] {
+			edges = append(edges, Edge{Source: node.Key, Dest: a})
+		}
+	}
+	if err := g.addMissing(edges); err != nil {
+		errs = append(errs, err)
+	}
+
 	// Add edges between vertices
-	for node, adjacencies := range nodes {
-		for _, a := range adjacencies {
-			err = graph.AddEdge(node.Key, a)
+	for _, node := range sorted {
+		for _, a := range nodes[node] {
+			if g.reportedMissing(Edge{Source: node.Key, Dest: a}) {
+				continue
+			}
+			err := g.AddDependency(node.Key, a)
 			if err != nil {
-				return nil, err
+				errs = append(errs, fmt.Errorf("vertex %s: %w", node.Key, err))
 			}
 		}
 	}
-	return graph, nil
+	return errors.Join(errs...)
+}
+
+func containsID(ids []int32, match int32) bool {
+	for _, id := range ids {
+		if id == match {
+			return true
+		}
+	}
+	return false
+}
+
+// Edges returns all edges of the graph, sorted by Source, then Dest.
+// With [WithInsertionOrder], they are ordered by the registration order of their Source instead, then in the order they were added.
+func (g *Graph[T]) Edges() []Edge {
+	edges := g.insertionOrderEdges()
+	if !g.config.insertionOrder {
+		sortEdges(edges)
+	}
+	return edges
+}
+
+// sortedEdges returns all edges of the graph sorted by Source, then Dest, whatever the graph's options
+func (g *Graph[T]) sortedEdges() []Edge {
+	edges := g.insertionOrderEdges()
+	sortEdges(edges)
+	return edges
+}
+
+// insertionOrderEdges returns all edges of the graph by the ID of their Source, then in the order they were added
+func (g *Graph[T]) insertionOrderEdges() []Edge {
+	edges := make([]Edge, 0, g.edgeCount)
+	for source, dests := range g.adjacency {
+		for i, dest := range dests {
+			edges = append(edges, Edge{Source: g.nodes[source].Key, Dest: g.nodes[dest].Key, Label: g.edgeLabel(int32(source), i)})
+		}
+	}
+	return edges
+}
+
+// AdjacencyMap returns a copy of the graph's structure: for every vertex key, the keys of the vertices it depends on, in the order the edges were added.
+// Vertices without dependencies map to an empty slice. Changing the map doesn't change the graph.
+func (g *Graph[T]) AdjacencyMap() map[string][]string {
+	adjacency := make(map[string][]string, len(g.nodes))
+	for id, node := range g.nodes {
+		deps := make([]string, len(g.adjacency[id]))
+		for i, dest := range g.adjacency[id] {
+			deps[i] = g.nodes[dest].Key
+		}
+		adjacency[node.Key] = deps
+	}
+	return adjacency
+}
+
+// dependentsOf returns the reverse of the adjacency list: for every vertex key, the sorted keys of the vertices which depend on it
+func (g *Graph[T]) dependentsOf() map[string][]string {
+	dependents := make(map[string][]string, len(g.nodes))
+	for source, dests := range g.adjacency {
+		for _, dest := range dests {
+			dependents[g.nodes[dest].Key] = append(dependents[g.nodes[dest].Key], g.nodes[source].Key)
+		}
+	}
+	for _, d := range dependents {
+		sort.Strings(d)
+	}
+	return dependents
 }
 
-func containsNode[T any](nodes []*GraphNode[T], match *GraphNode[T]) bool {
-	for _, n := range nodes {
-		if n == match {
+// containsLabeledEdge reports whether source already has an edge to dest with the given label
+func (g *Graph[T]) containsLabeledEdge(source, dest int32, label string) bool {
+	for i, id := range g.adjacency[source] {
+		if id == dest && g.edgeLabel(source, i) == label {
 			return true
 		}
 	}
 	return false
 }
+
+// edgeLabel returns the label of the i-th edge of source
+func (g *Graph[T]) edgeLabel(source int32, i int) string {
+	if g.edgeLabels[source] == nil {
+		return ""
+	}
+	return g.edgeLabels[source][i]
+}
+
+// vertex returns the vertex registered under key, or nil
+func (g *Graph[T]) vertex(key string) *GraphNode[T] {
+	id, ok := g.id(key)
+	if !ok {
+		return nil
+	}
+	return g.nodes[id]
+}
+
+// dependencyNodes returns the vertices key depends on (nil for an unregistered key), in the order the edges were added
+func (g *Graph[T]) dependencyNodes(key string) []*GraphNode[T] {
+	id, ok := g.id(key)
+	if !ok {
+		return nil
+	}
+	deps := make([]*GraphNode[T], len(g.adjacency[id]))
+	for i, dep := range g.adjacency[id] {
+		deps[i] = g.nodes[dep]
+	}
+	return deps
+}
+
+// dependencyKeys returns the keys of the vertices key depends on (nil for an unregistered key), in the order the edges were added
+func (g *Graph[T]) dependencyKeys(key string) []string {
+	id, ok := g.id(key)
+	if !ok {
+		return nil
+	}
+	deps := make([]string, len(g.adjacency[id]))
+	for i, dep := range g.adjacency[id] {
+		deps[i] = g.nodes[dep].Key
+	}
+	return deps
+}
+
+// sortEdges sorts edges by Source, then Dest, then Label
+func sortEdges(edges []Edge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Source != edges[j].Source {
+			return edges[i].Source < edges[j].Source
+		}
+		if edges[i].Dest != edges[j].Dest {
+			return edges[i].Dest < edges[j].Dest
+		}
+		return edges[i].Label < edges[j].Label
+	})
+}
@@ -1,6 +1,7 @@
 package topologicalsort
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -210,3 +211,327 @@ func Test_TopographicSort_With_Arbitrary_Data(t *testing.T) {
 		})
 	}
 }
+
+func TestNewGraphFromData_Errors(t *testing.T) {
+	_, err := NewGraphFromData(map[*GraphNode[string]][]string{
+		{Key: "gcc"}:             {"libc", "binutils"},
+		{Key: "gcc"}:             {},
+		{Key: "build-essential"}: {"make"},
+		{Key: "libc"}:            {},
+	})
+	if err == nil {
+		t.Fatalf("NewGraphFromData() expected an error")
+	}
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	want := []string{
+		"attempted to register duplicate vertex gcc",
+		"vertex build-essential: attempted to add edge from unregistered vertex make",
+		"vertex gcc: attempted to add edge from unregistered vertex binutils",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("NewGraphFromData() returned %d errors, want %d: %v", len(errs), len(want), err)
+	}
+	for i := range want {
+		if errs[i].Error() != want[i] {
+			t.Errorf("NewGraphFromData() error %d = %q, want %q", i, errs[i], want[i])
+		}
+	}
+}
+
+func TestGraph_SetVertexData(t *testing.T) {
+	graph := NewGraph("")
+	graph.RegisterVertex("gcc", "gcc-data")
+	graph.RegisterVertex("libc", "libc-data")
+	graph.AddEdge("gcc", "libc")
+
+	if err := graph.SetVertexData("libc", "libc-6"); err != nil {
+		t.Fatalf("SetVertexData: unexpected error %v", err)
+	}
+	if err := graph.SetVertexData("make", "make-data"); err == nil {
+		t.Errorf("SetVertexData: expected an error for an unregistered vertex")
+	}
+	if err := graph.UpdateVertexData("gcc", func(d string) string { return d + "-12" }); err != nil {
+		t.Fatalf("UpdateVertexData: unexpected error %v", err)
+	}
+
+	if _, err := graph.TopologicalSort(); err != nil {
+		t.Fatalf("Graph.TopologicalSort() unexpected error %v", err)
+	}
+	want := []string{"libc-6", "gcc-data-12"}
+	if got := graph.SortedValues(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.SortedValues() = %v, want %v", got, want)
+	}
+}
+
+func TestGraph_UpsertVertex(t *testing.T) {
+	graph := NewGraph(0)
+	if err := graph.UpsertVertex("one", 1); err != nil {
+		t.Fatalf("UpsertVertex: unexpected error registering a new vertex %v", err)
+	}
+	if err := graph.UpsertVertex("one", 11); err != nil {
+		t.Fatalf("UpsertVertex: unexpected error updating a vertex %v", err)
+	}
+	if got := graph.vertex("one").Data; got != 11 {
+		t.Errorf("UpsertVertex: Data = %d, want 11", got)
+	}
+}
+
+func TestGraph_TopologicalSortFor(t *testing.T) {
+	adjacencyList := map[string][]string{
+		"build-essential": {"make", "gcc"},
+		"make":            {"gcc"},
+		"gcc":             {"libc"},
+		"libc":            {},
+		"vim":             {"ncurses"},
+		"ncurses":         {"libc"},
+		"broken":          {"cyclic"},
+		"cyclic":          {"broken"},
+	}
+	tests := []struct {
+		name    string
+		targets []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "No targets means nothing to sort",
+			targets: []string{},
+			want:    []string{},
+		},
+		{
+			name:    "Only transitive dependencies of the target are included",
+			targets: []string{"make"},
+			want:    []string{"libc", "gcc", "make"},
+		},
+		{
+			name:    "Shared dependencies appear once",
+			targets: []string{"make", "vim"},
+			want:    []string{"libc", "gcc", "make", "ncurses", "vim"},
+		},
+		{
+			name:    "Cycles outside the targets' dependencies are ignored",
+			targets: []string{"vim"},
+			want:    []string{"libc", "ncurses", "vim"},
+		},
+		{
+			name:    "Cycles among the targets' dependencies trigger an error",
+			targets: []string{"broken"},
+			wantErr: true,
+		},
+		{
+			name:    "Unregistered targets trigger an error",
+			targets: []string{"emacs"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphWithVerticesDUMMYDATA(adjacencyList, "")
+			got, err := g.TopologicalSortFor(tt.targets...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Graph.TopologicalSortFor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graph.TopologicalSortFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraph_TopologicalSort_Twice(t *testing.T) {
+	g := graphWithVerticesDUMMYDATA(map[string][]string{"one": {}, "two": {"one"}}, "")
+	g.TopologicalSort()
+	got, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatalf("Graph.TopologicalSort() unexpected error %v", err)
+	}
+	if want := []string{"one", "two"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.TopologicalSort() = %v, want %v", got, want)
+	}
+}
+
+func TestGraph_Edges(t *testing.T) {
+	graph := NewGraph("")
+	graph.AddItem("build-essential", "be-data")
+	graph.AddItem("gcc", "gcc-data")
+	graph.AddItem("make", "make-data")
+	graph.AddItem("libc", "libc-data")
+
+	err := graph.AddEdges(
+		Edge{Source: "gcc", Dest: "libc", Label: "links"},
+		Edge{Source: "build-essential", Dest: "make"},
+		Edge{Source: "build-essential", Dest: "gcc"},
+	)
+	if err != nil {
+		t.Fatalf("AddEdges: unexpected error %v", err)
+	}
+	if err := graph.AddEdges(Edge{Source: "make", Dest: "libc"}, Edge{Source: "make", Dest: "bash"}); err == nil {
+		t.Errorf("AddEdges: expected an error for an unregistered vertex")
+	}
+
+	want := []Edge{
+		{Source: "build-essential", Dest: "gcc"},
+		{Source: "build-essential", Dest: "make"},
+		{Source: "gcc", Dest: "libc", Label: "links"},
+		{Source: "make", Dest: "libc"},
+	}
+	if got := graph.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.Edges() = %v, want %v", got, want)
+	}
+}
+
+func TestGraph_AdjacencyMap(t *testing.T) {
+	graph := NewGraph("")
+	graph.AddItem("build-essential", "be-data")
+	graph.AddItem("gcc", "gcc-data")
+	graph.AddItem("make", "make-data")
+	graph.AddItem("libc", "libc-data")
+	graph.AddEdge("build-essential", "make")
+	graph.AddEdge("build-essential", "gcc")
+	graph.AddEdge("gcc", "libc")
+
+	want := map[string][]string{
+		"build-essential": {"make", "gcc"},
+		"gcc":             {"libc"},
+		"make":            {},
+		"libc":            {},
+	}
+	got := graph.AdjacencyMap()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.AdjacencyMap() = %v, want %v", got, want)
+	}
+
+	// changing the copy doesn't change the graph
+	got["gcc"][0] = "make"
+	got["make"] = append(got["make"], "libc")
+	if again := graph.AdjacencyMap(); !reflect.DeepEqual(again, want) {
+		t.Errorf("Graph.AdjacencyMap() = %v after changing a copy, want %v", again, want)
+	}
+}
+
+func TestGraph_WithSelfCheck(t *testing.T) {
+	g := NewGraph("", WithSelfCheck())
+	g.RegisterVertex("gcc", "")
+	g.RegisterVertex("libc", "")
+	g.AddEdge("gcc", "libc")
+
+	got, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatalf("Graph.TopologicalSort() unexpected error %v", err)
+	}
+	if want := []string{"libc", "gcc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.TopologicalSort() = %v, want %v", got, want)
+	}
+
+	// corrupt the sorted order the way a buggy sort would
+	g.topoSortedOrder = []*GraphNode[string]{g.vertex("gcc"), g.vertex("libc")}
+	if err := g.selfCheck(true); !errors.Is(err, ErrSelfCheckFailed) {
+		t.Errorf("Graph.selfCheck() error = %v, want %v", err, ErrSelfCheckFailed)
+	}
+	g.topoSortedOrder = []*GraphNode[string]{g.vertex("libc")}
+	if err := g.selfCheck(true); !errors.Is(err, ErrSelfCheckFailed) {
+		t.Errorf("Graph.selfCheck() error = %v, want %v", err, ErrSelfCheckFailed)
+	}
+	if err := g.selfCheck(false); err != nil {
+		t.Errorf("Graph.selfCheck() unexpected error %v for a partial order", err)
+	}
+}
+
+func TestGraph_AddEdge_SelfLoops(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		wantErr   error
+		wantEdges int
+		wantCycle bool
+	}{
+		{
+			name:    "Self loops are rejected by default",
+			wantErr: ErrSelfLoop,
+		},
+		{
+			name: "Self loops can be ignored",
+			opts: []Option{WithSelfLoops(IgnoreSelfLoops)},
+		},
+		{
+			name:      "Allowed self loops are cycles",
+			opts:      []Option{WithSelfLoops(AllowSelfLoops)},
+			wantEdges: 1,
+			wantCycle: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGraph("", tt.opts...)
+			g.RegisterVertex("loop", "")
+			if err := g.AddEdge("loop", "loop"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Graph.AddEdge() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := len(g.Edges()); got != tt.wantEdges {
+				t.Errorf("Graph.Edges() has %d edges, want %d", got, tt.wantEdges)
+			}
+			if _, err := g.TopologicalSort(); (err != nil) != tt.wantCycle {
+				t.Errorf("Graph.TopologicalSort() error = %v, wantCycle %v", err, tt.wantCycle)
+			}
+		})
+	}
+}
+
+func TestGraph_WithAllowParallelEdges(t *testing.T) {
+	g := NewGraph("", WithAllowParallelEdges())
+	g.RegisterVertex("app", "")
+	g.RegisterVertex("lib", "")
+	g.RegisterVertex("tool", "")
+
+	err := g.AddEdges(
+		Edge{Source: "app", Dest: "lib", Label: "links"},
+		Edge{Source: "app", Dest: "lib", Label: "imports"},
+		Edge{Source: "lib", Dest: "tool", Label: "builds-with"},
+	)
+	if err != nil {
+		t.Fatalf("AddEdges: unexpected error %v", err)
+	}
+	if err := g.AddEdges(Edge{Source: "app", Dest: "lib", Label: "links"}); err == nil {
+		t.Errorf("AddEdges: expected an error for an edge with a duplicate label")
+	}
+
+	want := []Edge{
+		{Source: "app", Dest: "lib", Label: "imports"},
+		{Source: "app", Dest: "lib", Label: "links"},
+		{Source: "lib", Dest: "tool", Label: "builds-with"},
+	}
+	if got := g.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.Edges() = %v, want %v", got, want)
+	}
+
+	sorted, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatalf("Graph.TopologicalSort() unexpected error %v", err)
+	}
+	if want := []string{"tool", "lib", "app"}; !reflect.DeepEqual(sorted, want) {
+		t.Errorf("Graph.TopologicalSort() = %v, want %v", sorted, want)
+	}
+	levels, err := g.Levels()
+	if err != nil {
+		t.Fatalf("Graph.Levels() unexpected error %v", err)
+	}
+	if want := [][]string{{"tool"}, {"lib"}, {"app"}}; !reflect.DeepEqual(levels, want) {
+		t.Errorf("Graph.Levels() = %v, want %v", levels, want)
+	}
+}
+
+func TestGraph_Vertex(t *testing.T) {
+	graph := NewGraph("")
+	graph.AddItem("gcc", "gcc-data")
+	if !graph.HasVertex("gcc") || graph.HasVertex("make") {
+		t.Errorf("Graph.HasVertex() doesn't match the registered vertices")
+	}
+	node, ok := graph.Vertex("gcc")
+	if !ok || node.Data != "gcc-data" {
+		t.Errorf("Graph.Vertex() = %v, %v, want the gcc vertex", node, ok)
+	}
+	if _, ok := graph.Vertex("make"); ok {
+		t.Errorf("Graph.Vertex() found an unregistered vertex")
+	}
+}
@@ -0,0 +1,87 @@
+package topotest
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// AssertValidOrder checks that order lists every vertex of g exactly once, with every vertex after all of its dependencies.
+// It reports every problem with t.Errorf (so the test goes on), and returns whether the order is valid.
+func AssertValidOrder[T any](t testing.TB, g *topologicalsort.Graph[T], order []string) bool {
+	t.Helper()
+	comparison, err := g.CompareOrders(order, order)
+	if err != nil {
+		t.Errorf("order %v is not a permutation of the graph's vertices: %v", order, err)
+		return false
+	}
+	for _, e := range comparison.AViolations {
+		t.Errorf("order %v places %s before its dependency %s", order, e.Source, e.Dest)
+	}
+	return comparison.AValid()
+}
+
+// AssertAcyclic checks that g has no cycles, reporting each cycle's vertices with t.Errorf. It returns whether g is acyclic.
+func AssertAcyclic[T any](t testing.TB, g *topologicalsort.Graph[T]) bool {
+	t.Helper()
+	cycles := g.Cycles()
+	for _, cycle := range cycles {
+		t.Errorf("graph has a cycle through %v", cycle)
+	}
+	return len(cycles) == 0
+}
+
+// AssertEdges checks that g has exactly the given edges (labels included), in any order.
+// It reports every missing and unexpected edge with t.Errorf, and returns whether the edges match.
+func AssertEdges[T any](t testing.TB, g *topologicalsort.Graph[T], edges []topologicalsort.Edge) bool {
+	t.Helper()
+	want := make(map[topologicalsort.Edge]int, len(edges))
+	for _, e := range edges {
+		want[e]++
+	}
+	unexpected := make([]topologicalsort.Edge, 0)
+	for _, e := range g.Edges() {
+		if want[e] > 0 {
+			want[e]--
+			continue
+		}
+		unexpected = append(unexpected, e)
+	}
+	missing := make([]topologicalsort.Edge, 0)
+	for _, e := range edges {
+		if want[e] > 0 {
+			want[e]--
+			missing = append(missing, e)
+		}
+	}
+
+	sortEdges(missing)
+	for _, e := range missing {
+		t.Errorf("graph is missing edge %s", formatEdge(e))
+	}
+	for _, e := range unexpected {
+		t.Errorf("graph has unexpected edge %s", formatEdge(e))
+	}
+	return len(missing) == 0 && len(unexpected) == 0
+}
+
+// sortEdges sorts edges like [topologicalsort.Graph.Edges]
+func sortEdges(edges []topologicalsort.Edge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Source != edges[j].Source {
+			return edges[i].Source < edges[j].Source
+		}
+		if edges[i].Dest != edges[j].Dest {
+			return edges[i].Dest < edges[j].Dest
+		}
+		return edges[i].Label < edges[j].Label
+	})
+}
+
+func formatEdge(e topologicalsort.Edge) string {
+	if e.Label == "" {
+		return e.Source + " -> " + e.Dest
+	}
+	return e.Source + " -> " + e.Dest + " (" + e.Label + ")"
+}
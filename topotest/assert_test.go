@@ -0,0 +1,98 @@
+package topotest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// recorder is a testing.TB which records the failures reported to it instead of failing the test
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// chain returns the graph c -> b -> a (c depends on b, which depends on a)
+func chain(t *testing.T) *topologicalsort.Graph[string] {
+	t.Helper()
+	graph := topologicalsort.NewGraphWithOptions[string]()
+	for _, k := range []string{"a", "b", "c"} {
+		if err := graph.RegisterVertex(k, k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := graph.AddEdges(topologicalsort.Edge{Source: "b", Dest: "a"}, topologicalsort.Edge{Source: "c", Dest: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	return graph
+}
+
+func TestAssertValidOrder(t *testing.T) {
+	graph := chain(t)
+	tests := []struct {
+		name         string
+		order        []string
+		want         bool
+		wantFailures int
+	}{
+		{name: "Valid order", order: []string{"a", "b", "c"}, want: true},
+		{name: "Order breaking two edges", order: []string{"c", "b", "a"}, wantFailures: 2},
+		{name: "Missing vertex", order: []string{"a", "b"}, wantFailures: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{}
+			if got := AssertValidOrder(r, graph, tt.order); got != tt.want {
+				t.Errorf("AssertValidOrder() = %v, want %v", got, tt.want)
+			}
+			if len(r.failures) != tt.wantFailures {
+				t.Errorf("AssertValidOrder() reported %v, want %d failures", r.failures, tt.wantFailures)
+			}
+		})
+	}
+}
+
+func TestAssertAcyclic(t *testing.T) {
+	graph := chain(t)
+	r := &recorder{}
+	if !AssertAcyclic(r, graph) || len(r.failures) != 0 {
+		t.Errorf("AssertAcyclic() failed for an acyclic graph: %v", r.failures)
+	}
+
+	if err := graph.AddEdge("a", "c"); err != nil {
+		t.Fatal(err)
+	}
+	r = &recorder{}
+	if AssertAcyclic(r, graph) {
+		t.Errorf("AssertAcyclic() passed for a cyclic graph")
+	}
+	want := []string{"graph has a cycle through [a b c]"}
+	if !reflect.DeepEqual(r.failures, want) {
+		t.Errorf("AssertAcyclic() reported %v, want %v", r.failures, want)
+	}
+}
+
+func TestAssertEdges(t *testing.T) {
+	graph := chain(t)
+	r := &recorder{}
+	if !AssertEdges(r, graph, []topologicalsort.Edge{{Source: "c", Dest: "b"}, {Source: "b", Dest: "a"}}) || len(r.failures) != 0 {
+		t.Errorf("AssertEdges() failed for matching edges: %v", r.failures)
+	}
+
+	r = &recorder{}
+	if AssertEdges(r, graph, []topologicalsort.Edge{{Source: "c", Dest: "b"}, {Source: "c", Dest: "a", Label: "uses"}}) {
+		t.Errorf("AssertEdges() passed for different edges")
+	}
+	want := []string{"graph is missing edge c -> a (uses)", "graph has unexpected edge b -> a"}
+	if !reflect.DeepEqual(r.failures, want) {
+		t.Errorf("AssertEdges() reported %v, want %v", r.failures, want)
+	}
+}
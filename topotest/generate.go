@@ -0,0 +1,58 @@
+// Package topotest generates random graphs and provides assertions for testing code built on topologicalsort.
+package topotest
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/groovemonkey/topologicalsort"
+)
+
+// RandomDAG returns a random directed acyclic graph with the given number of vertices.
+// Every pair of vertices is connected with probability density (0 gives no edges, 1 gives a total order).
+// Vertex keys are "v0", "v1", ... and each vertex's Data is its index; the hidden ordering the edges follow is shuffled, so it has nothing to do with the indices.
+func RandomDAG(rng *rand.Rand, vertices int, density float64) *topologicalsort.Graph[int] {
+	graph, rank := randomVertices(rng, vertices)
+
+	for i := 0; i < vertices; i++ {
+		for j := i + 1; j < vertices; j++ {
+			if rng.Float64() < density {
+				// edges always point at the vertex with the lower rank, so there can't be a cycle
+				graph.AddEdge(key(rank[j]), key(rank[i]))
+			}
+		}
+	}
+	return graph
+}
+
+// RandomCyclicGraph returns a random graph like [RandomDAG], plus enough extra edges to guarantee at least one cycle.
+// It needs at least two vertices.
+func RandomCyclicGraph(rng *rand.Rand, vertices int, density float64) *topologicalsort.Graph[int] {
+	if vertices < 2 {
+		panic(fmt.Sprintf("topotest: a cyclic graph needs at least two vertices, got %d", vertices))
+	}
+	graph := RandomDAG(rng, vertices, density)
+
+	// pick two distinct vertices and connect them both ways; one of the edges may already exist
+	a := rng.Intn(vertices)
+	b := rng.Intn(vertices - 1)
+	if b >= a {
+		b++
+	}
+	graph.AddEdge(key(a), key(b))
+	graph.AddEdge(key(b), key(a))
+	return graph
+}
+
+// randomVertices registers the vertices and returns a random permutation to rank them by
+func randomVertices(rng *rand.Rand, vertices int) (*topologicalsort.Graph[int], []int) {
+	graph := topologicalsort.NewGraph(0)
+	for i := 0; i < vertices; i++ {
+		graph.RegisterVertex(key(i), i)
+	}
+	return graph, rng.Perm(vertices)
+}
+
+func key(i int) string {
+	return fmt.Sprintf("v%d", i)
+}
@@ -0,0 +1,34 @@
+package topotest
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRandomDAG(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, density := range []float64{0, 0.1, 0.5, 1} {
+		graph := RandomDAG(rng, 50, density)
+		sorted, err := graph.TopologicalSort()
+		if err != nil {
+			t.Fatalf("RandomDAG(density=%v) produced a graph that doesn't sort: %v", density, err)
+		}
+		if len(sorted) != 50 {
+			t.Errorf("RandomDAG(density=%v) sorted %d vertices, want 50", density, len(sorted))
+		}
+	}
+
+	if got := len(RandomDAG(rng, 10, 1).Edges()); got != 45 {
+		t.Errorf("RandomDAG(density=1) has %d edges, want 45", got)
+	}
+}
+
+func TestRandomCyclicGraph(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, density := range []float64{0, 0.1, 0.5, 1} {
+		graph := RandomCyclicGraph(rng, 20, density)
+		if _, err := graph.TopologicalSort(); err == nil {
+			t.Errorf("RandomCyclicGraph(density=%v) produced a graph without a cycle", density)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package topologicalsort
+
+import (
+	"context"
+	"time"
+)
+
+// Tracer is a minimal tracing hook, so that graphs can show up in distributed traces without this package depending on OpenTelemetry.
+// An adapter only needs to start a span from the OpenTelemetry tracer and record the attributes and error on it. See [WithTracer].
+type Tracer interface {
+	// Start starts a span named name as a child of any span in ctx, and returns a context containing the new span
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a span started by a [Tracer]
+type Span interface {
+	// End ends the span; err is the operation's error, or nil if it succeeded
+	End(err error)
+}
+
+// Attribute is a key/value pair describing a span
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+type noopSpan struct{}
+
+func (noopSpan) End(error) {}
+
+// startSpan starts a span with the graph's tracer, if it has one
+func (g *Graph[T]) startSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	if g.config.tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return g.config.tracer.Start(ctx, name, attrs...)
+}
+
+// startSort starts the span of a sort entry point, named like "topologicalsort.levels"; calling the returned function with the sort's error ends it,
+// and reports the sort to the graph's metrics and logger (see [Graph.observeSort]).
+// Entry points call each other's unexported implementations, so a sort shows up as a single span and is counted once.
+func (g *Graph[T]) startSort(name string) func(error) {
+	start := time.Now()
+	_, span := g.startSpan(context.Background(), name, Attribute{Key: "vertices", Value: len(g.nodes)})
+	return func(err error) {
+		span.End(err)
+		g.observeSort(start, err)
+	}
+}
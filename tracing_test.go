@@ -0,0 +1,126 @@
+package topologicalsort
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+type recordedSpan struct {
+	name   string
+	parent string
+	err    error
+}
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []recordedSpan
+}
+
+type spanKey struct{}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+	span   recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	parent, _ := ctx.Value(spanKey{}).(string)
+	return context.WithValue(ctx, spanKey{}, name), &recordingSpan{tracer: t, span: recordedSpan{name: name, parent: parent}}
+}
+
+func (s *recordingSpan) End(err error) {
+	s.span.err = err
+	s.tracer.mu.Lock()
+	s.tracer.spans = append(s.tracer.spans, s.span)
+	s.tracer.mu.Unlock()
+}
+
+func TestWithTracer(t *testing.T) {
+	tracer := &recordingTracer{}
+	g, err := NewGraphFromData(map[*GraphNode[string]][]string{
+		{Key: "one"}: {},
+		{Key: "two"}: {"one"},
+	}, WithTracer(tracer))
+	if err != nil {
+		t.Fatalf("NewGraphFromData: unexpected error %v", err)
+	}
+	g.TopologicalSort()
+
+	broken := errors.New("broken")
+	g.Execute(context.Background(), func(ctx context.Context, node *GraphNode[string]) error {
+		if node.Key == "two" {
+			return broken
+		}
+		return nil
+	}, ExecuteConfig[string]{})
+
+	sort.SliceStable(tracer.spans, func(i, j int) bool { return tracer.spans[i].name < tracer.spans[j].name })
+	got := make([]string, len(tracer.spans))
+	for i, s := range tracer.spans {
+		got[i] = s.parent + ">" + s.name
+		if (s.name == "topologicalsort.execute") != errors.Is(s.err, broken) && s.name != "topologicalsort.node" {
+			t.Errorf("span %s ended with error %v", s.name, s.err)
+		}
+	}
+	want := []string{
+		">topologicalsort.build",
+		">topologicalsort.execute",
+		"topologicalsort.execute>topologicalsort.node",
+		"topologicalsort.execute>topologicalsort.node",
+		">topologicalsort.sort",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("recorded spans = %v, want %v", got, want)
+	}
+}
+
+func TestWithTracer_SortEntryPoints(t *testing.T) {
+	tracer := &recordingTracer{}
+	g := NewGraphWithOptions[string](WithTracer(tracer))
+	for _, k := range []string{"libc", "gcc", "make"} {
+		g.RegisterVertex(k, "")
+		g.SetVertexPhase(k, "build")
+	}
+	g.AddEdge("gcc", "libc")
+
+	g.TopologicalSortFor("gcc")
+	g.TopologicalSortBestEffort()
+	g.TopologicalSortWithPreferences(Preference{Before: "make", After: "libc"})
+	g.TopologicalSortByPhase("build")
+	g.TopologicalSortByBlock()
+	g.Levels()
+	g.LevelsWithMaxWeight(1, nil)
+	g.LevelsWithMaxWidth(1)
+	g.SampleTopologicalOrder(rand.New(rand.NewSource(1)))
+
+	// every entry point shows up once, without spans for the sorts it runs internally
+	got := make([]string, len(tracer.spans))
+	for i, s := range tracer.spans {
+		got[i] = s.name
+	}
+	want := []string{
+		"topologicalsort.sort_for",
+		"topologicalsort.sort_best_effort",
+		"topologicalsort.sort_with_preferences",
+		"topologicalsort.sort_by_phase",
+		"topologicalsort.sort_by_block",
+		"topologicalsort.levels",
+		"topologicalsort.levels_with_max_weight",
+		"topologicalsort.levels_with_max_width",
+		"topologicalsort.sample",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("recorded spans = %v, want %v", got, want)
+	}
+
+	g.AddEdge("libc", "gcc")
+	tracer.spans = nil
+	if _, err := g.Levels(); err == nil || len(tracer.spans) != 1 || !errors.Is(tracer.spans[0].err, ErrCycle) {
+		t.Errorf("recorded spans = %+v, want one span ending with the cycle error", tracer.spans)
+	}
+}
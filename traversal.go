@@ -0,0 +1,79 @@
+package topologicalsort
+
+import "sort"
+
+// TraversalOrder decides the order depth-first sorts start from vertices and follow edges in, see [WithTraversalOrder]
+type TraversalOrder int
+
+const (
+	// InsertionOrder starts from vertices in the order they were registered, and follows edges in the order they were added. This is the default.
+	InsertionOrder TraversalOrder = iota
+	// KeyOrder starts from vertices, and follows edges, in key order. The sorted order and the back edge reported for a cycle then only depend
+	// on the graph's structure, not on how it was built, e.g. from iterating over a map. It costs sorting every adjacency list once per change.
+	KeyOrder
+)
+
+// WithTraversalOrder sets the order TopologicalSort, TopologicalSortFor and [Sorter] visit vertices in (by default, [InsertionOrder]).
+// Every order is a valid topological order; this only decides which one comes out when there's a choice, and which back edge a cycle error reports.
+func WithTraversalOrder(order TraversalOrder) Option {
+	return func(c *config) {
+		c.traversalOrder = order
+	}
+}
+
+// keyOrder is the graph's structure in key order, for depth-first searches with [KeyOrder]
+type keyOrder struct {
+	// roots holds every vertex ID, by key
+	roots []int32
+	// rank[id] is the position of id in roots
+	rank []int
+	// adjacency[id] holds the dependencies of id, by key
+	adjacency [][]int32
+}
+
+// prepareTraversal builds g.keyOrder if the graph traverses in [KeyOrder] and it's missing (every structural change drops it).
+// Depth-first sorts call it before they start.
+func (g *Graph[T]) prepareTraversal() {
+	if g.config.traversalOrder != KeyOrder || g.keyOrder != nil {
+		return
+	}
+	order := &keyOrder{
+		roots:     make([]int32, len(g.nodes)),
+		rank:      make([]int, len(g.nodes)),
+		adjacency: make([][]int32, len(g.nodes)),
+	}
+	for id := range g.nodes {
+		order.roots[id] = int32(id)
+	}
+	sort.Slice(order.roots, func(i, j int) bool {
+		return g.nodes[order.roots[i]].Key < g.nodes[order.roots[j]].Key
+	})
+	for i, id := range order.roots {
+		order.rank[id] = i
+	}
+	for id, deps := range g.adjacency {
+		sorted := make([]int32, len(deps))
+		copy(sorted, deps)
+		sort.Slice(sorted, func(i, j int) bool {
+			return order.rank[sorted[i]] < order.rank[sorted[j]]
+		})
+		order.adjacency[id] = sorted
+	}
+	g.keyOrder = order
+}
+
+// dfsRoot returns the vertex the i-th start of a depth-first sort begins at
+func (g *Graph[T]) dfsRoot(i int) int32 {
+	if g.keyOrder != nil {
+		return g.keyOrder.roots[i]
+	}
+	return int32(i)
+}
+
+// dfsDependencies returns the dependencies of id, in the order depth-first searches follow them
+func (g *Graph[T]) dfsDependencies(id int32) []int32 {
+	if g.keyOrder != nil {
+		return g.keyOrder.adjacency[id]
+	}
+	return g.adjacency[id]
+}
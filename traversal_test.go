@@ -0,0 +1,112 @@
+package topologicalsort
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWithTraversalOrder(t *testing.T) {
+	// the same graph, registered in two different orders
+	build := func(order TraversalOrder, keys []string, edges []Edge, opts ...Option) *Graph[string] {
+		graph := NewGraphWithOptions[string](append(opts, WithTraversalOrder(order))...)
+		for _, k := range keys {
+			if err := graph.RegisterVertex(k, k); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := graph.AddEdges(edges...); err != nil {
+			t.Fatal(err)
+		}
+		return graph
+	}
+	edges := []Edge{{Source: "d", Dest: "c"}, {Source: "d", Dest: "a"}, {Source: "b", Dest: "a"}}
+	reversedEdges := []Edge{{Source: "b", Dest: "a"}, {Source: "d", Dest: "a"}, {Source: "d", Dest: "c"}}
+
+	tests := []struct {
+		name  string
+		order TraversalOrder
+		opts  []Option
+		want  [2][]string
+	}{
+		{
+			name:  "Insertion order depends on how the graph was built",
+			order: InsertionOrder,
+			want:  [2][]string{{"a", "b", "c", "d"}, {"a", "c", "d", "b"}},
+		},
+		{
+			name:  "Key order doesn't",
+			order: KeyOrder,
+			want:  [2][]string{{"a", "b", "c", "d"}, {"a", "b", "c", "d"}},
+		},
+		{
+			// the graph is one component, which a parallel sort sorts frontier by frontier
+			name:  "Key order with parallel sorting",
+			order: KeyOrder,
+			opts:  []Option{WithParallelism(4)},
+			want:  [2][]string{{"a", "c", "b", "d"}, {"a", "c", "b", "d"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graphs := []*Graph[string]{
+				build(tt.order, []string{"a", "b", "c", "d"}, edges, tt.opts...),
+				build(tt.order, []string{"d", "c", "b", "a"}, reversedEdges, tt.opts...),
+			}
+			for i, graph := range graphs {
+				got, err := graph.TopologicalSort()
+				if err != nil {
+					t.Fatalf("TopologicalSort returned unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(got, tt.want[i]) {
+					t.Errorf("TopologicalSort() of graph %d = %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWithTraversalOrderCycleError(t *testing.T) {
+	// a graph with two cycles, built from a map in whatever order the map iterates in
+	adjacency := map[string][]string{
+		"a": {"b"},
+		"b": {"a"},
+		"x": {"y"},
+		"y": {"x"},
+	}
+	for i := 0; i < 20; i++ {
+		graph := NewGraphWithOptions[string](WithTraversalOrder(KeyOrder))
+		for k := range adjacency {
+			graph.RegisterVertex(k, k)
+		}
+		for k, deps := range adjacency {
+			for _, d := range deps {
+				graph.AddEdge(k, d)
+			}
+		}
+
+		_, err := graph.TopologicalSort()
+		var graphErr *GraphError
+		if !errors.As(err, &graphErr) || !errors.Is(err, ErrCycle) {
+			t.Fatalf("TopologicalSort returned %v, want a cycle error", err)
+		}
+		if graphErr.Source != "b" || graphErr.Dest != "a" {
+			t.Fatalf("TopologicalSort reported back edge %s -> %s, want b -> a every time", graphErr.Source, graphErr.Dest)
+		}
+	}
+}
+
+func TestWithTraversalOrderAfterChange(t *testing.T) {
+	graph := NewGraphWithOptions[string](WithTraversalOrder(KeyOrder))
+	for _, k := range []string{"b", "a"} {
+		graph.RegisterVertex(k, k)
+	}
+	if got, _ := graph.TopologicalSort(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("TopologicalSort() = %v, want [a b]", got)
+	}
+	// the key-ordered structure is rebuilt after a change
+	graph.AddEdge("a", "b")
+	if got, _ := graph.TopologicalSort(); !reflect.DeepEqual(got, []string{"b", "a"}) {
+		t.Errorf("TopologicalSort() after adding an edge = %v, want [b a]", got)
+	}
+}
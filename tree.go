@@ -0,0 +1,81 @@
+package topologicalsort
+
+import (
+	"fmt"
+	"io"
+)
+
+// RenderTree writes the dependencies of root to w as an indented tree, for a quick look in the terminal:
+//
+//	app
+//	├── auth
+//	│   └── db
+//	└── cache
+//	    └── db (shown above)
+//
+// Dependencies are listed in key order. A vertex which was already expanded is marked "(shown above)" instead of being expanded again,
+// an edge back to a vertex on the current branch is marked "(cycle)", and a vertex with dependencies beyond maxDepth levels below root is marked "(...)".
+// maxDepth <= 0 means no limit. An empty root renders a tree for every one of [Graph.Roots].
+func (g *Graph[T]) RenderTree(root string, w io.Writer, maxDepth int) error {
+	roots := []string{root}
+	if root == "" {
+		roots = g.Roots()
+	} else if !g.HasVertex(root) {
+		return vertexError(root, "attempted to render tree of unregistered vertex %s", root)
+	} else {
+		roots[0] = g.canonicalKey(root)
+	}
+
+	r := treeRenderer[T]{graph: g, w: w, maxDepth: maxDepth, expanded: make(map[string]bool), onBranch: make(map[string]bool)}
+	for _, k := range roots {
+		r.render(k, "", "", 0)
+		if r.err != nil {
+			return r.err
+		}
+	}
+	return nil
+}
+
+type treeRenderer[T any] struct {
+	graph    *Graph[T]
+	w        io.Writer
+	maxDepth int
+	expanded map[string]bool
+	onBranch map[string]bool
+	err      error
+}
+
+// render writes the line for k, prefixed by linePrefix, and then its dependencies, each prefixed by childPrefix plus their own branch
+func (r *treeRenderer[T]) render(k, linePrefix, childPrefix string, depth int) {
+	deps := sortedUnique(r.graph.dependencyKeys(k))
+	marker := ""
+	switch {
+	case r.onBranch[k]:
+		marker = " (cycle)"
+	case r.expanded[k] && len(deps) > 0:
+		marker = " (shown above)"
+	case r.maxDepth > 0 && depth >= r.maxDepth && len(deps) > 0:
+		marker = " (...)"
+	}
+	if _, err := fmt.Fprintf(r.w, "%s%s%s\n", linePrefix, k, marker); err != nil {
+		r.err = err
+		return
+	}
+	if marker != "" {
+		return
+	}
+
+	r.expanded[k] = true
+	r.onBranch[k] = true
+	for i, dep := range deps {
+		if i == len(deps)-1 {
+			r.render(dep, childPrefix+"└── ", childPrefix+"    ", depth+1)
+		} else {
+			r.render(dep, childPrefix+"├── ", childPrefix+"│   ", depth+1)
+		}
+		if r.err != nil {
+			return
+		}
+	}
+	r.onBranch[k] = false
+}
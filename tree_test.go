@@ -0,0 +1,81 @@
+package topologicalsort
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGraph_RenderTree(t *testing.T) {
+	graph := graphWithVerticesDUMMYDATA(map[string][]string{
+		"app":   {"auth", "cache"},
+		"auth":  {"db"},
+		"cache": {"db"},
+		"db":    {"disk"},
+		"disk":  {},
+		"cron":  {"jobs"},
+		"jobs":  {"cron"},
+	}, "")
+
+	tests := []struct {
+		name     string
+		root     string
+		maxDepth int
+		want     string
+	}{
+		{
+			name: "Whole tree",
+			root: "app",
+			want: `app
+├── auth
+│   └── db
+│       └── disk
+└── cache
+    └── db (shown above)
+`,
+		},
+		{
+			name:     "Depth limit",
+			root:     "app",
+			maxDepth: 2,
+			want: `app
+├── auth
+│   └── db (...)
+└── cache
+    └── db (...)
+`,
+		},
+		{
+			name: "Every root",
+			want: `app
+├── auth
+│   └── db
+│       └── disk
+└── cache
+    └── db (shown above)
+`,
+		},
+		{
+			name: "Cycle",
+			root: "cron",
+			want: `cron
+└── jobs
+    └── cron (cycle)
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := graph.RenderTree(tt.root, &b, tt.maxDepth); err != nil {
+				t.Fatalf("Graph.RenderTree() unexpected error %v", err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("Graph.RenderTree() =\n%s\nwant\n%s", b.String(), tt.want)
+			}
+		})
+	}
+
+	if err := graph.RenderTree("web", &strings.Builder{}, 0); err == nil {
+		t.Errorf("Graph.RenderTree() expected an error for an unregistered vertex")
+	}
+}
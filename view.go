@@ -0,0 +1,129 @@
+package topologicalsort
+
+import "fmt"
+
+// View is a read-only, filtered view of a graph, see [Graph.FilterView].
+// It doesn't copy anything, so it reflects later changes to the graph.
+type View[T any] struct {
+	graph      *Graph[T]
+	vertexPred func(*GraphNode[T]) bool
+	edgePred   func(src, dst string) bool
+}
+
+// FilterView returns a read-only view of the graph containing only the vertices for which vertexPred returns true,
+// and only the edges between them for which edgePred returns true. A nil predicate keeps everything.
+func (g *Graph[T]) FilterView(vertexPred func(*GraphNode[T]) bool, edgePred func(src, dst string) bool) *View[T] {
+	if vertexPred == nil {
+		vertexPred = func(*GraphNode[T]) bool { return true }
+	}
+	if edgePred == nil {
+		edgePred = func(src, dst string) bool { return true }
+	}
+	return &View[T]{graph: g, vertexPred: vertexPred, edgePred: edgePred}
+}
+
+// HasVertex reports whether key is a vertex of the view
+func (v *View[T]) HasVertex(key string) bool {
+	node, ok := v.graph.Vertex(key)
+	return ok && v.vertexPred(node)
+}
+
+// Keys returns the sorted keys of the view's vertices
+func (v *View[T]) Keys() []string {
+	keys := make([]string, 0)
+	for _, k := range v.graph.sortedVertexKeys() {
+		if v.vertexPred(v.graph.vertex(k)) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Dependencies returns the vertices key directly depends on within the view
+func (v *View[T]) Dependencies(key string) ([]*GraphNode[T], error) {
+	if !v.HasVertex(key) {
+		return []*GraphNode[T]{}, fmt.Errorf("vertex %s is not part of the view", key)
+	}
+	return v.dependencies(key), nil
+}
+
+func (v *View[T]) dependencies(key string) []*GraphNode[T] {
+	deps := make([]*GraphNode[T], 0)
+	for _, dest := range v.graph.dependencyNodes(key) {
+		if v.vertexPred(dest) && v.edgePred(key, dest.Key) {
+			deps = append(deps, dest)
+		}
+	}
+	return deps
+}
+
+// Edges returns the view's edges, sorted like [Graph.Edges]
+func (v *View[T]) Edges() []Edge {
+	edges := make([]Edge, 0)
+	for _, e := range v.graph.Edges() {
+		if v.HasVertex(e.Source) && v.HasVertex(e.Dest) && v.edgePred(e.Source, e.Dest) {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// TopologicalSort sorts the view's vertices like [Graph.TopologicalSort], without touching the graph's own sorted order.
+// Cycles are only reported if they are part of the view.
+func (v *View[T]) TopologicalSort() ([]string, error) {
+	nodes, err := v.sortedNodes()
+	if err != nil {
+		return []string{}, err
+	}
+	keys := make([]string, len(nodes))
+	for i, n := range nodes {
+		keys[i] = n.Key
+	}
+	return keys, nil
+}
+
+// Walk calls fn for every vertex of the view in topological order, passing its direct dependencies within the view, like [Graph.Walk]
+func (v *View[T]) Walk(fn func(node *GraphNode[T], deps []*GraphNode[T]) error) error {
+	nodes, err := v.sortedNodes()
+	if err != nil {
+		return err
+	}
+	for _, n := range nodes {
+		err = fn(n, v.dependencies(n.Key))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedNodes sorts the view with the graph's iterative depth-first search, hiding the vertices and edges outside the view,
+// so it visits vertices in the same order as [Graph.TopologicalSort] (following [WithTraversalOrder])
+func (v *View[T]) sortedNodes() ([]*GraphNode[T], error) {
+	g := v.graph
+	inView := make([]bool, len(g.nodes))
+	for id, node := range g.nodes {
+		inView[id] = v.vertexPred(node)
+	}
+
+	var scratch dfsScratch
+	scratch.reset(len(g.nodes))
+	scratch.skip = func(source, dest int32) bool {
+		return !inView[dest] || !v.edgePred(g.nodes[source].Key, g.nodes[dest].Key)
+	}
+	g.prepareTraversal()
+
+	sorted := make([]*GraphNode[T], 0)
+	for i := range g.nodes {
+		id := g.dfsRoot(i)
+		if !inView[id] {
+			continue
+		}
+		var err error
+		sorted, err = g.depthFirstOrder(id, &scratch, sorted)
+		if err != nil {
+			return []*GraphNode[T]{}, err
+		}
+	}
+	return sorted, nil
+}
@@ -0,0 +1,107 @@
+package topologicalsort
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type service struct {
+	enabled bool
+}
+
+func viewTestGraph() *Graph[service] {
+	g := NewGraph(service{})
+	g.RegisterVertex("web", service{enabled: true})
+	g.RegisterVertex("api", service{enabled: true})
+	g.RegisterVertex("legacy", service{enabled: false})
+	g.RegisterVertex("db", service{enabled: true})
+	g.AddEdge("web", "api")
+	g.AddEdge("web", "legacy")
+	g.AddEdge("api", "db")
+	g.AddEdge("legacy", "db")
+	// the cycle only involves the disabled service
+	g.AddEdge("db", "legacy")
+	return g
+}
+
+func TestView_TopologicalSort(t *testing.T) {
+	g := viewTestGraph()
+	enabled := func(n *GraphNode[service]) bool { return n.Data.enabled }
+
+	if _, err := g.TopologicalSort(); err == nil {
+		t.Fatalf("Graph.TopologicalSort() expected the full graph to have a cycle")
+	}
+
+	view := g.FilterView(enabled, nil)
+	if got, want := view.Keys(), []string{"api", "db", "web"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("View.Keys() = %v, want %v", got, want)
+	}
+	got, err := view.TopologicalSort()
+	if err != nil {
+		t.Fatalf("View.TopologicalSort() unexpected error %v", err)
+	}
+	if want := []string{"db", "api", "web"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("View.TopologicalSort() = %v, want %v", got, want)
+	}
+
+	noDB := g.FilterView(enabled, func(src, dst string) bool { return dst != "db" })
+	want := []Edge{{Source: "web", Dest: "api"}}
+	if got := noDB.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("View.Edges() = %v, want %v", got, want)
+	}
+
+	everything := g.FilterView(nil, nil)
+	if _, err := everything.TopologicalSort(); err == nil {
+		t.Errorf("View.TopologicalSort() expected an unfiltered view to have a cycle")
+	}
+}
+
+func TestView_Walk(t *testing.T) {
+	view := viewTestGraph().FilterView(func(n *GraphNode[service]) bool { return n.Data.enabled }, nil)
+
+	deps := make(map[string]int)
+	err := view.Walk(func(node *GraphNode[service], d []*GraphNode[service]) error {
+		deps[node.Key] = len(d)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("View.Walk() unexpected error %v", err)
+	}
+	if want := map[string]int{"db": 0, "api": 1, "web": 1}; !reflect.DeepEqual(deps, want) {
+		t.Errorf("View.Walk() dependency counts = %v, want %v", deps, want)
+	}
+	if _, err := view.Dependencies("legacy"); err == nil {
+		t.Errorf("View.Dependencies() expected an error for a filtered vertex")
+	}
+}
+
+func TestView_TopologicalSort_LikeGraph(t *testing.T) {
+	for _, order := range []TraversalOrder{InsertionOrder, KeyOrder} {
+		g := NewGraphWithOptions[int](WithTraversalOrder(order))
+		// a long chain, registered backwards, with every tenth vertex also depending on a vertex outside the view
+		const length = 100000
+		g.RegisterVertex("outside", -1)
+		for i := length - 1; i >= 0; i-- {
+			g.RegisterVertex(fmt.Sprintf("v%06d", i), i)
+		}
+		for i := 1; i < length; i++ {
+			g.AddEdge(fmt.Sprintf("v%06d", i), fmt.Sprintf("v%06d", i-1))
+			if i%10 == 0 {
+				g.AddEdge(fmt.Sprintf("v%06d", i), "outside")
+			}
+		}
+
+		view := g.FilterView(func(n *GraphNode[int]) bool { return n.Data >= 0 }, nil)
+		got, err := view.TopologicalSort()
+		if err != nil {
+			t.Fatalf("View.TopologicalSort() unexpected error %v", err)
+		}
+		want, _ := g.TopologicalSort()
+		want = slices.DeleteFunc(want, func(k string) bool { return k == "outside" })
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("View.TopologicalSort() with traversal order %v doesn't match Graph.TopologicalSort() without the filtered vertex", order)
+		}
+	}
+}
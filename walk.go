@@ -0,0 +1,45 @@
+package topologicalsort
+
+// Walk sorts the graph and calls fn for every vertex in topological order, passing the vertex's direct dependencies (which have all been visited already).
+// It stops at, and returns, the first error returned by fn.
+func (g *Graph[T]) Walk(fn func(node *GraphNode[T], deps []*GraphNode[T]) error) error {
+	_, err := g.TopologicalSort()
+	if err != nil {
+		return err
+	}
+
+	for _, node := range g.topoSortedOrder {
+		// dependencyNodes hands out a fresh slice, so fn can't mess with the adjacency list
+		deps := g.dependencyNodes(node.Key)
+
+		err = fn(node, deps)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WalkReverse sorts the graph and calls fn for every vertex in reverse topological order, passing the vertex's direct dependents (which have all been visited already).
+// This is the order for teardown: consumers are stopped before the things they depend on. It stops at, and returns, the first error returned by fn.
+func (g *Graph[T]) WalkReverse(fn func(node *GraphNode[T], dependents []*GraphNode[T]) error) error {
+	_, err := g.TopologicalSort()
+	if err != nil {
+		return err
+	}
+	dependentKeys := g.dependentsOf()
+
+	for i := len(g.topoSortedOrder) - 1; i >= 0; i-- {
+		node := g.topoSortedOrder[i]
+		dependents := make([]*GraphNode[T], len(dependentKeys[node.Key]))
+		for j, k := range dependentKeys[node.Key] {
+			dependents[j] = g.vertex(k)
+		}
+
+		err = fn(node, dependents)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package topologicalsort
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGraph_Walk(t *testing.T) {
+	g := graphWithVerticesDUMMYDATA(map[string][]string{
+		"build-essential": {"make", "gcc"},
+		"make":            {"gcc"},
+		"gcc":             {"libc"},
+		"libc":            {},
+	}, "")
+
+	visited := make(map[string]bool)
+	order := make([]string, 0)
+	err := g.Walk(func(node *GraphNode[string], deps []*GraphNode[string]) error {
+		for _, d := range deps {
+			if !visited[d.Key] {
+				t.Errorf("Graph.Walk() visited %s before its dependency %s", node.Key, d.Key)
+			}
+		}
+		visited[node.Key] = true
+		order = append(order, node.Key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Graph.Walk() unexpected error %v", err)
+	}
+	if want := []string{"libc", "gcc", "make", "build-essential"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("Graph.Walk() order = %v, want %v", order, want)
+	}
+
+	stop := errors.New("stop")
+	count := 0
+	err = g.Walk(func(node *GraphNode[string], deps []*GraphNode[string]) error {
+		count++
+		return stop
+	})
+	if !errors.Is(err, stop) || count != 1 {
+		t.Errorf("Graph.Walk() should stop at the first error, got error %v after %d calls", err, count)
+	}
+
+	cyclic := graphWithVerticesDUMMYDATA(map[string][]string{"one": {"two"}, "two": {"one"}}, "")
+	err = cyclic.Walk(func(node *GraphNode[string], deps []*GraphNode[string]) error { return nil })
+	if err == nil {
+		t.Errorf("Graph.Walk() expected an error for a graph with a cycle")
+	}
+}
+
+func TestGraph_WalkReverse(t *testing.T) {
+	g := graphWithVerticesDUMMYDATA(map[string][]string{
+		"build-essential": {"make", "gcc"},
+		"make":            {"gcc"},
+		"gcc":             {"libc"},
+		"libc":            {},
+	}, "")
+
+	stopped := make(map[string]bool)
+	order := make([]string, 0)
+	err := g.WalkReverse(func(node *GraphNode[string], dependents []*GraphNode[string]) error {
+		for _, d := range dependents {
+			if !stopped[d.Key] {
+				t.Errorf("Graph.WalkReverse() visited %s before its dependent %s", node.Key, d.Key)
+			}
+		}
+		stopped[node.Key] = true
+		order = append(order, node.Key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Graph.WalkReverse() unexpected error %v", err)
+	}
+	if want := []string{"build-essential", "make", "gcc", "libc"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("Graph.WalkReverse() order = %v, want %v", order, want)
+	}
+
+	stop := errors.New("stop")
+	err = g.WalkReverse(func(node *GraphNode[string], dependents []*GraphNode[string]) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Errorf("Graph.WalkReverse() error = %v, want %v", err, stop)
+	}
+}
@@ -0,0 +1,139 @@
+package topologicalsort
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// Width returns the width of the graph, the largest number of vertices which are all independent of each other (none depends on another,
+// directly or transitively), along with such a set of vertices (a maximum antichain), sorted. It's the most work that can ever run at once,
+// e.g. for sizing a worker pool; it can be larger than the biggest of [Graph.Levels]. It returns an error if the graph contains a cycle.
+// It's exact (by Dilworth's theorem, via a matching over the transitive closure), which costs O(n³) time and O(n²) bits of memory for n vertices.
+func (g *Graph[T]) Width() (int, []string, error) {
+	d, err := g.dilworth()
+	if err != nil {
+		return 0, []string{}, err
+	}
+
+	// König's theorem: walk alternating paths from the unmatched later sides; a vertex whose later side is reached
+	// but whose earlier side isn't is in no minimum vertex cover, so those vertices are independent
+	reachedLater := make([]bool, len(g.nodes))
+	reachedEarlier := make([]bool, len(g.nodes))
+	queue := make([]int, 0)
+	for v := range g.nodes {
+		if d.predecessor[v] < 0 {
+			reachedLater[v] = true
+			queue = append(queue, v)
+		}
+	}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		d.reach[v].each(func(u int) {
+			if reachedEarlier[u] {
+				return
+			}
+			reachedEarlier[u] = true
+			if next := d.successor[u]; next >= 0 && !reachedLater[next] {
+				reachedLater[next] = true
+				queue = append(queue, int(next))
+			}
+		})
+	}
+
+	antichain := make([]string, 0)
+	for v, node := range g.nodes {
+		if reachedLater[v] && !reachedEarlier[v] {
+			antichain = append(antichain, node.Key)
+		}
+	}
+	sort.Strings(antichain)
+	return len(antichain), antichain, nil
+}
+
+// dilworthMatching is a maximum matching between every vertex and the vertices it depends on transitively:
+// if u is matched to v, v can directly follow u in a chain. Every unmatched vertex starts a chain of a minimum chain decomposition.
+type dilworthMatching struct {
+	// reach[v] holds the IDs of the vertices v depends on, directly or transitively
+	reach []bitset
+	// predecessor[v] is the vertex matched to come right before v, and successor[u] the one matched to come right after u; -1 if none
+	predecessor []int32
+	successor   []int32
+}
+
+// dilworth computes the transitive closure of the graph and a maximum matching over it. It returns an error if the graph contains a cycle.
+func (g *Graph[T]) dilworth() (dilworthMatching, error) {
+	order, err := g.TopologicalSort()
+	if err != nil {
+		return dilworthMatching{}, err
+	}
+
+	d := dilworthMatching{
+		reach:       make([]bitset, len(g.nodes)),
+		predecessor: make([]int32, len(g.nodes)),
+		successor:   make([]int32, len(g.nodes)),
+	}
+	for _, k := range order {
+		v, _ := g.id(k)
+		d.reach[v] = newBitset(len(g.nodes))
+		for _, dep := range g.adjacency[v] {
+			d.reach[v].set(int(dep))
+			d.reach[v].or(d.reach[dep])
+		}
+	}
+	for i := range d.predecessor {
+		d.predecessor[i] = -1
+		d.successor[i] = -1
+	}
+
+	// Kuhn's augmenting paths, from every vertex in turn
+	for v := range g.nodes {
+		visited := make([]bool, len(g.nodes))
+		d.augment(v, visited)
+	}
+	return d, nil
+}
+
+// augment looks for an augmenting path starting at the later side of v, and flips it if it finds one
+func (d *dilworthMatching) augment(v int, visited []bool) bool {
+	found := false
+	d.reach[v].each(func(u int) {
+		if found || visited[u] {
+			return
+		}
+		visited[u] = true
+		if d.successor[u] < 0 || d.augment(int(d.successor[u]), visited) {
+			d.successor[u] = int32(v)
+			d.predecessor[v] = int32(u)
+			found = true
+		}
+	})
+	return found
+}
+
+// bitset is a fixed-size set of small non-negative integers
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << (i % 64)
+}
+
+func (b bitset) or(other bitset) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
+// each calls fn with every member of b, in increasing order
+func (b bitset) each(fn func(int)) {
+	for i, word := range b {
+		for word != 0 {
+			fn(i*64 + bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package topologicalsort
+
+import (
+	"errors"
+	"math/bits"
+	"math/rand"
+	"testing"
+)
+
+func TestGraph_Width(t *testing.T) {
+	tests := []struct {
+		name           string
+		adjacency_list map[string][]string
+		wantWidth      int
+	}{
+		{
+			name:           "Empty graph",
+			adjacency_list: map[string][]string{},
+			wantWidth:      0,
+		},
+		{
+			name:           "A chain has width 1",
+			adjacency_list: map[string][]string{"c": {"b"}, "b": {"a"}, "a": {}},
+			wantWidth:      1,
+		},
+		{
+			name: "Package manager example from cmd",
+			adjacency_list: map[string][]string{
+				"build-essential": {"make", "gcc"},
+				"make":            {"gcc"},
+				"gcc":             {"libc"},
+				"libc":            {},
+			},
+			wantWidth: 1,
+		},
+		{
+			// the levels are {a, x}, {b}, {c, y}, {d}, but x is independent of c and y too
+			name: "Width larger than every level",
+			adjacency_list: map[string][]string{
+				"a": {},
+				"b": {"a"},
+				"c": {"b"},
+				"d": {"c"},
+				"x": {},
+				"y": {"b"},
+			},
+			wantWidth: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphWithVerticesDUMMYDATA(tt.adjacency_list, "")
+			width, antichain, err := g.Width()
+			if err != nil {
+				t.Fatalf("Graph.Width() unexpected error: %v", err)
+			}
+			if width != tt.wantWidth {
+				t.Errorf("Graph.Width() width = %d, want %d", width, tt.wantWidth)
+			}
+			if !isAntichain(g, antichain) || len(antichain) != width {
+				t.Errorf("Graph.Width() returned %v, which isn't an antichain of %d vertices", antichain, width)
+			}
+		})
+	}
+}
+
+func TestGraph_Width_MatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		const vertices = 10
+		g := NewGraph(0)
+		for v := 0; v < vertices; v++ {
+			g.RegisterVertex(string(rune('a'+v)), v)
+		}
+		// edges only point at lower indices, so there's no cycle
+		for e := 0; e < rng.Intn(20); e++ {
+			a, b := rng.Intn(vertices), rng.Intn(vertices)
+			if a > b {
+				g.AddEdge(string(rune('a'+a)), string(rune('a'+b)))
+			}
+		}
+
+		// try every subset of vertices
+		want := 0
+		for set := 0; set < 1<<vertices; set++ {
+			keys := make([]string, 0)
+			for v := 0; v < vertices; v++ {
+				if set&(1<<v) != 0 {
+					keys = append(keys, string(rune('a'+v)))
+				}
+			}
+			if bits.OnesCount(uint(set)) > want && isAntichain(g, keys) {
+				want = len(keys)
+			}
+		}
+
+		width, antichain, err := g.Width()
+		if err != nil {
+			t.Fatalf("Graph.Width() unexpected error: %v", err)
+		}
+		if width != want || !isAntichain(g, antichain) {
+			t.Fatalf("Graph.Width() = %d, %v, want width %d for edges %v", width, antichain, want, g.Edges())
+		}
+	}
+}
+
+func TestGraph_Width_Cycle(t *testing.T) {
+	g := graphWithVerticesDUMMYDATA(map[string][]string{"a": {"b"}, "b": {"a"}}, "")
+	if _, _, err := g.Width(); !errors.Is(err, ErrCycle) {
+		t.Errorf("Graph.Width() error = %v, want an error wrapping ErrCycle", err)
+	}
+}
+
+// isAntichain reports whether no vertex of keys depends on another one, directly or transitively
+func isAntichain[T any](g *Graph[T], keys []string) bool {
+	for _, a := range keys {
+		descendants, _ := g.Descendants(a, 0)
+		for _, group := range descendants {
+			for _, d := range group {
+				for _, b := range keys {
+					if d == b {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}